@@ -0,0 +1,173 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"container/list"
+	"fmt"
+	"reflect"
+)
+
+// AddMapLike adds a comparison function for a map-like type T that compares its
+// values like maps, i.e. by their entries, instead of by their internal representation.
+//
+// iterate has to be of the signature func(T, func(K, V) bool), calling the given
+// function for each entry of a T until it returns false. As this is the signature
+// of Range methods, method expressions can often be used directly:
+//
+//	c.AddMapLike((*sync.Map).Range)
+//
+// K has to be a valid map key type.
+func (c Comparisons) AddMapLike(iterate interface{}) error {
+	fv := reflect.ValueOf(iterate)
+	if !fv.IsValid() {
+		return fmt.Errorf("expected func, got: nil")
+	}
+	ft := fv.Type()
+	if ft.Kind() != reflect.Func || ft.NumIn() != 2 || ft.NumOut() != 0 {
+		return fmt.Errorf("expected func(T, func(K, V) bool), got: %v", ft)
+	}
+	yt := ft.In(1)
+	if yt.Kind() != reflect.Func || yt.NumIn() != 2 || yt.NumOut() != 1 || yt.Out(0).Kind() != reflect.Bool {
+		return fmt.Errorf("expected func(T, func(K, V) bool), got: %v", ft)
+	}
+	kt, vt := yt.In(0), yt.In(1)
+	if !kt.Comparable() {
+		return fmt.Errorf("expected comparable key type, got: %v", kt)
+	}
+
+	mt := reflect.MapOf(kt, vt)
+	c.addAdapted(ft.In(0), "AddMapLike", func(v reflect.Value) reflect.Value {
+		m := reflect.MakeMap(mt)
+		fv.Call([]reflect.Value{v, reflect.MakeFunc(yt, func(args []reflect.Value) []reflect.Value {
+			m.SetMapIndex(args[0], args[1])
+			return []reflect.Value{reflect.ValueOf(true)}
+		})})
+		return m
+	})
+	return nil
+}
+
+// AddSliceLike adds a comparison function for a list-like type T that compares its
+// values like slices, i.e. element-wise, instead of by their internal representation.
+//
+// length has to be of the signature func(T) int, returning the number of elements,
+// and index of the signature func(T, int) E, returning the element at the given index:
+//
+//	c.AddSliceLike((*Ring).Len, (*Ring).At)
+func (c Comparisons) AddSliceLike(length, index interface{}) error {
+	lv, iv := reflect.ValueOf(length), reflect.ValueOf(index)
+	if !lv.IsValid() || !iv.IsValid() {
+		return fmt.Errorf("expected funcs, got: nil")
+	}
+	lt, it := lv.Type(), iv.Type()
+	if lt.Kind() != reflect.Func || lt.NumIn() != 1 || lt.NumOut() != 1 || lt.Out(0) != intType {
+		return fmt.Errorf("expected length func(T) int, got: %v", lt)
+	}
+	t := lt.In(0)
+	if it.Kind() != reflect.Func || it.NumIn() != 2 || it.NumOut() != 1 || it.In(0) != t || it.In(1) != intType {
+		return fmt.Errorf("expected index func(%v, int) E, got: %v", t, it)
+	}
+
+	st := reflect.SliceOf(it.Out(0))
+	c.addAdapted(t, "AddSliceLike", func(v reflect.Value) reflect.Value {
+		n := int(lv.Call([]reflect.Value{v})[0].Int())
+		s := reflect.MakeSlice(st, n, n)
+		for i := 0; i < n; i++ {
+			s.Index(i).Set(iv.Call([]reflect.Value{v, reflect.ValueOf(i)})[0])
+		}
+		return s
+	})
+	return nil
+}
+
+// AddLinked adds a comparison function for a linked node type N (usually a pointer type)
+// that compares nodes by the sequence of values of the nodes linked from them.
+//
+// next has to be of the signature func(N) N, returning the node following the given one
+// or the zero N (e.g. nil) at the end, and value of the signature func(N) E, returning the
+// value of a node. Nodes linked cyclically are compared by the values of the nodes up to
+// the first repeated node, then by the position of the node the cycle starts at. Nodes
+// linked acyclically are less than cyclic ones with the same values.
+//
+//	c.AddLinked(func(n *Node) *Node { return n.Next }, func(n *Node) int { return n.Value })
+func (c Comparisons) AddLinked(next, value interface{}) error {
+	nv, vv := reflect.ValueOf(next), reflect.ValueOf(value)
+	if !nv.IsValid() || !vv.IsValid() {
+		return fmt.Errorf("expected funcs, got: nil")
+	}
+	nt, vt := nv.Type(), vv.Type()
+	if nt.Kind() != reflect.Func || nt.NumIn() != 1 || nt.NumOut() != 1 || nt.In(0) != nt.Out(0) {
+		return fmt.Errorf("expected next func(N) N, got: %v", nt)
+	}
+	t := nt.In(0)
+	if !t.Comparable() {
+		return fmt.Errorf("expected comparable node type, got: %v", t)
+	}
+	if vt.Kind() != reflect.Func || vt.NumIn() != 1 || vt.NumOut() != 1 || vt.In(0) != t {
+		return fmt.Errorf("expected value func(%v) E, got: %v", t, vt)
+	}
+
+	st := reflect.SliceOf(vt.Out(0))
+	// linked returns the values of the nodes linked from v up to the first repeated node
+	// and the position of that node, if any, or -1.
+	linked := func(v reflect.Value) (reflect.Value, int) {
+		s := reflect.MakeSlice(st, 0, 0)
+		seen := make(map[interface{}]int)
+		for n := v; !n.IsZero(); n = nv.Call([]reflect.Value{n})[0] {
+			if i, ok := seen[n.Interface()]; ok {
+				return s, i
+			}
+			seen[n.Interface()] = s.Len()
+			s = reflect.Append(s, vv.Call([]reflect.Value{n})[0])
+		}
+		return s, -1
+	}
+	c.addTraversalFunc(t, "AddLinked", func(tr *traversal, v1, v2 reflect.Value, depth int) (int, error) {
+		s1, cycle1 := linked(v1)
+		s2, cycle2 := linked(v2)
+		if res, err := tr.deepValueCompare(s1, s2, depth+1); res != 0 || err != nil {
+			return res, err
+		}
+		return compareInt64(int64(cycle1), int64(cycle2)), nil
+	})
+	return nil
+}
+
+var listType = reflect.TypeOf((*list.List)(nil))
+
+// AddList adds a comparison function for lists of the container/list package that
+// compares them by the sequence of their element values. A nil list is equal to an
+// empty one.
+func (c Comparisons) AddList() {
+	c.addAdapted(listType, "AddList", func(v reflect.Value) reflect.Value {
+		var values []interface{}
+		if l := v.Interface().(*list.List); l != nil {
+			for e := l.Front(); e != nil; e = e.Next() {
+				values = append(values, e.Value)
+			}
+		}
+		return reflect.ValueOf(values)
+	})
+}
+
+// addAdapted adds a comparison function for values of type t that compares them by
+// the values they are adapted to, with the comparison functions and options of the
+// calling traversal. name names the comparison function in reports.
+func (c Comparisons) addAdapted(t reflect.Type, name string, adapt func(reflect.Value) reflect.Value) {
+	c.addTraversalFunc(t, name, func(tr *traversal, v1, v2 reflect.Value, depth int) (int, error) {
+		return tr.deepValueCompare(adapt(v1), adapt(v2), depth+1)
+	})
+}
@@ -0,0 +1,219 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"container/list"
+	"sync"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func syncMap(entries map[string]int) *sync.Map {
+	m := &sync.Map{}
+	for k, v := range entries {
+		m.Store(k, v)
+	}
+	return m
+}
+
+// OrderedMap is a map-like type remembering the insertion order of its keys.
+type OrderedMap struct {
+	keys   []string
+	values map[string]int
+}
+
+func (m *OrderedMap) Set(k string, v int) *OrderedMap {
+	if m.values == nil {
+		m.values = make(map[string]int)
+	}
+	if _, ok := m.values[k]; !ok {
+		m.keys = append(m.keys, k)
+	}
+	m.values[k] = v
+	return m
+}
+
+func (m *OrderedMap) Range(f func(k string, v int) bool) {
+	for _, k := range m.keys {
+		if !f(k, m.values[k]) {
+			return
+		}
+	}
+}
+
+// Ring is a fixed-size ring buffer.
+type Ring struct {
+	start int
+	items []int
+}
+
+func NewRing(items ...int) *Ring {
+	return &Ring{items: items}
+}
+
+// Rotate rotates the ring without changing the sequence of its items.
+func (r *Ring) Rotate(n int) *Ring {
+	items := make([]int, len(r.items))
+	for i := range items {
+		items[(i+n)%len(items)] = r.At(i)
+	}
+	return &Ring{start: n % len(items), items: items}
+}
+
+func (r *Ring) Len() int {
+	return len(r.items)
+}
+
+func (r *Ring) At(i int) int {
+	return r.items[(r.start+i)%len(r.items)]
+}
+
+// Link is a node of a singly linked list.
+type Link struct {
+	Value int
+	Next  *Link
+}
+
+func newLinks(values ...int) *Link {
+	var head *Link
+	for i := len(values) - 1; i >= 0; i-- {
+		head = &Link{Value: values[i], Next: head}
+	}
+	return head
+}
+
+func newList(values ...interface{}) *list.List {
+	l := list.New()
+	for _, v := range values {
+		l.PushBack(v)
+	}
+	return l
+}
+
+var _ = Describe("Adapters", func() {
+	Describe("AddMapLike", func() {
+		It("should compare sync.Maps by their entries", func() {
+			c := make(Comparisons)
+			Expect(c.AddMapLike((*sync.Map).Range)).To(Succeed())
+			Expect(c.DeepCompare(syncMap(map[string]int{"a": 1, "b": 2}), syncMap(map[string]int{"b": 2, "a": 1}))).To(Equal(0))
+			Expect(c.DeepCompare(syncMap(map[string]int{"a": 1}), syncMap(map[string]int{"a": 2}))).To(Equal(-1))
+		})
+
+		It("should compare map-like types regardless of their representation", func() {
+			c := make(Comparisons)
+			Expect(c.AddMapLike((*OrderedMap).Range)).To(Succeed())
+			m1 := (&OrderedMap{}).Set("a", 1).Set("b", 2)
+			m2 := (&OrderedMap{}).Set("b", 2).Set("a", 1)
+			Expect(c.DeepCompare(m1, m2)).To(Equal(0))
+			Expect(c.DeepCompare([]*OrderedMap{m1}, []*OrderedMap{m2.Set("a", 0)})).To(Equal(1))
+		})
+
+		It("should use the comparison functions for entries", func() {
+			c := NewComparisonsOrDie(func(i1, i2 int) int { return i2 - i1 })
+			Expect(c.AddMapLike((*OrderedMap).Range)).To(Succeed())
+			Expect(c.DeepCompare((&OrderedMap{}).Set("a", 1), (&OrderedMap{}).Set("a", 2))).To(Equal(1))
+		})
+
+		It("should error on invalid iterate functions", func() {
+			c := make(Comparisons)
+			Expect(c.AddMapLike(nil)).NotTo(Succeed())
+			Expect(c.AddMapLike(1)).NotTo(Succeed())
+			Expect(c.AddMapLike(func(m *OrderedMap, f func(string, int)) {})).NotTo(Succeed())
+			Expect(c.AddMapLike(func(m *OrderedMap, f func([]string, int) bool) {})).NotTo(Succeed())
+		})
+	})
+
+	Describe("AddSliceLike", func() {
+		It("should compare list-like types element-wise", func() {
+			c := make(Comparisons)
+			Expect(c.AddSliceLike((*Ring).Len, (*Ring).At)).To(Succeed())
+			Expect(c.DeepCompare(NewRing(1, 2, 3), NewRing(1, 2, 3).Rotate(2))).To(Equal(0))
+			Expect(c.DeepCompare(NewRing(1, 2, 3), NewRing(1, 2, 4).Rotate(1))).To(Equal(-1))
+			Expect(c.DeepCompare(NewRing(1, 2, 3), NewRing(1, 2))).To(Equal(1))
+		})
+
+		It("should error on invalid accessor functions", func() {
+			c := make(Comparisons)
+			Expect(c.AddSliceLike(nil, nil)).NotTo(Succeed())
+			Expect(c.AddSliceLike((*Ring).At, (*Ring).Len)).NotTo(Succeed())
+			Expect(c.AddSliceLike((*Ring).Len, func(r Ring, i int) int { return 0 })).NotTo(Succeed())
+		})
+	})
+
+	Describe("AddLinked", func() {
+		var c Comparisons
+		BeforeEach(func() {
+			c = make(Comparisons)
+			Expect(c.AddLinked(
+				func(l *Link) *Link { return l.Next },
+				func(l *Link) int { return l.Value },
+			)).To(Succeed())
+		})
+
+		It("should compare linked nodes by their value sequence", func() {
+			Expect(c.DeepCompare(newLinks(1, 2, 3), newLinks(1, 2, 3))).To(Equal(0))
+			Expect(c.DeepCompare(newLinks(1, 2, 3), newLinks(1, 3))).To(Equal(1))
+			Expect(c.DeepCompare(newLinks(1, 2), newLinks(1, 3))).To(Equal(-1))
+			Expect(c.DeepCompare((*Link)(nil), newLinks())).To(Equal(0))
+		})
+
+		It("should compare cycles by where they start instead of looping", func() {
+			cyclic := func(start int, values ...int) *Link {
+				l := newLinks(values...)
+				last, first := l, l
+				for i := 0; i < start; i++ {
+					first = first.Next
+				}
+				for last.Next != nil {
+					last = last.Next
+				}
+				last.Next = first
+				return l
+			}
+			Expect(c.DeepCompare(cyclic(0, 1, 2), cyclic(0, 1, 2))).To(Equal(0))
+			Expect(c.DeepCompare(newLinks(1, 2), cyclic(0, 1, 2))).To(Equal(-1))
+			Expect(c.DeepCompare(cyclic(1, 1, 2), cyclic(0, 1, 2))).To(Equal(1))
+			Expect(c.DeepCompare(cyclic(0, 1, 2), cyclic(0, 1, 3))).To(Equal(-1))
+		})
+
+		It("should error on invalid accessor functions", func() {
+			Expect(c.AddLinked(nil, nil)).NotTo(Succeed())
+			Expect(c.AddLinked(func(l *Link) int { return 0 }, func(l *Link) int { return 0 })).NotTo(Succeed())
+			Expect(c.AddLinked(func(l *Link) *Link { return nil }, func(l Link) int { return 0 })).NotTo(Succeed())
+		})
+	})
+
+	Describe("AddList", func() {
+		It("should compare lists by their element values", func() {
+			c := make(Comparisons)
+			c.AddList()
+			Expect(c.DeepCompare(newList(1, 2), newList(1, 2))).To(Equal(0))
+			Expect(c.DeepCompare(newList(1, 2), newList(1, 3))).To(Equal(-1))
+			Expect(c.DeepCompare((*list.List)(nil), list.New())).To(Equal(0))
+			Expect(c.DeepCompare(struct{ L *list.List }{newList("b")}, struct{ L *list.List }{newList("a")})).To(Equal(1))
+		})
+
+		It("should compare the element values with the options of the caller", func() {
+			c := make(Comparisons)
+			c.AddList()
+			Expect(c.DeepCompare(newList("1.0"), newList("1"))).To(Equal(1))
+			Expect(c.NewComparer(NumericStrings()).Compare(newList("1.0"), newList("1"))).To(Equal(0))
+			Expect(c.NewComparer(NumericStrings()).Compare(struct{ L *list.List }{newList("2")}, struct{ L *list.List }{newList("10")})).To(Equal(-1))
+		})
+	})
+})
@@ -0,0 +1,53 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"fmt"
+)
+
+// Pair is a pair of values to compare.
+type Pair struct {
+	A, B interface{}
+}
+
+// CompareMany compares the values of each pair via DeepCompare and returns the
+// results in the order of the pairs.
+//
+// In contrast to calling DeepCompare for each pair, the bookkeeping of visited
+// values is shared across all comparisons. The values must thus not be modified
+// while CompareMany runs.
+func (c Comparisons) CompareMany(pairs []Pair) []int {
+	t := c.newTraversal()
+	res := make([]int, len(pairs))
+	for i, pair := range pairs {
+		res[i] = t.compare(pair.A, pair.B)
+	}
+	return res
+}
+
+// CompareEach compares as[i] with bs[i] for every i via DeepCompare and returns the results.
+// It is the same as CompareMany for two parallel slices, which have to be of the same length.
+func (c Comparisons) CompareEach(as, bs []interface{}) []int {
+	if len(as) != len(bs) {
+		panic(fmt.Sprintf("cannot compare slices of different length: %d - %d", len(as), len(bs)))
+	}
+	t := c.newTraversal()
+	res := make([]int, len(as))
+	for i := range as {
+		res[i] = t.compare(as[i], bs[i])
+	}
+	return res
+}
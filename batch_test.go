@@ -0,0 +1,55 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Batch", func() {
+	var c Comparisons
+	BeforeEach(func() {
+		c = make(Comparisons)
+	})
+
+	Describe("CompareMany", func() {
+		It("should compare all pairs", func() {
+			shared := &Struct{A: 1}
+			Expect(c.CompareMany([]Pair{
+				{A: 1, B: 2},
+				{A: "b", B: "a"},
+				{A: shared, B: shared},
+				{A: &Struct{A: 1}, B: shared},
+				{A: &Struct{A: 2}, B: shared},
+			})).To(Equal([]int{-1, 1, 0, 0, 1}))
+		})
+
+		It("should return an empty result for no pairs", func() {
+			Expect(c.CompareMany(nil)).To(BeEmpty())
+		})
+	})
+
+	Describe("CompareEach", func() {
+		It("should compare the slices element-wise", func() {
+			Expect(c.CompareEach([]interface{}{1, "a"}, []interface{}{1, "b"})).To(Equal([]int{0, -1}))
+		})
+
+		It("should panic on slices of different length", func() {
+			Expect(func() { c.CompareEach([]interface{}{1}, nil) }).To(Panic())
+		})
+	})
+})
@@ -0,0 +1,159 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bench measures the performance of reflcompare against other ways of comparing
+// values, e.g. reflect.DeepEqual or github.com/google/go-cmp's cmp.Equal, on user-supplied
+// corpora. This helps deciding whether e.g. reusing a reflcompare.Comparer is warranted
+// for a given workload.
+package bench
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/adracus/reflcompare"
+)
+
+// Pair is a pair of values to compare.
+type Pair struct {
+	A, B interface{}
+}
+
+// Contender is a named way of comparing values.
+type Contender struct {
+	// Name identifies the contender in results and sub-benchmarks.
+	Name string
+	// Func compares a and b, discarding the result.
+	Func func(a, b interface{})
+}
+
+// DeepCompare compares values using c.DeepCompare.
+func DeepCompare(c reflcompare.DeepComparer) Contender {
+	return Contender{
+		Name: "DeepCompare",
+		Func: func(a, b interface{}) { c.DeepCompare(a, b) },
+	}
+}
+
+// Comparer compares values using a single reflcompare.Comparer created from c and opts,
+// so its buffers are reused across comparisons.
+func Comparer(c reflcompare.Comparisons, opts ...reflcompare.Option) Contender {
+	cmp := c.NewComparer(opts...)
+	return Contender{
+		Name: "Comparer",
+		Func: func(a, b interface{}) { cmp.Compare(a, b) },
+	}
+}
+
+// DeepEqual compares values using reflect.DeepEqual.
+func DeepEqual() Contender {
+	return Contender{
+		Name: "reflect.DeepEqual",
+		Func: func(a, b interface{}) { reflect.DeepEqual(a, b) },
+	}
+}
+
+// Equal compares values using the given equality function, e.g. cmp.Equal:
+//
+//	bench.Equal("cmp.Equal", func(a, b interface{}) bool { return cmp.Equal(a, b) })
+func Equal(name string, equal func(a, b interface{}) bool) Contender {
+	return Contender{
+		Name: name,
+		Func: func(a, b interface{}) { equal(a, b) },
+	}
+}
+
+// Result is the performance of a contender on a corpus, per comparison of a pair.
+type Result struct {
+	// Name is the name of the contender.
+	Name string
+	// NsPerOp is the average time per comparison in nanoseconds.
+	NsPerOp float64
+	// AllocsPerOp is the average number of allocations per comparison.
+	AllocsPerOp float64
+	// BytesPerOp is the average number of bytes allocated per comparison.
+	BytesPerOp float64
+}
+
+// String implements fmt.Stringer.
+func (r Result) String() string {
+	return fmt.Sprintf("%s\t%.1f ns/op\t%.1f B/op\t%.1f allocs/op", r.Name, r.NsPerOp, r.BytesPerOp, r.AllocsPerOp)
+}
+
+// Measure compares all pairs of the corpus iterations times with each contender and
+// returns the results in the order of the contenders.
+//
+// Measure panics if the corpus is empty or iterations is not positive.
+func Measure(corpus []Pair, iterations int, contenders ...Contender) []Result {
+	if len(corpus) == 0 {
+		panic("expected non-empty corpus")
+	}
+	if iterations <= 0 {
+		panic(fmt.Sprintf("expected positive iterations, got: %d", iterations))
+	}
+	res := make([]Result, len(contenders))
+	for i, contender := range contenders {
+		res[i] = measure(corpus, iterations, contender)
+	}
+	return res
+}
+
+func measure(corpus []Pair, iterations int, contender Contender) Result {
+	// Warm up caches, e.g. of a Comparer.
+	for _, p := range corpus {
+		contender.Func(p.A, p.B)
+	}
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	for i := 0; i < iterations; i++ {
+		for _, p := range corpus {
+			contender.Func(p.A, p.B)
+		}
+	}
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	ops := float64(iterations * len(corpus))
+	return Result{
+		Name:        contender.Name,
+		NsPerOp:     float64(elapsed.Nanoseconds()) / ops,
+		AllocsPerOp: float64(after.Mallocs-before.Mallocs) / ops,
+		BytesPerOp:  float64(after.TotalAlloc-before.TotalAlloc) / ops,
+	}
+}
+
+// Run runs a sub-benchmark of b per contender, each comparing all pairs of the corpus
+// per iteration. This allows comparing contenders via 'go test -bench':
+//
+//	func BenchmarkObjects(b *testing.B) {
+//		bench.Run(b, corpus, bench.DeepCompare(c), bench.DeepEqual())
+//	}
+func Run(b *testing.B, corpus []Pair, contenders ...Contender) {
+	for _, contender := range contenders {
+		contender := contender
+		b.Run(contender.Name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				for _, p := range corpus {
+					contender.Func(p.A, p.B)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bench_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/adracus/reflcompare"
+	"github.com/adracus/reflcompare/bench"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type Object struct {
+	Name   string
+	Labels map[string]string
+	Items  []int
+}
+
+var corpus = []bench.Pair{
+	{Object{Name: "a", Labels: map[string]string{"app": "a"}, Items: []int{1, 2}}, Object{Name: "a", Labels: map[string]string{"app": "a"}, Items: []int{1, 2}}},
+	{Object{Name: "a"}, Object{Name: "b"}},
+}
+
+var _ = Describe("Bench", func() {
+	c := make(reflcompare.Comparisons)
+
+	Describe("Measure", func() {
+		It("should measure all contenders in order", func() {
+			var calls int
+			counting := bench.Equal("counting", func(a, b interface{}) bool {
+				calls++
+				return reflect.DeepEqual(a, b)
+			})
+			res := bench.Measure(corpus, 10, bench.DeepCompare(c), bench.Comparer(c), bench.DeepEqual(), counting)
+			Expect(res).To(HaveLen(4))
+			Expect(res[0].Name).To(Equal("DeepCompare"))
+			Expect(res[3].Name).To(Equal("counting"))
+			Expect(calls).To(Equal(len(corpus) * 11))
+			for _, r := range res {
+				Expect(r.NsPerOp).To(BeNumerically(">", 0))
+			}
+			Expect(res[0].String()).To(ContainSubstring("ns/op"))
+		})
+
+		It("should panic on empty corpora or non-positive iterations", func() {
+			Expect(func() { bench.Measure(nil, 1, bench.DeepEqual()) }).To(Panic())
+			Expect(func() { bench.Measure(corpus, 0, bench.DeepEqual()) }).To(Panic())
+		})
+	})
+})
+
+func BenchmarkCorpus(b *testing.B) {
+	c := make(reflcompare.Comparisons)
+	bench.Run(b, corpus, bench.DeepCompare(c), bench.Comparer(c), bench.DeepEqual())
+}
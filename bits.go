@@ -0,0 +1,67 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"bytes"
+)
+
+// CompareBitSet compares two bit sets as unsigned integers.
+//
+// A bit set stores bit i in word i/64 at position i%64, i.e. the word at index 0
+// holds the least significant bits. Trailing zero words are insignificant, so
+// a nil bit set is equal to a bit set only consisting of zero words.
+//
+// To use it for a custom bit set type (e.g. `type BitSet []uint64`), use AdaptFunc.
+func CompareBitSet(s1, s2 []uint64) int {
+	s1, s2 = trimBitSet(s1), trimBitSet(s2)
+	if res := compareInt64(int64(len(s1)), int64(len(s2))); res != 0 {
+		return res
+	}
+	for i := len(s1) - 1; i >= 0; i-- {
+		if res := compareUInt64(s1[i], s2[i]); res != 0 {
+			return res
+		}
+	}
+	return 0
+}
+
+func trimBitSet(s []uint64) []uint64 {
+	for len(s) > 0 && s[len(s)-1] == 0 {
+		s = s[:len(s)-1]
+	}
+	return s
+}
+
+// CompareBigEndian compares two big-endian byte slices as unsigned integers.
+//
+// In contrast to bytes.Compare, leading zero bytes are insignificant and a longer
+// number is greater than a shorter one, e.g. []byte{0x01, 0x00} is greater than []byte{0xff}.
+//
+// To use it for a custom type (e.g. `type Hash []byte`), use AdaptFunc.
+func CompareBigEndian(b1, b2 []byte) int {
+	b1, b2 = trimBigEndian(b1), trimBigEndian(b2)
+	if res := compareInt64(int64(len(b1)), int64(len(b2))); res != 0 {
+		return res
+	}
+	return bytes.Compare(b1, b2)
+}
+
+func trimBigEndian(b []byte) []byte {
+	for len(b) > 0 && b[0] == 0 {
+		b = b[1:]
+	}
+	return b
+}
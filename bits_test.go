@@ -0,0 +1,54 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Bits", func() {
+	DescribeTable("CompareBitSet",
+		func(s1, s2 []uint64, expect int) {
+			Expect(CompareBitSet(s1, s2)).To(Equal(expect))
+			Expect(CompareBitSet(s2, s1)).To(Equal(-expect))
+		},
+		Entry("nil == nil", ([]uint64)(nil), ([]uint64)(nil), 0),
+		Entry("nil == zero words", ([]uint64)(nil), []uint64{0, 0}, 0),
+		Entry("trailing zero words are insignificant", []uint64{1}, []uint64{1, 0}, 0),
+		Entry("higher word is more significant", []uint64{^uint64(0)}, []uint64{0, 1}, -1),
+		Entry("same length", []uint64{2, 1}, []uint64{1, 2}, -1),
+	)
+
+	DescribeTable("CompareBigEndian",
+		func(b1, b2 []byte, expect int) {
+			Expect(CompareBigEndian(b1, b2)).To(Equal(expect))
+			Expect(CompareBigEndian(b2, b1)).To(Equal(-expect))
+		},
+		Entry("nil == nil", ([]byte)(nil), ([]byte)(nil), 0),
+		Entry("nil == zero bytes", ([]byte)(nil), []byte{0, 0}, 0),
+		Entry("leading zero bytes are insignificant", []byte{0, 1}, []byte{1}, 0),
+		Entry("longer number is greater", []byte{0xff}, []byte{1, 0}, -1),
+		Entry("same length", []byte{1, 2}, []byte{2, 1}, -1),
+	)
+
+	It("should be registrable for custom types", func() {
+		type Hash []byte
+		c := NewComparisonsOrDie(AdaptFuncOrDie(CompareBigEndian, Hash(nil)))
+		Expect(c.DeepCompare(Hash{0xff}, Hash{1, 0})).To(Equal(-1))
+	})
+})
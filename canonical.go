@@ -0,0 +1,169 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// MarshalCanonical encodes v deterministically, so that equal encodings of two values
+// of the same type imply that DeepCompare considers them equal. This makes the encoding
+// suitable for reproducible hashes or signatures of values.
+//
+// The encoding follows the semantics of DeepCompare: Map entries are encoded in the
+// order of their keys according to c, and nil and empty slices and maps are encoded
+// alike. Values of interfaces are prefixed with their dynamic type.
+// The format of the encoding is unspecified and may only be used for equality checks.
+//
+// MarshalCanonical returns an error for non-nil funcs, channels and unsafe pointers
+// and for cyclic values.
+func (c Comparisons) MarshalCanonical(v interface{}) ([]byte, error) {
+	e := &canonicalEncoder{
+		traversal: c.newTraversal(),
+		pointers:  make(map[uintptr]bool),
+	}
+	if err := e.encode(reflect.ValueOf(v)); err != nil {
+		return nil, err
+	}
+	return e.buf.Bytes(), nil
+}
+
+type canonicalEncoder struct {
+	traversal *traversal
+	buf       bytes.Buffer
+	// pointers are the pointers on the path to the currently encoded value.
+	pointers map[uintptr]bool
+}
+
+func (e *canonicalEncoder) encode(v reflect.Value) error {
+	if !v.IsValid() {
+		e.buf.WriteString("null")
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		e.buf.WriteString(strconv.FormatBool(v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		e.buf.WriteString(strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uintptr, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		e.buf.WriteString(strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		e.buf.WriteString(strconv.FormatFloat(v.Float(), 'g', -1, 64))
+	case reflect.Complex64, reflect.Complex128:
+		e.buf.WriteString(strconv.FormatComplex(v.Complex(), 'g', -1, 128))
+	case reflect.String:
+		if v.Type() == jsonNumberType {
+			e.buf.WriteString(strconv.Quote(canonicalNumber(v)))
+		} else {
+			e.buf.WriteString(strconv.Quote(v.String()))
+		}
+	case reflect.Array, reflect.Slice:
+		return e.encodeList(v)
+	case reflect.Struct:
+		return e.encodeStruct(v)
+	case reflect.Map:
+		return e.encodeMap(v)
+	case reflect.Ptr:
+		return e.encodePointer(v)
+	case reflect.Interface:
+		if v.IsNil() {
+			e.buf.WriteString("null")
+			return nil
+		}
+		e.buf.WriteString(strconv.Quote(v.Elem().Type().String()))
+		e.buf.WriteByte('(')
+		if err := e.encode(v.Elem()); err != nil {
+			return err
+		}
+		e.buf.WriteByte(')')
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		if !v.IsNil() {
+			return fmt.Errorf("cannot canonically encode non-nil value of type %v", v.Type())
+		}
+		e.buf.WriteString("null")
+	default:
+		return fmt.Errorf("cannot canonically encode value of type %v", v.Type())
+	}
+	return nil
+}
+
+func (e *canonicalEncoder) encodeList(v reflect.Value) error {
+	e.buf.WriteByte('[')
+	for i := 0; i < v.Len(); i++ {
+		if i > 0 {
+			e.buf.WriteByte(',')
+		}
+		if err := e.encode(v.Index(i)); err != nil {
+			return err
+		}
+	}
+	e.buf.WriteByte(']')
+	return nil
+}
+
+func (e *canonicalEncoder) encodeStruct(v reflect.Value) error {
+	e.buf.WriteByte('{')
+	for i := 0; i < v.NumField(); i++ {
+		if i > 0 {
+			e.buf.WriteByte(',')
+		}
+		e.buf.WriteString(v.Type().Field(i).Name)
+		e.buf.WriteByte(':')
+		if err := e.encode(v.Field(i)); err != nil {
+			return err
+		}
+	}
+	e.buf.WriteByte('}')
+	return nil
+}
+
+func (e *canonicalEncoder) encodeMap(v reflect.Value) error {
+	keys, err := e.traversal.sortedKeys(v)
+	if err != nil {
+		return err
+	}
+	e.buf.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			e.buf.WriteByte(',')
+		}
+		if err := e.encode(k); err != nil {
+			return err
+		}
+		e.buf.WriteByte(':')
+		if err := e.encode(v.MapIndex(k)); err != nil {
+			return err
+		}
+	}
+	e.buf.WriteByte('}')
+	return nil
+}
+
+func (e *canonicalEncoder) encodePointer(v reflect.Value) error {
+	if v.IsNil() {
+		e.buf.WriteString("null")
+		return nil
+	}
+	ptr := v.Pointer()
+	if e.pointers[ptr] {
+		return fmt.Errorf("cannot canonically encode cyclic value of type %v", v.Type())
+	}
+	e.pointers[ptr] = true
+	defer delete(e.pointers, ptr)
+	return e.encode(v.Elem())
+}
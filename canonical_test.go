@@ -0,0 +1,97 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"errors"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+type Node struct {
+	Next *Node
+}
+
+var _ = Describe("MarshalCanonical", func() {
+	c := make(Comparisons)
+
+	marshal := func(v interface{}) string {
+		data, err := c.MarshalCanonical(v)
+		Expect(err).NotTo(HaveOccurred())
+		return string(data)
+	}
+
+	DescribeTable("encoding",
+		func(v interface{}, expect string) {
+			Expect(marshal(v)).To(Equal(expect))
+		},
+		Entry("nil", nil, "null"),
+		Entry("bool", true, "true"),
+		Entry("int", -1, "-1"),
+		Entry("uint", uint8(1), "1"),
+		Entry("float", 1.5, "1.5"),
+		Entry("complex", complex(1, 2), "(1+2i)"),
+		Entry("string", `a"b`, `"a\"b"`),
+		Entry("nil pointer", (*int)(nil), "null"),
+		Entry("pointer", intPtr(1), "1"),
+		Entry("array", [2]int{1, 2}, "[1,2]"),
+		Entry("slice", []int{1, 2}, "[1,2]"),
+		Entry("struct", Spec{Replicas: intPtr(1), Image: "foo"}, `{Replicas:1,Image:"foo"}`),
+		Entry("interface", []error{nil, errors.New("foo")}, `[null,"*errors.errorString"({s:"foo"})]`),
+		Entry("nil func", (func())(nil), "null"),
+	)
+
+	It("should encode nil and empty slices and maps alike", func() {
+		Expect(marshal([]int(nil))).To(Equal(marshal([]int{})))
+		Expect(marshal(map[int]int(nil))).To(Equal(marshal(map[int]int{})))
+	})
+
+	It("should encode maps deterministically in key order", func() {
+		m := map[string]int{"c": 3, "a": 1, "b": 2}
+		for i := 0; i < 10; i++ {
+			Expect(marshal(m)).To(Equal(`{"a":1,"b":2,"c":3}`))
+		}
+	})
+
+	It("should order map keys by the comparison functions", func() {
+		c := make(Comparisons)
+		Expect(c.AddOrder(Low, High, Critical)).To(Succeed())
+		data, err := c.MarshalCanonical(map[Severity]int{Critical: 3, Low: 1})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`{"low":1,"critical":3}`))
+	})
+
+	It("should encode shared but acyclic pointers", func() {
+		shared := &Node{}
+		Expect(marshal([]*Node{shared, shared})).To(Equal("[{Next:null},{Next:null}]"))
+	})
+
+	It("should error on cyclic values", func() {
+		n := &Node{}
+		n.Next = n
+		_, err := c.MarshalCanonical(n)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should error on non-nil funcs and channels", func() {
+		_, err := c.MarshalCanonical(func() {})
+		Expect(err).To(HaveOccurred())
+		_, err = c.MarshalCanonical(make(chan int))
+		Expect(err).To(HaveOccurred())
+	})
+})
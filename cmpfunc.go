@@ -0,0 +1,30 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.18
+
+package reflcompare
+
+// CmpFunc returns a comparison function for values of type T ordering them via
+// c.DeepCompare. It is suitable for the functions of the slices package:
+//
+//	slices.SortFunc(items, reflcompare.CmpFunc[Item](c))
+//	i, found := slices.BinarySearchFunc(items, target, reflcompare.CmpFunc[Item](c))
+//
+// The returned function panics if values of T cannot be compared.
+func CmpFunc[T any](c Comparisons) func(a, b T) int {
+	return func(a, b T) int {
+		return c.DeepCompare(a, b)
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.21
+
+package reflcompare_test
+
+import (
+	"slices"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CmpFunc", func() {
+	It("should be usable with the slices package", func() {
+		cmp := CmpFunc[[]int](make(Comparisons))
+		items := [][]int{{3}, {1, 2}, {1, 1}, {3}}
+
+		slices.SortFunc(items, cmp)
+		Expect(items).To(Equal([][]int{{3}, {3}, {1, 1}, {1, 2}}))
+
+		i, found := slices.BinarySearchFunc(items, []int{1, 1}, cmp)
+		Expect(found).To(BeTrue())
+		Expect(i).To(Equal(2))
+
+		Expect(slices.CompactFunc(items, func(a, b []int) bool { return cmp(a, b) == 0 })).To(HaveLen(3))
+	})
+
+	It("should use comparison functions", func() {
+		cmp := CmpFunc[Severity](NewComparisonsOrDie())
+		c := make(Comparisons)
+		Expect(c.AddOrder(Low, High, Critical)).To(Succeed())
+		Expect(cmp(Critical, High)).To(Equal(-1))
+		Expect(CmpFunc[Severity](c)(Critical, High)).To(Equal(1))
+	})
+})
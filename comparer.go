@@ -0,0 +1,60 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+// Comparer compares values using Comparisons, reusing its internal buffers and
+// per-type metadata across comparisons. This avoids allocations in the steady state
+// when comparing many values in a loop.
+//
+// A Comparer is not safe for concurrent use. Use one Comparer per goroutine instead.
+type Comparer struct {
+	traversal *traversal
+}
+
+// NewComparer creates a new Comparer using c, configured by the given options.
+//
+// As the Comparer caches which comparison functions apply to which struct fields,
+// c must not be modified afterwards. Create a new Comparer instead.
+func (c Comparisons) NewComparer(opts ...Option) *Comparer {
+	return &Comparer{traversal: c.newTraversal(opts...)}
+}
+
+// NewComparer creates a new Comparer using l, configured by the given options.
+// Like Comparisons.NewComparer, none of the Comparisons of l must be modified afterwards.
+func (l LayeredComparisons) NewComparer(opts ...Option) *Comparer {
+	return &Comparer{traversal: l.newTraversal(opts...)}
+}
+
+// Compare compares a1 and a2. Without options, it behaves exactly like Comparisons.DeepCompare.
+func (c *Comparer) Compare(a1, a2 interface{}) int {
+	defer c.traversal.reset()
+	return c.traversal.compare(a1, a2)
+}
+
+// TryCompare compares a1 and a2 like Compare, but returns an error instead of panicking.
+//
+// Whether values of a struct type cannot be compared regardless of their contents, e.g. because
+// its first field is an unexported channel, is decided once per type. Subsequent values of
+// such types fail immediately instead of being traversed again.
+func (c *Comparer) TryCompare(a1, a2 interface{}) (res int, err error) {
+	defer c.traversal.reset()
+	// Comparison functions may still panic.
+	defer func() {
+		if x := recover(); x != nil {
+			err = recoveredError(x)
+		}
+	}()
+	return c.traversal.tryCompare(a1, a2)
+}
@@ -0,0 +1,245 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"errors"
+	"fmt"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type OtherError struct{ Msg string }
+
+func (e *OtherError) Error() string { return e.Msg }
+
+var _ = Describe("Comparer", func() {
+	It("should compare like DeepCompare", func() {
+		cmp := NewComparisonsOrDie(func(a, b Struct) int { return b.A - a.A }).NewComparer()
+		Expect(cmp.Compare(1, 2)).To(Equal(-1))
+		Expect(cmp.Compare([]Struct{{A: 1}}, []Struct{{A: 2}})).To(Equal(1))
+	})
+
+	It("should apply comparison functions to struct fields", func() {
+		type Inner struct{ A, B int }
+		type Outer struct{ I Inner }
+		cmp := NewComparisonsOrDie(func(a, b Inner) int { return a.A - b.A }).NewComparer()
+		for i := 0; i < 2; i++ {
+			Expect(cmp.Compare(Outer{Inner{A: 1, B: 2}}, Outer{Inner{A: 1, B: 1}})).To(Equal(0))
+			Expect(cmp.Compare(Outer{Inner{A: 1}}, Outer{Inner{A: 2}})).To(Equal(-1))
+		}
+	})
+
+	It("should not carry state across comparisons", func() {
+		cmp := make(Comparisons).NewComparer()
+		s := []Struct{{A: 1}}
+		Expect(cmp.Compare(&s, &s)).To(Equal(0))
+		s2 := []Struct{{A: 2}}
+		Expect(cmp.Compare(&s, &s2)).To(Equal(-1))
+		s2[0].A = 1
+		Expect(cmp.Compare(&s, &s2)).To(Equal(0))
+	})
+
+	It("should remain usable after a panic", func() {
+		cmp := make(Comparisons).NewComparer()
+		Expect(func() { cmp.Compare(func() {}, func() {}) }).To(Panic())
+		Expect(cmp.Compare(1, 1)).To(Equal(0))
+	})
+
+	Describe("Strict", func() {
+		It("should only allow comparing untyped nil to untyped nil", func() {
+			cmp := make(Comparisons).NewComparer(Strict())
+			Expect(cmp.Compare(nil, nil)).To(Equal(0))
+			Expect(func() { cmp.Compare(nil, (*int)(nil)) }).To(Panic())
+			_, err := cmp.TryCompare(1, nil)
+			Expect(err).To(MatchError("cannot compare different types: int - <nil>"))
+		})
+	})
+
+	Describe("FuncsByNil", func() {
+		type Config struct {
+			Name    string
+			OnEvent func(string)
+		}
+
+		It("should compare funcs by whether they are nil only", func() {
+			cmp := make(Comparisons).NewComparer(FuncsByNil())
+			Expect(cmp.Compare(Config{OnEvent: func(string) {}}, Config{OnEvent: func(string) {}})).To(Equal(0))
+			Expect(cmp.Compare(Config{}, Config{OnEvent: func(string) {}})).To(Equal(-1))
+			Expect(cmp.Compare(Config{Name: "b", OnEvent: func(string) {}}, Config{Name: "a"})).To(Equal(1))
+		})
+
+		It("should fail on non-nil funcs by default", func() {
+			_, err := make(Comparisons).NewComparer().TryCompare(Config{OnEvent: func(string) {}}, Config{OnEvent: func(string) {}})
+			Expect(err).To(MatchError(ErrFuncCompare))
+		})
+	})
+
+	Describe("CollapsePointers", func() {
+		var (
+			nilPtr    *int
+			nilPtrPtr **int
+			one       = intPtr(1)
+		)
+
+		It("should compare pointer chains level by level by default", func() {
+			cmp := make(Comparisons).NewComparer()
+			Expect(cmp.Compare(nilPtrPtr, &nilPtr)).To(Equal(-1))
+		})
+
+		It("should treat nil at any level alike", func() {
+			cmp := make(Comparisons).NewComparer(CollapsePointers())
+			Expect(cmp.Compare(nilPtrPtr, &nilPtr)).To(Equal(0))
+			Expect(cmp.Compare(&nilPtr, &one)).To(Equal(-1))
+			Expect(cmp.Compare(&one, &one)).To(Equal(0))
+		})
+
+		It("should stop at pointers with comparison functions", func() {
+			cmp := NewComparisonsOrDie(func(a, b *int) int { return 0 }).NewComparer(CollapsePointers())
+			Expect(cmp.Compare(&nilPtr, &one)).To(Equal(0))
+		})
+	})
+
+	Describe("OrderByDynamicType", func() {
+		var (
+			err1 error = errors.New("foo")
+			err2 error = &OtherError{Msg: "foo"}
+		)
+
+		It("should panic on different dynamic types by default", func() {
+			cmp := make(Comparisons).NewComparer()
+			Expect(func() { cmp.Compare([]error{err1}, []error{err2}) }).To(Panic())
+		})
+
+		It("should order by dynamic type for the given interface types", func() {
+			cmp := make(Comparisons).NewComparer(OrderByDynamicType((*error)(nil)))
+			res := cmp.Compare([]error{err1}, []error{err2})
+			Expect(res).NotTo(Equal(0))
+			Expect(cmp.Compare([]error{err2}, []error{err1})).To(Equal(-res))
+			Expect(cmp.Compare([]error{err1}, []error{errors.New("foo")})).To(Equal(0))
+			Expect(cmp.Compare([]error{nil}, []error{err1})).To(Equal(-1))
+		})
+
+		It("should not apply to other interface types", func() {
+			cmp := make(Comparisons).NewComparer(OrderByDynamicType((*fmt.Stringer)(nil)))
+			Expect(func() { cmp.Compare([]error{err1}, []error{err2}) }).To(Panic())
+		})
+
+		It("should panic if the argument is no pointer to an interface", func() {
+			Expect(func() { OrderByDynamicType(err1) }).To(Panic())
+		})
+	})
+
+	Describe("Markers", func() {
+		type Member struct{ AddedBy string }
+
+		It("should consider all values of marker types equal", func() {
+			cmp := make(Comparisons).NewComparer(Markers(Member{}))
+			s1 := map[string]Member{"a": {AddedBy: "x"}, "b": {}}
+			s2 := map[string]Member{"a": {AddedBy: "y"}, "b": {AddedBy: "z"}}
+			Expect(cmp.Compare(s1, s2)).To(Equal(0))
+			Expect(make(Comparisons).DeepCompare(s1, s2)).NotTo(Equal(0))
+		})
+
+		It("should take precedence over comparison functions", func() {
+			cmp := NewComparisonsOrDie(func(m1, m2 Member) int { return 1 }).NewComparer(Markers(Member{}))
+			Expect(cmp.Compare(Member{}, Member{})).To(Equal(0))
+		})
+
+		It("should panic on an untyped nil sample", func() {
+			Expect(func() { Markers(nil) }).To(Panic())
+		})
+	})
+
+	Describe("FieldWeights", func() {
+		It("should compare fields with higher weights first", func() {
+			cmp := make(Comparisons).NewComparer(FieldWeights(Struct{}, map[string]int{"B": 1}))
+			Expect(cmp.Compare(Struct{A: 1, B: intPtr(1)}, Struct{A: 2})).To(Equal(1))
+		})
+
+		It("should take precedence over tags", func() {
+			cmp := make(Comparisons).NewComparer(FieldWeights(Release{}, map[string]int{"Major": 0, "Name": 3}))
+			Expect(cmp.Compare(Release{Name: "a", Minor: 2}, Release{Name: "b", Minor: 1})).To(Equal(-1))
+		})
+
+		It("should panic on unknown fields or non-struct samples", func() {
+			Expect(func() { FieldWeights(Struct{}, map[string]int{"X": 1}) }).To(Panic())
+			Expect(func() { FieldWeights(1, nil) }).To(Panic())
+		})
+
+		It("should panic on promoted fields", func() {
+			type Embedding struct{ Release }
+			Expect(func() { FieldWeights(Embedding{}, map[string]int{"Major": 1}) }).To(Panic())
+			Expect(func() { FieldWeights(Embedding{}, map[string]int{"Release": 1}) }).NotTo(Panic())
+		})
+	})
+
+	Describe("SignificantFields", func() {
+		It("should only compare the fields with the highest weights", func() {
+			cmp := make(Comparisons).NewComparer(SignificantFields(1))
+			Expect(cmp.Compare(Release{Major: 1, Minor: 1}, Release{Major: 1, Minor: 2})).To(Equal(0))
+			Expect(cmp.Compare(Release{Major: 1}, Release{Major: 2})).To(Equal(-1))
+		})
+
+		It("should not affect structs without weights", func() {
+			cmp := make(Comparisons).NewComparer(SignificantFields(1))
+			Expect(cmp.Compare(Struct{A: 1, B: intPtr(1)}, Struct{A: 1})).To(Equal(1))
+		})
+	})
+
+	Describe("TryCompare", func() {
+		type Hidden struct{ c chan int }
+
+		It("should compare values", func() {
+			cmp := make(Comparisons).NewComparer()
+			Expect(cmp.TryCompare(1, 2)).To(Equal(-1))
+		})
+
+		It("should return an error instead of panicking", func() {
+			cmp := make(Comparisons).NewComparer()
+			_, err := cmp.TryCompare(func() {}, func() {})
+			Expect(err).To(HaveOccurred())
+			_, err = cmp.TryCompare(1, "foo")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should fail fast for types whose values cannot be compared at all", func() {
+			type Mid struct{ h Hidden }
+			type Outer struct{ m Mid }
+			cmp := make(Comparisons).NewComparer()
+			for i := 0; i < 2; i++ {
+				cmp.ResetStats()
+				_, err := cmp.TryCompare(Outer{Mid{Hidden{make(chan int)}}}, Outer{})
+				Expect(err).To(BeAssignableToTypeOf(&UnexportedFieldError{}))
+				Expect(err.(*UnexportedFieldError).Path).To(Equal(".m.h.c"))
+				Expect(err.(*UnexportedFieldError).Types).To(HaveLen(4))
+				Expect(cmp.Stats().Nodes).To(Equal(1))
+			}
+
+			Expect(make(Comparisons).NewComparer(Lenient()).TryCompare(Outer{}, Outer{})).To(Equal(0))
+		})
+
+		It("should not carry errors over to other values of the same type", func() {
+			type Box struct{ V interface{} }
+			cmp := make(Comparisons).NewComparer()
+			_, err := cmp.TryCompare(Box{Hidden{make(chan int)}}, Box{Hidden{make(chan int)}})
+			Expect(err).To(MatchError(ContainSubstring("unexported field")))
+
+			Expect(cmp.TryCompare(Box{1}, Box{2})).To(Equal(-1))
+		})
+	})
+})
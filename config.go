@@ -0,0 +1,182 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// Config is the declarative part of the options of comparisons, so policies on how to compare
+// values can be reviewed, versioned and loaded as configuration instead of being spread across
+// code. Configs are encoded as JSON. YAML can be used via libraries converting YAML to JSON
+// respecting json struct tags, e.g. sigs.k8s.io/yaml.
+//
+// Comparison functions cannot be configured, but referred to by name: Fields can be tagged
+// with the names of comparison functions registered via TagComparison in code.
+type Config struct {
+	// Flags are the names of the options without arguments to apply, e.g. "StrictMaps".
+	Flags []string `json:"flags,omitempty"`
+	// MaxDepth configures MaxDepth, if positive.
+	MaxDepth int `json:"maxDepth,omitempty"`
+	// MaxDifferences configures MaxDifferences, if positive.
+	MaxDifferences int `json:"maxDifferences,omitempty"`
+	// SignificantFields configures SignificantFields, if positive.
+	SignificantFields int `json:"significantFields,omitempty"`
+	// AsOfVersion configures AsOfVersion, if positive.
+	AsOfVersion int `json:"asOfVersion,omitempty"`
+	// Tags are the compare tags of struct fields by field name per type name, e.g.
+	// {"v1.Spec": {"Replicas": "ignore"}}, see FieldTags. Type names are as printed by
+	// reflect.Type.String.
+	Tags map[string]map[string]string `json:"tags,omitempty"`
+}
+
+// configFlags are the options that can be configured via Config.Flags, by name.
+var configFlags = map[string]func(o *options) *bool{
+	"CollapsePointers": func(o *options) *bool { return &o.collapsePointers },
+	"CompareAsSets":    func(o *options) *bool { return &o.compareAsSets },
+	"FuncsByNil":       func(o *options) *bool { return &o.funcsByNil },
+	"Lenient":          func(o *options) *bool { return &o.lenient },
+	"MissingAsZero":    func(o *options) *bool { return &o.missingAsZero },
+	"NumericStrings":   func(o *options) *bool { return &o.numericStrings },
+	"Redact":           func(o *options) *bool { return &o.redact },
+	"ReuseReports":     func(o *options) *bool { return &o.reuseReports },
+	"SortKeyedLists":   func(o *options) *bool { return &o.sortKeyedLists },
+	"Strict":           func(o *options) *bool { return &o.strict },
+	"StrictMaps":       func(o *options) *bool { return &o.strictMaps },
+}
+
+// NewConfig returns the declarative part of the given options as a Config. Options that are
+// not declarative, e.g. Formatters, and options scoped via ForType or AtPath are omitted.
+// Field weights set via FieldWeights are added to the tags of the fields.
+func NewConfig(opts ...Option) Config {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	cfg := Config{
+		MaxDepth:          o.maxDepth,
+		MaxDifferences:    o.maxDifferences,
+		SignificantFields: o.significantFields,
+		AsOfVersion:       o.asOfVersion,
+	}
+	for name, flag := range configFlags {
+		if *flag(&o) {
+			cfg.Flags = append(cfg.Flags, name)
+		}
+	}
+	sort.Strings(cfg.Flags)
+	setTag := func(t reflect.Type, name, value string) {
+		if cfg.Tags == nil {
+			cfg.Tags = make(map[string]map[string]string)
+		}
+		if cfg.Tags[t.String()] == nil {
+			cfg.Tags[t.String()] = make(map[string]string)
+		}
+		cfg.Tags[t.String()][name] = value
+	}
+	for t, tags := range o.fieldTags {
+		for name, value := range tags {
+			setTag(t, name, value)
+		}
+	}
+	for t, weights := range o.fieldWeights {
+		for name, weight := range weights {
+			tag, ok := o.fieldTags[t][name]
+			if !ok {
+				f, _ := t.FieldByName(name)
+				tag = f.Tag.Get(tagKey)
+			}
+			if tag != "" {
+				tag += ","
+			}
+			setTag(t, name, tag+"weight="+strconv.Itoa(weight))
+		}
+	}
+	return cfg
+}
+
+// ParseConfig parses the JSON encoding of a Config. Unlike json.Unmarshal, it fails on unknown
+// keys, so misspelled settings are not silently ignored.
+func ParseConfig(data []byte) (Config, error) {
+	var cfg Config
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.DisallowUnknownFields()
+	if err := d.Decode(&cfg); err != nil {
+		return Config{}, fmt.Errorf("invalid config: %w", err)
+	}
+	return cfg, nil
+}
+
+// Options returns the options configured by cfg. The types named by cfg.Tags are resolved among
+// the types of the given samples.
+//
+// Options returns an error if cfg names unknown or conflicting flags, types that are not the type
+// of a struct sample, or fields those types do not declare, or if any tag is malformed.
+func (cfg Config) Options(samples ...interface{}) ([]Option, error) {
+	var opts []Option
+	flags := make(map[string]bool, len(cfg.Flags))
+	for _, name := range cfg.Flags {
+		flag, ok := configFlags[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown flag %q", name)
+		}
+		flags[name] = true
+		opts = append(opts, func(o *options) { *flag(o) = true })
+	}
+	if flags["MissingAsZero"] && flags["StrictMaps"] {
+		// StrictMaps takes precedence, so MissingAsZero would have no effect.
+		return nil, fmt.Errorf("conflicting flags %q and %q", "MissingAsZero", "StrictMaps")
+	}
+	if cfg.MaxDepth > 0 {
+		opts = append(opts, MaxDepth(cfg.MaxDepth))
+	}
+	if cfg.MaxDifferences > 0 {
+		opts = append(opts, MaxDifferences(cfg.MaxDifferences))
+	}
+	if cfg.SignificantFields > 0 {
+		opts = append(opts, SignificantFields(cfg.SignificantFields))
+	}
+	if cfg.AsOfVersion > 0 {
+		opts = append(opts, AsOfVersion(cfg.AsOfVersion))
+	}
+	types := make(map[string]reflect.Type, len(samples))
+	for _, sample := range samples {
+		if t := reflect.TypeOf(sample); t != nil && t.Kind() == reflect.Struct {
+			types[t.String()] = t
+		}
+	}
+	for typeName, tags := range cfg.Tags {
+		t, ok := types[typeName]
+		if !ok {
+			return nil, fmt.Errorf("unknown type %s", typeName)
+		}
+		for name, value := range tags {
+			f, ok := t.FieldByName(name)
+			if !ok || len(f.Index) > 1 {
+				return nil, fmt.Errorf("type %v has no field %s", t, name)
+			}
+			if _, err := parseFieldTag(withTag(f, value)); err != nil {
+				return nil, fmt.Errorf("invalid %s tag of %v: %w", tagKey, t, err)
+			}
+		}
+		opts = append(opts, FieldTags(reflect.Zero(t).Interface(), tags))
+	}
+	return opts, nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"encoding/json"
+	"strings"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type Workload struct {
+	Name     string
+	Image    string `compare:"weight=1"`
+	Replicas int
+	Status   string
+}
+
+var _ = Describe("Config", func() {
+	const data = `{
+		"flags": ["Lenient", "StrictMaps"],
+		"maxDepth": 10,
+		"tags": {
+			"reflcompare_test.Workload": {"Status": "ignore", "Name": "caseless,weight=2"}
+		}
+	}`
+	caseless := TagComparison("caseless", func(s1, s2 string) int {
+		return strings.Compare(strings.ToLower(s1), strings.ToLower(s2))
+	})
+
+	It("should configure options", func() {
+		cfg, err := ParseConfig([]byte(data))
+		Expect(err).NotTo(HaveOccurred())
+		opts, err := cfg.Options(Workload{})
+		Expect(err).NotTo(HaveOccurred())
+		cmp := make(Comparisons).NewComparer(append(opts, caseless)...)
+		Expect(cmp.Compare(Workload{Name: "A", Status: "up"}, Workload{Name: "a", Status: "down"})).To(Equal(0))
+		// Name is compared first.
+		Expect(cmp.Compare(Workload{Name: "b", Image: "x"}, Workload{Name: "a", Image: "y"})).To(Equal(1))
+		Expect(cmp.Compare(map[string]int{}, map[string]int{"a": 1})).To(Equal(-1))
+	})
+
+	It("should encode the declarative part of options", func() {
+		cfg := NewConfig(
+			StrictMaps(),
+			Lenient(),
+			MaxDepth(10),
+			FieldTags(Workload{}, map[string]string{"Status": "ignore"}),
+			FieldWeights(Workload{}, map[string]int{"Name": 2}),
+			Formatters(func(w Workload) string { return w.Name }),
+		)
+		Expect(cfg).To(Equal(Config{
+			Flags:    []string{"Lenient", "StrictMaps"},
+			MaxDepth: 10,
+			Tags: map[string]map[string]string{
+				"reflcompare_test.Workload": {"Status": "ignore", "Name": "weight=2"},
+			},
+		}))
+		data, err := json.Marshal(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ParseConfig(data)).To(Equal(cfg))
+	})
+
+	It("should add field weights to declared tags", func() {
+		cfg := NewConfig(FieldWeights(Workload{}, map[string]int{"Image": 3, "Replicas": 1}))
+		Expect(cfg.Tags["reflcompare_test.Workload"]).To(Equal(map[string]string{"Image": "weight=1,weight=3", "Replicas": "weight=1"}))
+		opts, err := cfg.Options(Workload{})
+		Expect(err).NotTo(HaveOccurred())
+		cmp := make(Comparisons).NewComparer(opts...)
+		Expect(cmp.Compare(Workload{Image: "a", Replicas: 2}, Workload{Image: "b", Replicas: 1})).To(Equal(-1))
+		Expect(cmp.Compare(Workload{Name: "b", Replicas: 2}, Workload{Name: "a", Replicas: 1})).To(Equal(1))
+	})
+
+	It("should fail on invalid configs", func() {
+		_, err := ParseConfig([]byte(`{"flag": ["Strict"]}`))
+		Expect(err).To(HaveOccurred())
+		_, err = Config{Flags: []string{"Loose"}}.Options()
+		Expect(err).To(MatchError(`unknown flag "Loose"`))
+		_, err = Config{Flags: []string{"MissingAsZero", "StrictMaps"}}.Options()
+		Expect(err).To(MatchError(`conflicting flags "MissingAsZero" and "StrictMaps"`))
+		_, err = Config{Tags: map[string]map[string]string{"reflcompare_test.Workload": {}}}.Options()
+		Expect(err).To(MatchError("unknown type reflcompare_test.Workload"))
+		_, err = Config{Tags: map[string]map[string]string{"reflcompare_test.Workload": {"Missing": "ignore"}}}.Options(Workload{})
+		Expect(err).To(MatchError("type reflcompare_test.Workload has no field Missing"))
+		_, err = Config{Tags: map[string]map[string]string{"reflcompare_test.Workload": {"Name": "weight=x"}}}.Options(Workload{})
+		Expect(err).To(MatchError(ContainSubstring(`invalid weight "x"`)))
+	})
+})
@@ -0,0 +1,211 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package conformance provides table-driven tests checking that comparison functions
+// registered with reflcompare conform to the contract of an ordering, so new custom
+// comparison functions can be verified uniformly instead of by review.
+package conformance
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/adracus/reflcompare"
+)
+
+// Case is a conformance test case.
+type Case struct {
+	// Name is the name of the subtest running the case.
+	Name string
+	// Comparer compares the samples, typically Comparisons holding the comparison function under test.
+	Comparer reflcompare.DeepComparer
+	// Samples is a slice or array of values to compare. It should cover edge cases like zero
+	// values, equal but not identical values and values differing in each compared part.
+	Samples interface{}
+}
+
+// Run runs a subtest per case that fails if Check reports any violations.
+func Run(t *testing.T, cases []Case) {
+	t.Helper()
+	for _, c := range cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			if err := Check(c.Comparer, c.Samples); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// Check checks that cmp orders the samples (a slice or array) consistently. It checks that
+//
+//   - every sample is equal to itself and reversing the arguments reverses the sign of the
+//     result (symmetry around zero),
+//   - a <= b and b <= c implies a <= c for all samples (transitivity),
+//   - samples are equal if and only if their Equal method reports them equal, for samples of
+//     types T with a method Equal(T) bool, and samples that are reflect.DeepEqual are equal
+//     otherwise (consistency with Equal) and
+//   - comparing copies of samples yields the same results as comparing the samples, where
+//     pointers are copied by copying the values they point to (stability under copies).
+//
+// Check returns an error describing all violations, if any. Panics of cmp are violations as well.
+func Check(cmp reflcompare.DeepComparer, samples interface{}) error {
+	v := reflect.ValueOf(samples)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return fmt.Errorf("expected slice or array but got %T", samples)
+	}
+	values := make([]interface{}, v.Len())
+	copies := make([]interface{}, v.Len())
+	for i := range values {
+		values[i] = v.Index(i).Interface()
+		copies[i] = copyValue(v.Index(i)).Interface()
+	}
+
+	var violations []string
+	violate := func(format string, args ...interface{}) {
+		violations = append(violations, fmt.Sprintf(format, args...))
+	}
+	res := make([][]int, len(values))
+	for i, a := range values {
+		res[i] = make([]int, len(values))
+		for j, b := range values {
+			r, err := compare(cmp, a, b)
+			if err != nil {
+				violate("%v", err)
+			}
+			res[i][j] = r
+		}
+	}
+
+	for i, a := range values {
+		if res[i][i] != 0 {
+			violate("not reflexive: compare(%#v, %#v) = %d", a, a, res[i][i])
+		}
+		for j, b := range values {
+			if j > i && res[i][j] != -res[j][i] {
+				violate("not symmetric: compare(%#v, %#v) = %d, but compare(%#v, %#v) = %d", a, b, res[i][j], b, a, res[j][i])
+			}
+			if equal, ok := equalMethod(a, b); ok && equal != (res[i][j] == 0) {
+				violate("not consistent with Equal: %#v.Equal(%#v) = %t, but compare(%#v, %#v) = %d", a, b, equal, a, b, res[i][j])
+			} else if !ok && res[i][j] != 0 && reflect.DeepEqual(a, b) {
+				violate("not consistent with reflect.DeepEqual: compare(%#v, %#v) = %d", a, b, res[i][j])
+			}
+			if r, err := compare(cmp, copies[i], copies[j]); err == nil && r != res[i][j] {
+				violate("not stable under copies: compare(%#v, %#v) = %d, but %d for copies", a, b, res[i][j], r)
+			}
+			for k, c := range values {
+				if res[i][j] <= 0 && res[j][k] <= 0 && res[i][k] > 0 {
+					violate("not transitive: %#v <= %#v and %#v <= %#v, but %#v > %#v", a, b, b, c, a, c)
+				}
+			}
+		}
+	}
+	if len(violations) > 0 {
+		return fmt.Errorf("%d violations:\n%s", len(violations), strings.Join(violations, "\n"))
+	}
+	return nil
+}
+
+// compare compares a and b via c, returning the sign of the result or an error if c panics.
+func compare(c reflcompare.DeepComparer, a, b interface{}) (res int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("compare(%#v, %#v) panicked: %v", a, b, r)
+		}
+	}()
+	switch r := c.DeepCompare(a, b); {
+	case r < 0:
+		return -1, nil
+	case r > 0:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// equalMethod calls the method Equal(T) bool of a with b, if a of type T has one.
+func equalMethod(a, b interface{}) (bool, bool) {
+	va := reflect.ValueOf(a)
+	if !va.IsValid() {
+		return false, false
+	}
+	m := va.MethodByName("Equal")
+	if !m.IsValid() {
+		return false, false
+	}
+	mt := m.Type()
+	if mt.NumIn() != 1 || mt.In(0) != va.Type() || mt.NumOut() != 1 || mt.Out(0).Kind() != reflect.Bool {
+		return false, false
+	}
+	return m.Call([]reflect.Value{reflect.ValueOf(b)})[0].Bool(), true
+}
+
+// copyValue copies v, copying the values pointed to by pointers, slices and maps recursively.
+// Unexported struct fields are copied shallowly.
+func copyValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		res := reflect.New(v.Type().Elem())
+		res.Elem().Set(copyValue(v.Elem()))
+		return res
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		res := reflect.New(v.Type()).Elem()
+		res.Set(copyValue(v.Elem()))
+		return res
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		res := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			res.Index(i).Set(copyValue(v.Index(i)))
+		}
+		return res
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		res := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			res.SetMapIndex(copyValue(iter.Key()), copyValue(iter.Value()))
+		}
+		return res
+	case reflect.Array:
+		res := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			res.Index(i).Set(copyValue(v.Index(i)))
+		}
+		return res
+	case reflect.Struct:
+		res := reflect.New(v.Type()).Elem()
+		res.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath == "" {
+				res.Field(i).Set(copyValue(v.Field(i)))
+			}
+		}
+		return res
+	default:
+		return v
+	}
+}
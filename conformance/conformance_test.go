@@ -0,0 +1,122 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conformance_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/adracus/reflcompare"
+	. "github.com/adracus/reflcompare/conformance"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type Version struct {
+	Major, Minor int
+}
+
+type Box struct {
+	Value *int
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+var _ = Describe("Conformance", func() {
+	Describe("Check", func() {
+		It("should accept conforming comparison functions", func() {
+			c := reflcompare.NewComparisonsOrDie(func(v1, v2 Version) int {
+				if v1.Major != v2.Major {
+					return v1.Major - v2.Major
+				}
+				return v1.Minor - v2.Minor
+			})
+			Expect(Check(c, []Version{{}, {1, 0}, {1, 2}, {1, 2}, {2, 0}})).To(Succeed())
+		})
+
+		It("should accept the default rules", func() {
+			Expect(Check(make(reflcompare.Comparisons), []Box{{}, {intPtr(1)}, {intPtr(2)}})).To(Succeed())
+		})
+
+		It("should report asymmetric comparison functions", func() {
+			c := reflcompare.NewComparisonsOrDie(func(v1, v2 Version) int { return 1 })
+			err := Check(c, []Version{{1, 0}, {2, 0}})
+			Expect(err).To(MatchError(ContainSubstring("not reflexive")))
+			Expect(err).To(MatchError(ContainSubstring("not symmetric")))
+		})
+
+		It("should report intransitive comparison functions", func() {
+			// Rock, paper, scissors.
+			c := reflcompare.NewComparisonsOrDie(func(v1, v2 Version) int {
+				switch (v2.Major - v1.Major + 3) % 3 {
+				case 0:
+					return 0
+				case 1:
+					return -1
+				default:
+					return 1
+				}
+			})
+			Expect(Check(c, []Version{{0, 0}, {1, 0}, {2, 0}})).To(MatchError(ContainSubstring("not transitive")))
+		})
+
+		It("should report inconsistencies with Equal methods", func() {
+			c := reflcompare.NewComparisonsOrDie(func(t1, t2 time.Time) int { return strings.Compare(t1.String(), t2.String()) })
+			t := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+			Expect(Check(c, []time.Time{t, t.In(time.FixedZone("X", 3600))})).To(MatchError(ContainSubstring("not consistent with Equal")))
+		})
+
+		It("should report inconsistencies with reflect.DeepEqual", func() {
+			c := reflcompare.NewComparisonsOrDie(func(v1, v2 Version) int { return 0 })
+			Expect(Check(c, []Version{{1, 0}, {1, 0}})).To(Succeed())
+
+			c = reflcompare.NewComparisonsOrDie(func(b1, b2 Box) int {
+				switch {
+				case b1.Value == b2.Value:
+					return 0
+				case b1.Value == nil:
+					return -1
+				default:
+					return 1
+				}
+			})
+			err := Check(c, []Box{{intPtr(1)}, {intPtr(1)}})
+			Expect(err).To(MatchError(ContainSubstring("not consistent with reflect.DeepEqual")))
+		})
+
+		It("should report comparison functions that are not stable under copies", func() {
+			c := reflcompare.NewComparisonsOrDie(func(b1, b2 *Box) int {
+				// Boxes are considered equal only if identical.
+				if b1 == b2 {
+					return 0
+				}
+				return 1
+			})
+			b := &Box{intPtr(1)}
+			Expect(Check(c, []*Box{b, b})).To(MatchError(ContainSubstring("not stable under copies")))
+		})
+
+		It("should report panics", func() {
+			c := reflcompare.NewComparisonsOrDie(func(v1, v2 Version) int { panic("boom") })
+			Expect(Check(c, []Version{{}})).To(MatchError(ContainSubstring("panicked: boom")))
+		})
+
+		It("should fail for samples that are no slice or array", func() {
+			Expect(Check(make(reflcompare.Comparisons), 1)).NotTo(Succeed())
+		})
+	})
+})
@@ -0,0 +1,106 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package container provides ordered containers whose order is determined by
+// reflcompare.Comparisons, so their keys can be of any deep-comparable type.
+package container
+
+import (
+	"sort"
+
+	"github.com/adracus/reflcompare"
+)
+
+type entry struct {
+	key, value interface{}
+}
+
+// MultiMap is a map whose entries are ordered by their keys. It allows duplicate keys;
+// values of equal keys are ordered by insertion.
+//
+// Lookups take O(log n) comparisons, insertions and deletions additionally move O(n) entries.
+// A MultiMap is not safe for concurrent use.
+type MultiMap struct {
+	comparisons reflcompare.Comparisons
+	entries     []entry
+}
+
+// NewMultiMap creates a new, empty MultiMap ordering its keys by the given Comparisons.
+func NewMultiMap(c reflcompare.Comparisons) *MultiMap {
+	return &MultiMap{comparisons: c}
+}
+
+// Len returns the number of entries of the MultiMap.
+func (m *MultiMap) Len() int {
+	return len(m.entries)
+}
+
+// lowerBound returns the position of the first entry whose key is not less than key,
+// or, if exclusive, greater than key.
+func (m *MultiMap) lowerBound(key interface{}, exclusive bool) int {
+	return sort.Search(len(m.entries), func(i int) bool {
+		res := m.comparisons.DeepCompare(m.entries[i].key, key)
+		return res > 0 || (res == 0 && !exclusive)
+	})
+}
+
+// Put adds value with the given key, after all values of equal keys.
+func (m *MultiMap) Put(key, value interface{}) {
+	i := m.lowerBound(key, true)
+	m.entries = append(m.entries, entry{})
+	copy(m.entries[i+1:], m.entries[i:])
+	m.entries[i] = entry{key, value}
+}
+
+// Get returns the values of keys equal to key, in insertion order.
+func (m *MultiMap) Get(key interface{}) []interface{} {
+	var res []interface{}
+	m.Range(reflcompare.Range{Lo: key, Hi: key}, func(_, value interface{}) bool {
+		res = append(res, value)
+		return true
+	})
+	return res
+}
+
+// Delete removes all values of keys equal to key and returns how many were removed.
+func (m *MultiMap) Delete(key interface{}) int {
+	lo, hi := m.lowerBound(key, false), m.lowerBound(key, true)
+	m.entries = append(m.entries[:lo], m.entries[hi:]...)
+	return hi - lo
+}
+
+// Range calls fn for every entry whose key is within r, in order. If fn returns false,
+// Range stops. Bounds of r are compared to keys via DeepCompare.
+func (m *MultiMap) Range(r reflcompare.Range, fn func(key, value interface{}) bool) {
+	i := 0
+	if r.Lo != nil {
+		i = m.lowerBound(r.Lo, r.ExcludeLo)
+	}
+	for ; i < len(m.entries); i++ {
+		e := m.entries[i]
+		if r.Hi != nil {
+			if res := m.comparisons.DeepCompare(e.key, r.Hi); res > 0 || (res == 0 && r.ExcludeHi) {
+				return
+			}
+		}
+		if !fn(e.key, e.value) {
+			return
+		}
+	}
+}
+
+// Ascend calls fn for every entry in order. If fn returns false, Ascend stops.
+func (m *MultiMap) Ascend(fn func(key, value interface{}) bool) {
+	m.Range(reflcompare.Range{}, fn)
+}
@@ -0,0 +1,87 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container_test
+
+import (
+	"time"
+
+	"github.com/adracus/reflcompare"
+	. "github.com/adracus/reflcompare/container"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type Slot struct {
+	Day  int
+	Hour int
+}
+
+var _ = Describe("MultiMap", func() {
+	var m *MultiMap
+	BeforeEach(func() {
+		m = NewMultiMap(make(reflcompare.Comparisons))
+		m.Put(Slot{1, 10}, "a")
+		m.Put(Slot{2, 9}, "b")
+		m.Put(Slot{1, 10}, "c")
+		m.Put(Slot{1, 8}, "d")
+	})
+
+	collect := func(r reflcompare.Range) []interface{} {
+		var res []interface{}
+		m.Range(r, func(_, value interface{}) bool {
+			res = append(res, value)
+			return true
+		})
+		return res
+	}
+
+	It("should order entries by key, then by insertion", func() {
+		Expect(m.Len()).To(Equal(4))
+		Expect(collect(reflcompare.Range{})).To(Equal([]interface{}{"d", "a", "c", "b"}))
+	})
+
+	It("should get all values of a key", func() {
+		Expect(m.Get(Slot{1, 10})).To(Equal([]interface{}{"a", "c"}))
+		Expect(m.Get(Slot{3, 0})).To(BeEmpty())
+	})
+
+	It("should query ranges of keys", func() {
+		Expect(collect(reflcompare.Range{Lo: Slot{1, 9}, Hi: Slot{2, 9}})).To(Equal([]interface{}{"a", "c", "b"}))
+		Expect(collect(reflcompare.Range{Lo: Slot{1, 8}, Hi: Slot{1, 10}, ExcludeLo: true, ExcludeHi: true})).To(BeEmpty())
+		Expect(collect(reflcompare.Range{Hi: Slot{1, 10}, ExcludeHi: true})).To(Equal([]interface{}{"d"}))
+	})
+
+	It("should stop if fn returns false", func() {
+		var n int
+		m.Ascend(func(_, _ interface{}) bool {
+			n++
+			return false
+		})
+		Expect(n).To(Equal(1))
+	})
+
+	It("should delete all values of a key", func() {
+		Expect(m.Delete(Slot{1, 10})).To(Equal(2))
+		Expect(m.Delete(Slot{1, 10})).To(Equal(0))
+		Expect(collect(reflcompare.Range{})).To(Equal([]interface{}{"d", "b"}))
+	})
+
+	It("should respect comparison functions", func() {
+		m := NewMultiMap(reflcompare.NewComparisonsOrDie(reflcompare.CompareTime))
+		t := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+		m.Put(t.In(time.FixedZone("X", 3600)), "x")
+		Expect(m.Get(t)).To(Equal([]interface{}{"x"}))
+	})
+})
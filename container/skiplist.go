@@ -0,0 +1,171 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"math/rand"
+	"sync"
+	"sync/atomic"
+
+	"github.com/adracus/reflcompare"
+)
+
+// maxLevel is the maximum number of levels of a SkipList, sufficient for 4^maxLevel entries.
+const maxLevel = 24
+
+// box wraps values, as atomic.Value requires consistently typed values.
+type box struct {
+	value interface{}
+}
+
+type skipNode struct {
+	key   interface{}
+	value atomic.Value   // *box
+	next  []atomic.Value // *skipNode
+}
+
+func (n *skipNode) nextAt(level int) *skipNode {
+	next, _ := n.next[level].Load().(*skipNode)
+	return next
+}
+
+// SkipList is a map whose entries are ordered by their keys. Readers do not take locks and
+// may run concurrently with each other and with a writer; writers are serialized.
+// A reader running concurrently with writers observes each write either entirely or not at all.
+//
+// Lookups, insertions and deletions take O(log n) comparisons, expected.
+type SkipList struct {
+	comparisons reflcompare.Comparisons
+	head        *skipNode
+	size        int64
+	// mu serializes writers.
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+// NewSkipList creates a new, empty SkipList ordering its keys by the given Comparisons.
+func NewSkipList(c reflcompare.Comparisons) *SkipList {
+	return &SkipList{
+		comparisons: c,
+		head:        &skipNode{next: make([]atomic.Value, maxLevel)},
+		rnd:         rand.New(rand.NewSource(rand.Int63())),
+	}
+}
+
+// Len returns the number of entries of the SkipList.
+func (s *SkipList) Len() int {
+	return int(atomic.LoadInt64(&s.size))
+}
+
+// seek returns, per level, the last node whose key is less than key or, if inclusive,
+// not greater than key.
+func (s *SkipList) seek(key interface{}, inclusive bool) (preds [maxLevel]*skipNode) {
+	x := s.head
+	for level := maxLevel - 1; level >= 0; level-- {
+		for next := x.nextAt(level); next != nil; next = x.nextAt(level) {
+			res := s.comparisons.DeepCompare(next.key, key)
+			if res > 0 || (res == 0 && !inclusive) {
+				break
+			}
+			x = next
+		}
+		preds[level] = x
+	}
+	return preds
+}
+
+// find returns the node with a key equal to key, if any, along with its predecessors.
+func (s *SkipList) find(key interface{}) (*skipNode, [maxLevel]*skipNode) {
+	preds := s.seek(key, false)
+	if n := preds[0].nextAt(0); n != nil && s.comparisons.DeepCompare(n.key, key) == 0 {
+		return n, preds
+	}
+	return nil, preds
+}
+
+// Get returns the value of the key equal to key, if any.
+func (s *SkipList) Get(key interface{}) (interface{}, bool) {
+	n, _ := s.find(key)
+	if n == nil {
+		return nil, false
+	}
+	return n.value.Load().(*box).value, true
+}
+
+// Set sets the value of key, replacing the value of an equal key if present.
+func (s *SkipList) Set(key, value interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n, preds := s.find(key)
+	if n != nil {
+		n.value.Store(&box{value})
+		return
+	}
+
+	level := 1
+	for level < maxLevel && s.rnd.Intn(4) == 0 {
+		level++
+	}
+	n = &skipNode{key: key, next: make([]atomic.Value, level)}
+	n.value.Store(&box{value})
+	for l := 0; l < level; l++ {
+		n.next[l].Store(preds[l].nextAt(l))
+	}
+	// Publish bottom-up, so readers finding n on any level find it on the levels below, too.
+	for l := 0; l < level; l++ {
+		preds[l].next[l].Store(n)
+	}
+	atomic.AddInt64(&s.size, 1)
+}
+
+// Delete removes the entry of the key equal to key and reports whether it was present.
+func (s *SkipList) Delete(key interface{}) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n, preds := s.find(key)
+	if n == nil {
+		return false
+	}
+	// Unlink top-down; readers currently at n still find their way via n's next nodes.
+	for l := len(n.next) - 1; l >= 0; l-- {
+		preds[l].next[l].Store(n.nextAt(l))
+	}
+	atomic.AddInt64(&s.size, -1)
+	return true
+}
+
+// Range calls fn for every entry whose key is within r, in order. If fn returns false,
+// Range stops. Bounds of r are compared to keys via DeepCompare.
+func (s *SkipList) Range(r reflcompare.Range, fn func(key, value interface{}) bool) {
+	x := s.head
+	if r.Lo != nil {
+		x = s.seek(r.Lo, r.ExcludeLo)[0]
+	}
+	for n := x.nextAt(0); n != nil; n = n.nextAt(0) {
+		if r.Hi != nil {
+			if res := s.comparisons.DeepCompare(n.key, r.Hi); res > 0 || (res == 0 && r.ExcludeHi) {
+				return
+			}
+		}
+		if !fn(n.key, n.value.Load().(*box).value) {
+			return
+		}
+	}
+}
+
+// Ascend calls fn for every entry in order. If fn returns false, Ascend stops.
+func (s *SkipList) Ascend(fn func(key, value interface{}) bool) {
+	s.Range(reflcompare.Range{}, fn)
+}
@@ -0,0 +1,102 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container_test
+
+import (
+	"sync"
+
+	"github.com/adracus/reflcompare"
+	. "github.com/adracus/reflcompare/container"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SkipList", func() {
+	var s *SkipList
+	BeforeEach(func() {
+		s = NewSkipList(make(reflcompare.Comparisons))
+		s.Set(Slot{2, 9}, "b")
+		s.Set(Slot{1, 10}, "a")
+		s.Set(Slot{1, 8}, "d")
+	})
+
+	keys := func(r reflcompare.Range) []interface{} {
+		var res []interface{}
+		s.Range(r, func(key, _ interface{}) bool {
+			res = append(res, key)
+			return true
+		})
+		return res
+	}
+
+	It("should order entries by key", func() {
+		Expect(s.Len()).To(Equal(3))
+		Expect(keys(reflcompare.Range{})).To(Equal([]interface{}{Slot{1, 8}, Slot{1, 10}, Slot{2, 9}}))
+	})
+
+	It("should get and replace values", func() {
+		v, ok := s.Get(Slot{1, 10})
+		Expect(ok).To(BeTrue())
+		Expect(v).To(Equal("a"))
+		s.Set(Slot{1, 10}, 42)
+		v, _ = s.Get(Slot{1, 10})
+		Expect(v).To(Equal(42))
+		Expect(s.Len()).To(Equal(3))
+		_, ok = s.Get(Slot{3, 0})
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should query ranges of keys", func() {
+		Expect(keys(reflcompare.Range{Lo: Slot{1, 8}, Hi: Slot{2, 9}, ExcludeLo: true})).To(Equal([]interface{}{Slot{1, 10}, Slot{2, 9}}))
+		Expect(keys(reflcompare.Range{Lo: Slot{1, 9}, Hi: Slot{2, 9}, ExcludeHi: true})).To(Equal([]interface{}{Slot{1, 10}}))
+	})
+
+	It("should delete entries", func() {
+		Expect(s.Delete(Slot{1, 10})).To(BeTrue())
+		Expect(s.Delete(Slot{1, 10})).To(BeFalse())
+		Expect(keys(reflcompare.Range{})).To(Equal([]interface{}{Slot{1, 8}, Slot{2, 9}}))
+		Expect(s.Len()).To(Equal(2))
+	})
+
+	It("should allow reading while writing", func() {
+		s := NewSkipList(make(reflcompare.Comparisons))
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				s.Set(i, i)
+				if i%2 == 1 {
+					s.Delete(i)
+				}
+			}
+		}()
+		go func() {
+			defer GinkgoRecover()
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				prev := -1
+				s.Ascend(func(key, value interface{}) bool {
+					Expect(key.(int)).To(BeNumerically(">", prev))
+					Expect(value).To(Equal(key))
+					prev = key.(int)
+					return true
+				})
+			}
+		}()
+		wg.Wait()
+		Expect(s.Len()).To(Equal(250))
+	})
+})
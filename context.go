@@ -0,0 +1,92 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrIncomplete indicates a comparison that was stopped before completion (see IncompleteError).
+var ErrIncomplete = errors.New("comparison incomplete")
+
+// IncompleteError is returned when comparing values was stopped before completion because
+// the context of the comparison was done. It matches ErrIncomplete as well as the error of
+// the context, e.g. context.DeadlineExceeded.
+//
+// The results returned along with it are the best known ones: CompareContext returns 0, as
+// the values are equal as far as they have been compared, and DiffContext returns the
+// differences found so far.
+type IncompleteError struct {
+	// Path is the path to the values being compared when the comparison was stopped, e.g. '.Spec'.
+	Path string
+	// Err is the error of the context.
+	Err error
+}
+
+// Error implements error.
+func (e *IncompleteError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("%v: %v", ErrIncomplete, e.Err)
+	}
+	return fmt.Sprintf("%v at %s: %v", ErrIncomplete, e.Path, e.Err)
+}
+
+// Is reports whether target is ErrIncomplete.
+func (e *IncompleteError) Is(target error) bool {
+	return target == ErrIncomplete
+}
+
+// Unwrap returns the error of the context.
+func (e *IncompleteError) Unwrap() error {
+	return e.Err
+}
+
+// CompareContext compares a1 and a2 like TryCompare, but stops when ctx is done, returning 0
+// along with an IncompleteError. This bounds the time spent comparing huge values, e.g. for
+// monitoring, where an approximate answer is preferable to none.
+func (c *Comparer) CompareContext(ctx context.Context, a1, a2 interface{}) (int, error) {
+	t := c.traversal
+	t.setContext(ctx)
+	defer t.setContext(nil)
+	return c.TryCompare(a1, a2)
+}
+
+// DiffContext compares a1 and a2 like Diff, but stops when ctx is done, returning the
+// differences found so far along with an IncompleteError.
+func (c Comparisons) DiffContext(ctx context.Context, a1, a2 interface{}, opts ...Option) ([]Difference, error) {
+	t := c.newTraversal(opts...)
+	t.setContext(ctx)
+	return t.diff(a1, a2)
+}
+
+// setContext makes the traversal stop when ctx is done. A nil ctx never stops it.
+func (t *traversal) setContext(ctx context.Context) {
+	t.ctx, t.done = ctx, nil
+	if ctx != nil {
+		t.done = ctx.Done()
+	}
+}
+
+// interrupted returns an IncompleteError if the context of the traversal is done.
+func (t *traversal) interrupted() error {
+	select {
+	case <-t.done:
+		return &IncompleteError{Err: t.ctx.Err()}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// Probe cancels the context of the test when compared.
+type Probe int
+
+type Metrics struct {
+	A int
+	B Probe
+	C int
+}
+
+var _ = Describe("Context", func() {
+	var (
+		ctx    context.Context
+		cancel context.CancelFunc
+		c      Comparisons
+	)
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		c = NewComparisonsOrDie(func(p1, p2 Probe) int {
+			cancel()
+			return int(p1 - p2)
+		})
+	})
+	AfterEach(func() {
+		cancel()
+	})
+
+	Describe("CompareContext", func() {
+		It("should compare values like TryCompare", func() {
+			cmp := c.NewComparer()
+			Expect(cmp.CompareContext(ctx, []int{1, 2}, []int{1, 3})).To(Equal(-1))
+			Expect(cmp.CompareContext(context.Background(), []Probe{1}, []Probe{1})).To(Equal(0))
+		})
+
+		It("should stop when the context is done", func() {
+			res, err := c.NewComparer().CompareContext(ctx, []Probe{1, 1, 1}, []Probe{1, 1, 2})
+			Expect(res).To(Equal(0))
+			Expect(err).To(MatchError(ErrIncomplete))
+			Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+			var incomplete *IncompleteError
+			Expect(errors.As(err, &incomplete)).To(BeTrue())
+			Expect(incomplete.Path).To(Equal("[1]"))
+		})
+
+		It("should stop immediately if the context is done already", func() {
+			cancel()
+			_, err := c.NewComparer().CompareContext(ctx, 1, 2)
+			Expect(err).To(MatchError("comparison incomplete: context canceled"))
+		})
+
+		It("should not stop subsequent comparisons", func() {
+			cmp := c.NewComparer()
+			_, err := cmp.CompareContext(ctx, []Probe{1, 1}, []Probe{1, 2})
+			Expect(err).To(MatchError(ErrIncomplete))
+			Expect(cmp.TryCompare([]Probe{1, 1}, []Probe{1, 2})).To(Equal(-1))
+		})
+	})
+
+	Describe("DiffContext", func() {
+		It("should return the differences found so far when the context is done", func() {
+			diffs, err := c.DiffContext(ctx, Metrics{A: 1, B: 1, C: 1}, Metrics{A: 2, B: 1, C: 2})
+			Expect(err).To(MatchError(ErrIncomplete))
+			Expect(err.(*IncompleteError).Path).To(Equal(".C"))
+			Expect(diffs).To(HaveLen(1))
+			Expect(diffs[0].Path).To(Equal(".A"))
+		})
+
+		It("should diff values like Diff", func() {
+			diffs, err := c.DiffContext(context.Background(), Metrics{A: 1, C: 1}, Metrics{A: 2, C: 2})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diffs).To(HaveLen(2))
+		})
+	})
+})
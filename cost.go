@@ -0,0 +1,167 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Cost is the estimated cost of comparing values of a type, see Comparisons.EstimateCost.
+//
+// The sizes of slices and maps are unknown upfront, so a Cost describes comparing values
+// whose slices and maps hold one element each. Actual costs grow with their sizes.
+type Cost struct {
+	// Nodes is the number of values traversed to compare two equal values, including the values
+	// themselves. Values with comparison functions and other values not compared by the default
+	// rules count as one node.
+	Nodes int
+	// Depth is the maximum nesting depth of the traversed values.
+	Depth int
+	// Lists is the number of slices and maps traversed, whose elements add to Nodes per element.
+	Lists int
+	// Recursive reports whether values of the type can be nested arbitrarily deep. Nodes and
+	// Depth then account for one level of recursion only.
+	Recursive bool
+	// Dynamic reports whether the type contains interfaces, whose dynamic values are not
+	// accounted for.
+	Dynamic bool
+	// SortsMapKeys reports whether comparing values sorts the keys of maps, which allocates
+	// and compares keys beyond their map lookups, e.g. with StrictMaps or CompareAsSets.
+	// Diffing values always sorts the keys of maps.
+	SortsMapKeys bool
+}
+
+// EstimateCost estimates the cost of comparing values of type typ using c, configured by the
+// given options, without comparing any values. This helps deciding per type whether to compare
+// values reflectively or by dedicated comparison functions.
+//
+// EstimateCost panics if comparing values of type typ would fail because of invalid struct tags.
+func (c Comparisons) EstimateCost(typ reflect.Type, opts ...Option) Cost {
+	e := costEstimator{
+		traversal: c.newTraversal(opts...),
+		costs:     make(map[costKey]Cost),
+		entered:   make(map[reflect.Type]bool),
+	}
+	return e.estimate(typ)
+}
+
+// costEstimator estimates the costs of comparing values of types.
+type costEstimator struct {
+	traversal *traversal
+	// costs are the costs estimated so far, by scope and type.
+	costs map[costKey]Cost
+	// entered are the types whose costs are being estimated.
+	entered map[reflect.Type]bool
+}
+
+type costKey struct {
+	scope *scope
+	typ   reflect.Type
+}
+
+func (e *costEstimator) estimate(typ reflect.Type) Cost {
+	t := e.traversal
+	if e.entered[typ] {
+		return Cost{Nodes: 1, Recursive: true}
+	}
+	if _, ok := t.scoped[typ]; ok && !t.scope.entered[typ] {
+		prev := t.scope
+		t.setScope(prev.child(typ))
+		defer t.setScope(prev)
+	}
+	key := costKey{t.scope, typ}
+	if cost, ok := e.costs[key]; ok {
+		return cost
+	}
+	e.entered[typ] = true
+	cost := e.estimateNodes(typ)
+	delete(e.entered, typ)
+	if !cost.Recursive {
+		// Costs of types nested in recursive types depend on where the recursion was entered.
+		e.costs[key] = cost
+	}
+	return cost
+}
+
+func (e *costEstimator) estimateNodes(typ reflect.Type) Cost {
+	t := e.traversal
+	t.resolveTypeScopes(typ)
+	if !e.decomposable(typ) {
+		return Cost{Nodes: 1}
+	}
+	cost := Cost{Nodes: 1}
+	switch typ.Kind() {
+	case reflect.Interface:
+		cost.Dynamic = true
+	case reflect.Ptr:
+		cost.add(e.estimate(typ.Elem()), 1)
+	case reflect.Array:
+		cost.add(e.estimate(typ.Elem()), typ.Len())
+	case reflect.Slice:
+		cost.Lists++
+		cost.add(e.estimate(typ.Elem()), 1)
+	case reflect.Map:
+		cost.Lists++
+		if (t.compareAsSets && isSetType(typ)) || t.strictMaps {
+			cost.SortsMapKeys = true
+			cost.add(e.estimate(typ.Key()), 1)
+		}
+		if !t.compareAsSets || !isSetType(typ) {
+			cost.add(e.estimate(typ.Elem()), 1)
+		}
+	case reflect.Struct:
+		fields, err := t.structFields(typ)
+		if err != nil {
+			panic(fmt.Errorf("cannot estimate cost of %v: %w", typ, err))
+		}
+		for _, f := range fields {
+			if f.compare.IsValid() {
+				cost.add(Cost{Nodes: 1}, 1)
+				continue
+			}
+			cost.add(e.estimate(typ.Field(f.index).Type), 1)
+		}
+	}
+	return cost
+}
+
+// decomposable reports whether values of typ are compared by their elements or fields.
+func (e *costEstimator) decomposable(typ reflect.Type) bool {
+	t := e.traversal
+	if _, ok := t.lookup(typ); ok {
+		return false
+	}
+	if _, ok := t.versions[typ]; ok {
+		return false
+	}
+	if t.markers[typ] || t.opaque[typ] || isOpaqueType(typ) {
+		return false
+	}
+	return typ.Kind() == reflect.Interface ||
+		(!typ.Implements(customComparerType) && !reflect.PtrTo(typ).Implements(customComparerType) && !typ.Implements(recordType))
+}
+
+// add adds the cost of n values nested in the values of c.
+func (c *Cost) add(nested Cost, n int) {
+	c.Nodes += n * nested.Nodes
+	if nested.Depth+1 > c.Depth {
+		c.Depth = nested.Depth + 1
+	}
+	c.Lists += n * nested.Lists
+	c.Recursive = c.Recursive || nested.Recursive
+	c.Dynamic = c.Dynamic || nested.Dynamic
+	c.SortsMapKeys = c.SortsMapKeys || nested.SortsMapKeys
+}
@@ -0,0 +1,89 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"reflect"
+	"strings"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type Item struct {
+	Name  string
+	Count int
+	Tags  []string
+}
+
+type Inventory struct {
+	Items [2]Item
+	Stock map[string]*Item
+	Owner interface{}
+	Cache []byte `compare:"ignore"`
+}
+
+type Tree struct {
+	Value    int
+	Children []Tree
+}
+
+var _ = Describe("EstimateCost", func() {
+	It("should estimate the cost of plain types", func() {
+		Expect(make(Comparisons).EstimateCost(reflect.TypeOf(0))).To(Equal(Cost{Nodes: 1}))
+		Expect(make(Comparisons).EstimateCost(reflect.TypeOf(Item{}))).To(Equal(Cost{Nodes: 5, Depth: 2, Lists: 1}))
+	})
+
+	It("should estimate the cost of nested types", func() {
+		Expect(make(Comparisons).EstimateCost(reflect.TypeOf(Inventory{}))).To(Equal(Cost{
+			// Inventory, Items with 2 items, Stock with an *Item and Owner.
+			Nodes:   1 + (1 + 2*5) + (1 + 1 + 5) + 1,
+			Depth:   5,
+			Lists:   2 + 1 + 1,
+			Dynamic: true,
+		}))
+	})
+
+	It("should count values with comparison functions as one node", func() {
+		c := NewComparisonsOrDie(func(i1, i2 Item) int { return strings.Compare(i1.Name, i2.Name) })
+		Expect(c.EstimateCost(reflect.TypeOf([]Item{}))).To(Equal(Cost{Nodes: 2, Depth: 1, Lists: 1}))
+	})
+
+	It("should report recursive types", func() {
+		cost := make(Comparisons).EstimateCost(reflect.TypeOf(Tree{}))
+		Expect(cost.Recursive).To(BeTrue())
+		Expect(cost.Nodes).To(Equal(4))
+	})
+
+	It("should report sorting map keys", func() {
+		typ := reflect.TypeOf(map[string]int{})
+		Expect(make(Comparisons).EstimateCost(typ).SortsMapKeys).To(BeFalse())
+		Expect(make(Comparisons).EstimateCost(typ, StrictMaps())).To(Equal(Cost{Nodes: 3, Depth: 1, Lists: 1, SortsMapKeys: true}))
+		Expect(make(Comparisons).EstimateCost(reflect.TypeOf(map[string]struct{}{}), CompareAsSets())).To(Equal(Cost{Nodes: 2, Depth: 1, Lists: 1, SortsMapKeys: true}))
+	})
+
+	It("should respect scoped options", func() {
+		cost := make(Comparisons).EstimateCost(reflect.TypeOf([]map[string]int{}), ForType(map[string]int{}, StrictMaps()))
+		Expect(cost.SortsMapKeys).To(BeTrue())
+	})
+
+	It("should panic on invalid tags", func() {
+		type Invalid struct {
+			A int `compare:"weight=high"`
+		}
+		Expect(func() { make(Comparisons).EstimateCost(reflect.TypeOf(Invalid{})) }).To(Panic())
+	})
+})
@@ -0,0 +1,176 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Report reports how values of the types encountered by Coverage are compared.
+// All types are sorted by their string representation.
+type Report struct {
+	// Overrides are the types whose values are compared by comparison functions
+	// or by implementing CustomComparer or Record.
+	Overrides []reflect.Type
+	// Defaults are the types whose values are compared by the default rules of DeepCompare.
+	Defaults []reflect.Type
+	// Failures are the types whose values cannot be compared, along with why.
+	Failures map[reflect.Type]error
+}
+
+// Covered reports whether all encountered values can be compared.
+func (r Report) Covered() bool {
+	return len(r.Failures) == 0
+}
+
+// Coverage traverses the given sample values and reports which types are compared by
+// overrides, which by the default rules and which cannot be compared at all. This allows
+// auditing whether c covers a data model before comparing values of it.
+//
+// Unlike ScanTypes, Coverage only reports types actually present in the samples, e.g.
+// the dynamic types of interface values, but not the element types of empty slices.
+func (c Comparisons) Coverage(samples []interface{}) Report {
+	w := &coverageWalker{
+		comparisons: c,
+		overrides:   make(map[reflect.Type]bool),
+		defaults:    make(map[reflect.Type]bool),
+		failures:    make(map[reflect.Type]error),
+		pointers:    make(map[visit]bool),
+	}
+	for _, sample := range samples {
+		v := reflect.ValueOf(sample)
+		if v.IsValid() {
+			w.walk(v, v.Type().String(), true)
+		}
+	}
+	return Report{
+		Overrides: sortedTypes(w.overrides),
+		Defaults:  sortedTypes(w.defaults),
+		Failures:  w.failures,
+	}
+}
+
+type coverageWalker struct {
+	comparisons Comparisons
+	overrides   map[reflect.Type]bool
+	defaults    map[reflect.Type]bool
+	failures    map[reflect.Type]error
+	// pointers are the pointers already walked, to stop at cycles.
+	pointers map[visit]bool
+}
+
+func (w *coverageWalker) fail(t reflect.Type, err error) {
+	if _, ok := w.failures[t]; !ok {
+		w.failures[t] = err
+	}
+	delete(w.overrides, t)
+	delete(w.defaults, t)
+}
+
+func (w *coverageWalker) record(set map[reflect.Type]bool, t reflect.Type) {
+	if _, ok := w.failures[t]; !ok {
+		set[t] = true
+	}
+}
+
+// walk records how v is compared. path describes where v was reached,
+// exported whether v was reached via exported fields only.
+func (w *coverageWalker) walk(v reflect.Value, path string, exported bool) {
+	t := v.Type()
+	if _, ok := w.comparisons[t]; ok {
+		if !exported {
+			w.fail(t, fmt.Errorf("%s: cannot call comparison function for %v of unexported field", path, t))
+			return
+		}
+		w.record(w.overrides, t)
+		return
+	}
+	if exported && (t.Implements(customComparerType) || reflect.PtrTo(t).Implements(customComparerType) || t.Implements(recordType)) {
+		w.record(w.overrides, t)
+		return
+	}
+	if isOpaqueType(t) {
+		w.record(w.defaults, t)
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Func:
+		w.fail(t, fmt.Errorf("%s: cannot compare non-nil funcs of type %v", path, t))
+		return
+	case reflect.Complex64, reflect.Complex128:
+		w.fail(t, fmt.Errorf("%s: cannot order values of type %v", path, t))
+		return
+	case reflect.Chan, reflect.UnsafePointer:
+		if !exported {
+			w.fail(t, fmt.Errorf("%s: cannot compare %v of unexported field", path, t))
+			return
+		}
+	}
+	w.record(w.defaults, t)
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return
+		}
+		p := visit{a1: v.Pointer(), typ: t}
+		if w.pointers[p] {
+			return
+		}
+		w.pointers[p] = true
+		w.walk(v.Elem(), path, exported)
+	case reflect.Interface:
+		if !v.IsNil() {
+			w.walk(v.Elem(), path, exported)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			w.walk(v.Index(i), path+indexStep(i), exported)
+		}
+	case reflect.Map:
+		for iter := v.MapRange(); iter.Next(); {
+			w.walk(iter.Key(), path, exported)
+			w.walk(iter.Value(), path+keyStep(iter.Key()), exported)
+		}
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			tag, err := parseFieldTag(f)
+			if err == nil {
+				// Comparisons have no comparison functions to compare tagged fields with.
+				_, err = tagComparison(nil, f, tag)
+			}
+			if err != nil {
+				w.fail(t, fmt.Errorf("%s: invalid %s tag: %w", path, tagKey, err))
+				return
+			}
+			if !tag.ignore {
+				w.walk(v.Field(i), path+fieldStep(f.Name), exported && f.PkgPath == "")
+			}
+		}
+	}
+}
+
+func sortedTypes(set map[reflect.Type]bool) []reflect.Type {
+	res := make([]reflect.Type, 0, len(set))
+	for t := range set {
+		res = append(res, t)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].String() < res[j].String() })
+	return res
+}
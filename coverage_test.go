@@ -0,0 +1,62 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"reflect"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Coverage", func() {
+	type Inventory struct {
+		Items    []Severity
+		Owner    interface{}
+		Callback func()
+		done     chan struct{}
+	}
+	c := make(Comparisons)
+	Expect(c.AddOrder(Low, High, Critical)).To(Succeed())
+
+	It("should report how the types of the samples are compared", func() {
+		r := c.Coverage([]interface{}{Inventory{Items: []Severity{Low}, Owner: "me"}})
+		Expect(r.Overrides).To(Equal([]reflect.Type{reflect.TypeOf(Low)}))
+		Expect(r.Defaults).To(ConsistOf(
+			reflect.TypeOf(Inventory{}),
+			reflect.TypeOf([]Severity{}),
+			reflect.TypeOf((*interface{})(nil)).Elem(),
+			reflect.TypeOf(""),
+		))
+		Expect(r.Failures).To(HaveLen(2))
+		Expect(r.Failures[reflect.TypeOf(func() {})]).To(MatchError(ContainSubstring(".Callback")))
+		Expect(r.Failures[reflect.TypeOf(make(chan struct{}))]).To(MatchError(ContainSubstring(".done")))
+		Expect(r.Covered()).To(BeFalse())
+	})
+
+	It("should report types without failures as covered", func() {
+		r := c.Coverage([]interface{}{map[Severity][]int{High: {1}}, nil})
+		Expect(r.Covered()).To(BeTrue())
+		Expect(r.Overrides).To(Equal([]reflect.Type{reflect.TypeOf(Low)}))
+	})
+
+	It("should stop at cycles", func() {
+		type Node struct{ Next *Node }
+		n := &Node{}
+		n.Next = n
+		Expect(c.Coverage([]interface{}{n}).Defaults).To(HaveLen(2))
+	})
+})
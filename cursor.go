@@ -0,0 +1,131 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// EncodeCursor encodes v as an opaque cursor for keyset pagination, e.g. the last
+// item of a page. Items following the page are those greater than the cursor value,
+// see DecodeCursorCompare.
+//
+// Cursors are URL-safe. They contain the JSON encoding of v, so v has to round-trip
+// through encoding/json and cursors must not be used for confidential values.
+// Types that would not round-trip exactly, i.e. interfaces (whose dynamic types and large
+// integers are lost), maps with non-string keys and structs with fields omitted by
+// encoding/json, are rejected with an error. NaN and infinite floats fail to encode.
+func (c Comparisons) EncodeCursor(v interface{}) (string, error) {
+	if v != nil {
+		if err := checkCursorType(reflect.TypeOf(v), make(map[reflect.Type]bool)); err != nil {
+			return "", fmt.Errorf("error encoding cursor: %w", err)
+		}
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("error encoding cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeCursor decodes the value of the given cursor into the value ptr points to.
+// Like EncodeCursor, it rejects types that do not round-trip through encoding/json.
+func (c Comparisons) DecodeCursor(cursor string, ptr interface{}) error {
+	if t := reflect.TypeOf(ptr); t != nil && t.Kind() == reflect.Ptr {
+		if err := checkCursorType(t.Elem(), make(map[reflect.Type]bool)); err != nil {
+			return fmt.Errorf("error decoding cursor: %w", err)
+		}
+	}
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return fmt.Errorf("invalid cursor: %w", err)
+	}
+	if err := json.Unmarshal(data, ptr); err != nil {
+		return fmt.Errorf("invalid cursor: %w", err)
+	}
+	return nil
+}
+
+// DecodeCursorCompare decodes the given cursor into a value of the type of v and compares
+// v to it via DeepCompare. It returns a positive result if v comes after the cursor:
+//
+//	res, err := c.DecodeCursorCompare(cursor, item)
+//	if err == nil && res > 0 {
+//		// item belongs to the next page
+//	}
+//
+// As cursors usually are client-provided, invalid cursors result in an error instead of a panic.
+// When comparing many values against the same cursor, decode it once via DecodeCursor instead.
+func (c Comparisons) DecodeCursorCompare(cursor string, v interface{}) (int, error) {
+	if v == nil {
+		return 0, fmt.Errorf("expected value, got: nil")
+	}
+	ptr := reflect.New(reflect.TypeOf(v))
+	if err := c.DecodeCursor(cursor, ptr.Interface()); err != nil {
+		return 0, err
+	}
+	return c.TryDeepCompare(v, ptr.Elem().Interface())
+}
+
+var (
+	jsonMarshalerType   = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// checkCursorType errors if values of type t do not round-trip through encoding/json
+// exactly. Types marshaling themselves are trusted to round-trip.
+func checkCursorType(t reflect.Type, seen map[reflect.Type]bool) error {
+	if seen[t] {
+		return nil
+	}
+	seen[t] = true
+	pt := reflect.PtrTo(t)
+	if pt.Implements(jsonMarshalerType) && pt.Implements(jsonUnmarshalerType) ||
+		pt.Implements(textMarshalerType) && pt.Implements(textUnmarshalerType) {
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool, reflect.String, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return nil
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return checkCursorType(t.Elem(), seen)
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return fmt.Errorf("type %v does not round-trip through encoding/json: non-string map keys", t)
+		}
+		return checkCursorType(t.Elem(), seen)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.PkgPath != "" && !f.Anonymous || f.Tag.Get("json") == "-" {
+				return fmt.Errorf("type %v does not round-trip through encoding/json: field %s is omitted", t, f.Name)
+			}
+			if err := checkCursorType(f.Type, seen); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("type %v does not round-trip through encoding/json", t)
+}
@@ -0,0 +1,106 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"math"
+	"time"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+type Page struct {
+	Name     string
+	Priority int
+}
+
+var _ = Describe("Cursor", func() {
+	c := make(Comparisons)
+
+	It("should round-trip values", func() {
+		cursor, err := c.EncodeCursor(Page{Name: "foo", Priority: 2})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cursor).To(MatchRegexp(`^[A-Za-z0-9_-]+$`))
+
+		var page Page
+		Expect(c.DecodeCursor(cursor, &page)).To(Succeed())
+		Expect(page).To(Equal(Page{Name: "foo", Priority: 2}))
+	})
+
+	It("should compare values to cursors", func() {
+		cursor, err := c.EncodeCursor(Page{Name: "foo", Priority: 2})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(c.DecodeCursorCompare(cursor, Page{Name: "foo", Priority: 3})).To(Equal(1))
+		Expect(c.DecodeCursorCompare(cursor, Page{Name: "foo", Priority: 2})).To(Equal(0))
+		Expect(c.DecodeCursorCompare(cursor, Page{Name: "bar", Priority: 3})).To(Equal(-1))
+	})
+
+	It("should use the ordering of the comparisons", func() {
+		c := make(Comparisons)
+		Expect(c.AddOrder(Low, High, Critical)).To(Succeed())
+		cursor, err := c.EncodeCursor(High)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.DecodeCursorCompare(cursor, Critical)).To(Equal(1))
+	})
+
+	It("should error on invalid cursors", func() {
+		_, err := c.DecodeCursorCompare("not a cursor", Page{})
+		Expect(err).To(HaveOccurred())
+		_, err = c.DecodeCursorCompare("bm90IGpzb24", Page{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should error on values that cannot be encoded", func() {
+		_, err := c.EncodeCursor(func() {})
+		Expect(err).To(HaveOccurred())
+	})
+
+	DescribeTable("should reject values that do not round-trip",
+		func(v interface{}) {
+			_, err := c.EncodeCursor(v)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("non-string map keys", map[int]string{1: "a"}),
+		Entry("interfaces", []interface{}{int64(1) << 60}),
+		Entry("unexported fields", struct{ a int }{1}),
+		Entry("omitted fields", struct {
+			A int `json:"-"`
+		}{1}),
+		Entry("NaN", math.NaN()),
+		Entry("infinity", math.Inf(-1)),
+	)
+
+	It("should round-trip large integers and self-marshaling types", func() {
+		type Item struct {
+			ID      uint64
+			Created time.Time
+		}
+		item := Item{ID: 1<<64 - 1, Created: time.Unix(1, 5).UTC()}
+		cursor, err := c.EncodeCursor(item)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.DecodeCursorCompare(cursor, item)).To(Equal(0))
+	})
+
+	It("should reject decoding into values that do not round-trip", func() {
+		cursor, err := c.EncodeCursor(map[string]int{"a": 1})
+		Expect(err).NotTo(HaveOccurred())
+		var v interface{}
+		Expect(c.DecodeCursor(cursor, &v)).NotTo(Succeed())
+	})
+})
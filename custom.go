@@ -0,0 +1,50 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"reflect"
+)
+
+// CustomComparer can be implemented by types to define their ordering without
+// registering a comparison function.
+type CustomComparer interface {
+	// CompareTo compares the receiver to other, which is of the same type as the receiver.
+	// It returns a negative result if the receiver is less than other, zero if they are
+	// equal and a positive result if the receiver is greater than other.
+	// If the values cannot be compared this way, CompareTo returns false and the
+	// values are compared as if CompareTo did not exist.
+	CompareTo(other interface{}) (int, bool)
+}
+
+var customComparerType = reflect.TypeOf((*CustomComparer)(nil)).Elem()
+
+// compareCustom compares v1 and v2 via CustomComparer if their type implements it,
+// either directly or via a pointer receiver if v1 and v2 are addressable.
+func (t *traversal) compareCustom(v1, v2 reflect.Value) (int, bool) {
+	typ := v1.Type()
+	switch {
+	case typ.Kind() == reflect.Interface || !v1.CanInterface() || !v2.CanInterface():
+		return 0, false
+	case typ.Kind() == reflect.Ptr && (v1.IsNil() || v2.IsNil()):
+		// Nil pointers are ordered first, without calling any method.
+		return 0, false
+	case typ.Implements(customComparerType):
+		return v1.Interface().(CustomComparer).CompareTo(v2.Interface())
+	case v1.CanAddr() && v2.CanAddr() && reflect.PtrTo(typ).Implements(customComparerType):
+		return v1.Addr().Interface().(CustomComparer).CompareTo(v2.Addr().Interface())
+	}
+	return 0, false
+}
@@ -0,0 +1,75 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"strings"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// Version compares its parts numerically instead of lexicographically.
+type Version struct {
+	Parts []int
+}
+
+func (v Version) CompareTo(other interface{}) (int, bool) {
+	o := other.(Version)
+	for i := 0; i < len(v.Parts) && i < len(o.Parts); i++ {
+		if v.Parts[i] != o.Parts[i] {
+			return v.Parts[i] - o.Parts[i], true
+		}
+	}
+	return len(v.Parts) - len(o.Parts), true
+}
+
+// Label compares case-insensitively unless it is empty.
+type Label struct {
+	Value string
+}
+
+func (l *Label) CompareTo(other interface{}) (int, bool) {
+	o := other.(*Label)
+	if l.Value == "" || o.Value == "" {
+		return 0, false
+	}
+	return strings.Compare(strings.ToLower(l.Value), strings.ToLower(o.Value)), true
+}
+
+var _ = Describe("CustomComparer", func() {
+	c := make(Comparisons)
+
+	It("should use CompareTo", func() {
+		Expect(c.DeepCompare(Version{[]int{1, 10}}, Version{[]int{1, 9, 1}})).To(BeNumerically(">", 0))
+		Expect(c.DeepCompare(Version{[]int{1}}, Version{[]int{1, 0}})).To(BeNumerically("<", 0))
+	})
+
+	It("should use CompareTo of pointer receivers for addressable values", func() {
+		Expect(c.DeepCompare(&Label{"A"}, &Label{"a"})).To(Equal(0))
+		Expect(c.DeepCompare([]Label{{"A"}}, []Label{{"a"}})).To(Equal(0))
+		Expect(c.DeepCompare((*Label)(nil), &Label{"a"})).To(Equal(-1))
+	})
+
+	It("should fall back if CompareTo does not handle the values", func() {
+		Expect(c.DeepCompare(&Label{""}, &Label{"a"})).To(Equal(-1))
+	})
+
+	It("should prefer registered comparison functions", func() {
+		c := NewComparisonsOrDie(func(v1, v2 Version) int { return 0 })
+		Expect(c.DeepCompare(Version{[]int{1}}, Version{[]int{2}})).To(Equal(0))
+	})
+})
@@ -0,0 +1,74 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// DebugTotalOrder orders all values instead of failing, for exploratory sorting and
+// inspecting values while debugging. Values that cannot be compared by the default rules
+// are ordered as follows:
+//
+//   - values of different types, including the dynamic types of interface values, by their types,
+//   - funcs by their code pointers, so closures of the same function are equal,
+//   - channels and unsafe pointers by their addresses,
+//   - complex numbers by their real parts first and their imaginary parts second,
+//   - values only reachable via unexported fields are accessed via unsafe, so comparison
+//     functions and CompareTo methods apply to them, and are otherwise considered equal and
+//   - values a partial order considers incomparable are considered equal.
+//
+// The resulting order depends on memory layout and addresses, so it is neither portable nor
+// stable across program runs, and it is not consistent with the order of the default rules.
+// Never rely on it outside of debugging. Comparison functions and CompareTo methods may still
+// panic, and comparisons still fail if nested deeper than MaxDepth.
+func DebugTotalOrder() Option {
+	return func(o *options) {
+		o.debugTotalOrder = true
+	}
+}
+
+// expose returns v made accessible via unsafe if the traversal orders debug values and v is
+// only reachable via unexported fields, so its value can be passed to functions.
+func (t *traversal) expose(v reflect.Value) reflect.Value {
+	if !t.debugTotalOrder || v.CanInterface() || !v.CanAddr() {
+		return v
+	}
+	return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem()
+}
+
+// addressable returns an addressable copy of v, so the fields of v can be exposed.
+func addressable(v reflect.Value) reflect.Value {
+	res := reflect.New(v.Type()).Elem()
+	res.Set(v)
+	return res
+}
+
+// compareDebug orders values of kinds that cannot be compared by the default rules,
+// see DebugTotalOrder.
+func compareDebug(v1, v2 reflect.Value) int {
+	switch v1.Kind() {
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return compareUInt64(uint64(v1.Pointer()), uint64(v2.Pointer()))
+	case reflect.Complex64, reflect.Complex128:
+		c1, c2 := v1.Complex(), v2.Complex()
+		if res := compareFloat64(real(c1), real(c2)); res != 0 {
+			return res
+		}
+		return compareFloat64(imag(c1), imag(c2))
+	}
+	return 0
+}
@@ -0,0 +1,98 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"errors"
+	"sort"
+	"unsafe"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type debugHidden struct{ a int }
+
+type DebugValue struct {
+	Name string
+	h    debugHidden
+	ch   chan int
+	fn   func()
+}
+
+var _ = Describe("DebugTotalOrder", func() {
+	c := NewComparisonsOrDie(func(h1, h2 debugHidden) int { return h1.a - h2.a }, compareSpans)
+	cmp := c.NewComparer(DebugTotalOrder())
+
+	expectAntisymmetric := func(a, b interface{}) int {
+		res := cmp.Compare(a, b)
+		Expect(cmp.Compare(b, a)).To(Equal(-res))
+		return res
+	}
+
+	It("should order values of different types by their types", func() {
+		Expect(expectAntisymmetric(1, "a")).NotTo(BeZero())
+		Expect(expectAntisymmetric([]interface{}{1}, []interface{}{"a"})).NotTo(BeZero())
+		Expect(expectAntisymmetric([]error{errors.New("a")}, []error{&OtherError{"a"}})).NotTo(BeZero())
+	})
+
+	It("should order funcs, channels and unsafe pointers by their addresses", func() {
+		f1, f2 := func() {}, func() {}
+		Expect(expectAntisymmetric(f1, f2)).NotTo(BeZero())
+		Expect(cmp.Compare(f1, f1)).To(Equal(0))
+
+		ch1, ch2 := make(chan int), make(chan int)
+		Expect(expectAntisymmetric(ch1, ch2)).NotTo(BeZero())
+		Expect(cmp.Compare(ch1, ch1)).To(Equal(0))
+
+		i1, i2 := 1, 1
+		Expect(expectAntisymmetric(unsafe.Pointer(&i1), unsafe.Pointer(&i2))).NotTo(BeZero())
+	})
+
+	It("should order complex numbers by their real parts first", func() {
+		Expect(cmp.Compare(complex(1, 2), complex(2, 1))).To(Equal(-1))
+		Expect(cmp.Compare(complex(1, 2), complex(1, 1))).To(Equal(1))
+		Expect(cmp.Compare(complex(1, 2), complex(1, 2))).To(Equal(0))
+	})
+
+	It("should apply comparison functions to unexported fields", func() {
+		Expect(cmp.Compare(DebugValue{h: debugHidden{2}}, DebugValue{h: debugHidden{1}})).To(Equal(1))
+		Expect(cmp.Compare(&DebugValue{h: debugHidden{1}}, &DebugValue{h: debugHidden{2}})).To(Equal(-1))
+	})
+
+	It("should order unexported channels and funcs", func() {
+		Expect(expectAntisymmetric(DebugValue{ch: make(chan int)}, DebugValue{ch: make(chan int)})).NotTo(BeZero())
+		f := func() {}
+		Expect(cmp.Compare(DebugValue{fn: f}, DebugValue{fn: f})).To(Equal(0))
+	})
+
+	It("should consider incomparable values equal", func() {
+		Expect(cmp.Compare(Span{0, 2}, Span{1, 3})).To(Equal(0))
+	})
+
+	It("should sort values of mixed types", func() {
+		values := []interface{}{"b", 2, nil, func() {}, "a", 1}
+		Expect(func() {
+			sort.Slice(values, func(i, j int) bool { return cmp.Compare(values[i], values[j]) < 0 })
+		}).NotTo(Panic())
+		Expect(values[0]).To(BeNil())
+	})
+
+	It("should still panic without DebugTotalOrder", func() {
+		Expect(func() { c.DeepCompare(1, "a") }).To(Panic())
+		Expect(func() { c.DeepCompare(DebugValue{h: debugHidden{1}}, DebugValue{}) }).To(Panic())
+	})
+})
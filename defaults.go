@@ -0,0 +1,122 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Defaults compares unset values, i.e. zero values, of the types of the given defaults as the
+// respective defaults. This keeps values that only differ by defaults applied by a server (or
+// any other party) from being reported as different, e.g. Defaults(Protocol("TCP")) compares
+// the empty Protocol equal to "TCP".
+//
+// Defaults panics if any default is an untyped nil.
+func Defaults(defaults ...interface{}) Option {
+	values := make([]reflect.Value, len(defaults))
+	for i, d := range defaults {
+		if d == nil {
+			panic("expected default, got: nil")
+		}
+		values[i], _ = defaultValue(reflect.TypeOf(d), d)
+	}
+	return func(o *options) {
+		if o.defaults == nil {
+			o.defaults = make(map[reflect.Type]reflect.Value)
+		}
+		for _, v := range values {
+			o.defaults[v.Type()] = v
+		}
+	}
+}
+
+// FieldDefaults compares unset fields, i.e. fields holding zero values, of the struct type of
+// sample as the defaults given by field name. The default of a pointer field may also be given
+// as a value of the type pointed to, e.g. FieldDefaults(Spec{}, map[string]interface{}{"Replicas": 1})
+// compares a nil Replicas *int field equal to one pointing to 1.
+// FieldDefaults take precedence over Defaults for the types of the fields.
+//
+// FieldDefaults panics if sample is not a struct, if any name does not denote a field
+// declared by it or if any default cannot be assigned to its field.
+func FieldDefaults(sample interface{}, defaults map[string]interface{}) Option {
+	t := reflect.TypeOf(sample)
+	if t == nil || t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("expected struct, got: %T", sample))
+	}
+	values := make(map[string]reflect.Value, len(defaults))
+	for name, d := range defaults {
+		f, ok := t.FieldByName(name)
+		if !ok {
+			panic(fmt.Sprintf("type %v has no field %s", t, name))
+		}
+		if len(f.Index) > 1 {
+			panic(fmt.Sprintf("field %s of type %v is promoted from embedded type %v", name, t, t.Field(f.Index[0]).Type))
+		}
+		v, ok := defaultValue(f.Type, d)
+		if !ok {
+			panic(fmt.Sprintf("cannot use %T as default of field %s of type %v", d, name, t))
+		}
+		values[name] = v
+	}
+	return func(o *options) {
+		if o.fieldDefaults == nil {
+			o.fieldDefaults = make(map[reflect.Type]map[string]reflect.Value)
+		}
+		if o.fieldDefaults[t] == nil {
+			o.fieldDefaults[t] = make(map[string]reflect.Value)
+		}
+		for name, v := range values {
+			o.fieldDefaults[t][name] = v
+		}
+	}
+}
+
+// defaultValue returns d as a default for values of type typ. If d is not assignable to typ
+// but to the type typ points to, the default is a pointer to d.
+func defaultValue(typ reflect.Type, d interface{}) (reflect.Value, bool) {
+	v := reflect.ValueOf(d)
+	switch {
+	case !v.IsValid():
+		return reflect.Value{}, false
+	case v.Type().AssignableTo(typ):
+		res := reflect.New(typ).Elem()
+		res.Set(v)
+		return res, true
+	case typ.Kind() == reflect.Ptr && v.Type().AssignableTo(typ.Elem()):
+		res := reflect.New(typ.Elem())
+		res.Elem().Set(v)
+		return res, true
+	}
+	return reflect.Value{}, false
+}
+
+// applyDefaults replaces v1 and v2 by the default of the field holding them, or of their type,
+// if they are unset.
+func (t *traversal) applyDefaults(field structField, v1, v2 reflect.Value) (reflect.Value, reflect.Value) {
+	def := field.def
+	if !def.IsValid() && len(t.defaults) > 0 && v1.IsValid() {
+		def = t.defaults[v1.Type()]
+	}
+	return defaulted(v1, def), defaulted(v2, def)
+}
+
+// defaulted returns def if it is valid and v is unset, and v otherwise.
+func defaulted(v, def reflect.Value) reflect.Value {
+	if def.IsValid() && v.IsValid() && v.Type() == def.Type() && v.IsZero() {
+		return def
+	}
+	return v
+}
@@ -0,0 +1,127 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"strings"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type Protocol string
+
+type Port struct {
+	Number   int
+	Protocol Protocol
+}
+
+type Rollout struct {
+	Name     string
+	Replicas *int
+	Ports    []Port
+	Strategy string
+}
+
+var _ = Describe("Defaults", func() {
+	one, two := 1, 2
+
+	Describe("FieldDefaults", func() {
+		cmp := make(Comparisons).NewComparer(FieldDefaults(Rollout{}, map[string]interface{}{
+			"Replicas": 1,
+			"Strategy": "RollingUpdate",
+		}))
+
+		It("should compare unset fields as their defaults", func() {
+			Expect(cmp.Compare(Rollout{Name: "a"}, Rollout{Name: "a", Replicas: &one, Strategy: "RollingUpdate"})).To(Equal(0))
+			Expect(cmp.Compare(Rollout{Replicas: &one}, Rollout{})).To(Equal(0))
+		})
+
+		It("should compare set fields by their values", func() {
+			Expect(cmp.Compare(Rollout{}, Rollout{Replicas: &two})).To(Equal(-1))
+			Expect(cmp.Compare(Rollout{Strategy: "Recreate"}, Rollout{})).To(Equal(-1))
+		})
+
+		It("should not report differences between unset fields and their defaults", func() {
+			diffs, err := make(Comparisons).Diff(
+				Rollout{Name: "a"},
+				Rollout{Name: "a", Replicas: &two, Strategy: "RollingUpdate"},
+				FieldDefaults(Rollout{}, map[string]interface{}{"Replicas": 1, "Strategy": "RollingUpdate"}),
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diffs).To(HaveLen(1))
+			Expect(diffs[0].Path).To(Equal(".Replicas"))
+		})
+
+		It("should apply defaults to fields with comparison functions", func() {
+			c := NewComparisonsOrDie(func(s1, s2 string) int { return strings.Compare(s1, s2) })
+			cmp := c.NewComparer(FieldDefaults(Rollout{}, map[string]interface{}{"Strategy": "RollingUpdate"}))
+			Expect(cmp.Compare(Rollout{}, Rollout{Strategy: "RollingUpdate"})).To(Equal(0))
+		})
+
+		It("should apply defaults when tracking values", func() {
+			baseline, current := Rollout{Replicas: &one}, Rollout{}
+			tr := make(Comparisons).NewTracker(&baseline, &current, FieldDefaults(Rollout{}, map[string]interface{}{"Replicas": 1}))
+			Expect(tr.Compare()).To(Equal(0))
+			current.Replicas = &two
+			tr.Dirty("Replicas")
+			Expect(tr.Compare()).To(Equal(-1))
+		})
+
+		It("should panic for invalid defaults", func() {
+			Expect(func() { FieldDefaults(1, nil) }).To(Panic())
+			Expect(func() { FieldDefaults(Rollout{}, map[string]interface{}{"Missing": 1}) }).To(Panic())
+			Expect(func() { FieldDefaults(Rollout{}, map[string]interface{}{"Replicas": "1"}) }).To(Panic())
+			Expect(func() { FieldDefaults(Rollout{}, map[string]interface{}{"Replicas": nil}) }).To(Panic())
+		})
+	})
+
+	Describe("Defaults", func() {
+		cmp := make(Comparisons).NewComparer(Defaults(Protocol("TCP")))
+
+		It("should compare unset values as the default of their type", func() {
+			Expect(cmp.Compare(Protocol(""), Protocol("TCP"))).To(Equal(0))
+			Expect(cmp.Compare(
+				Rollout{Ports: []Port{{Number: 80}}},
+				Rollout{Ports: []Port{{Number: 80, Protocol: "TCP"}}},
+			)).To(Equal(0))
+			Expect(cmp.Compare(map[string]Protocol{"a": ""}, map[string]Protocol{"a": "TCP"})).To(Equal(0))
+		})
+
+		It("should compare set values by their values", func() {
+			Expect(cmp.Compare(Port{Number: 53, Protocol: "UDP"}, Port{Number: 53})).To(Equal(1))
+		})
+
+		It("should be overridden by field defaults", func() {
+			cmp := make(Comparisons).NewComparer(
+				Defaults(Protocol("TCP")),
+				FieldDefaults(Port{}, map[string]interface{}{"Protocol": Protocol("UDP")}),
+			)
+			Expect(cmp.Compare(Port{}, Port{Protocol: "UDP"})).To(Equal(0))
+			Expect(cmp.Compare(Protocol(""), Protocol("TCP"))).To(Equal(0))
+		})
+
+		It("should apply in scopes only", func() {
+			cmp := make(Comparisons).NewComparer(ForType(Rollout{}, Defaults(Protocol("TCP"))))
+			Expect(cmp.Compare(Rollout{Ports: []Port{{}}}, Rollout{Ports: []Port{{Protocol: "TCP"}}})).To(Equal(0))
+			Expect(cmp.Compare(Port{}, Port{Protocol: "TCP"})).To(Equal(-1))
+		})
+
+		It("should panic for untyped nil", func() {
+			Expect(func() { Defaults(nil) }).To(Panic())
+		})
+	})
+})
@@ -0,0 +1,387 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+	"unsafe"
+)
+
+// Difference is a difference between two values found by Diff.
+type Difference struct {
+	// Path is the path to the different values, e.g. '.Spec.Containers[0].Name', see Result.Path.
+	// It is empty if the values differ at the root.
+	Path string
+	// A and B are the different values. They are nil if missing, e.g. for map keys present
+	// in only one of the maps, or if they are only reachable via unexported fields.
+	A, B interface{}
+	// Result is the result of comparing A to B.
+	Result int
+	// Comparator describes what decided the difference, see Result.Comparator.
+	// It is 'key missing' for map keys and 'length' for slice elements present in only one of the values.
+	Comparator string
+}
+
+// Diff compares a1 and a2 like DeepCompare, but instead of stopping at the first difference,
+// it reports all differences in order, up to the limit set via MaxDifferences.
+// Values with comparison functions, values implementing CustomComparer and other values
+// not compared by the default rules are reported as a whole.
+//
+// Diff returns an error if the values cannot be compared, along with the differences found so far.
+func (c Comparisons) Diff(a1, a2 interface{}, opts ...Option) ([]Difference, error) {
+	return c.newTraversal(opts...).diff(a1, a2)
+}
+
+// diff collects the differences of a1 and a2, see Diff.
+func (t *traversal) diff(a1, a2 interface{}) ([]Difference, error) {
+	v1 := reflect.ValueOf(a1)
+	v2 := reflect.ValueOf(a2)
+	if v1.IsValid() && v2.IsValid() && v1.Type() != v2.Type() {
+		return nil, &typeMismatchError{t1: v1.Type(), t2: v2.Type()}
+	}
+	d := newDiffer(t)
+	defer d.release()
+	err := d.diff(v1, v2, 0)
+	return d.diffs, err
+}
+
+// DiffFunc compares a1 and a2 like Diff, but instead of collecting the differences, it calls
+// fn with each difference in order until fn returns false. This avoids retaining the
+// differences of large values. With ReuseReports, the paths of the differences are only
+// valid until fn returns.
+//
+// DiffFunc returns an error if the values cannot be compared.
+func (c Comparisons) DiffFunc(a1, a2 interface{}, fn func(Difference) bool, opts ...Option) error {
+	v1 := reflect.ValueOf(a1)
+	v2 := reflect.ValueOf(a2)
+	if v1.IsValid() && v2.IsValid() && v1.Type() != v2.Type() {
+		return &typeMismatchError{t1: v1.Type(), t2: v2.Type()}
+	}
+	d := newDiffer(c.newTraversal(opts...))
+	defer d.release()
+	d.fn = fn
+	return d.diff(v1, v2, 0)
+}
+
+// differ collects the differences of values.
+type differ struct {
+	traversal *traversal
+	// steps are the steps of the path to the currently compared values.
+	steps []pathStep
+	diffs []Difference
+	// paths are the steps of the paths of diffs, if set.
+	paths [][]pathStep
+	// fn, if set, is called with each difference instead of collecting it.
+	fn func(Difference) bool
+	// reported is the number of differences reported so far.
+	reported int
+	// stopped reports whether fn returned false.
+	stopped bool
+	// buf is the buffer paths are rendered into.
+	buf []byte
+	// entered tracks the references that are being diffed, so cyclic values end the recursion.
+	entered map[visit]struct{}
+	// buffers holds the buffers of the differ while they are in use.
+	buffers *differBuffer
+}
+
+// differBuffers pools the buffers of differs, so diffing repeatedly reuses them.
+var differBuffers = sync.Pool{
+	New: func() interface{} { return &differBuffer{} },
+}
+
+// differBuffer are the buffers of a differ.
+type differBuffer struct {
+	steps []pathStep
+	buf   []byte
+}
+
+func newDiffer(t *traversal) *differ {
+	b := differBuffers.Get().(*differBuffer)
+	return &differ{traversal: t, steps: b.steps[:0], buf: b.buf[:0], buffers: b}
+}
+
+// release returns the buffers of d to the pool. d must not be used afterwards.
+func (d *differ) release() {
+	steps := d.steps[:cap(d.steps)]
+	for i := range steps {
+		// Don't retain the keys of compared maps.
+		steps[i] = pathStep{}
+	}
+	d.buffers.steps, d.buffers.buf = steps[:0], d.buf[:0]
+	differBuffers.Put(d.buffers)
+}
+
+func (d *differ) full() bool {
+	max := d.traversal.maxDifferences
+	return d.stopped || max > 0 && d.reported >= max
+}
+
+// path returns the path to the currently compared values.
+func (d *differ) path() string {
+	return string(d.renderPath())
+}
+
+// renderPath renders the path to the currently compared values into the buffer of d.
+func (d *differ) renderPath() []byte {
+	d.buf = d.buf[:0]
+	for _, s := range d.steps {
+		switch {
+		case s.redacted:
+			d.buf = append(d.buf, fmt.Sprintf("[%#v]", d.traversal.mask(interfaceOf(s.key)))...)
+		case s.key.IsValid():
+			d.buf = append(d.buf, d.traversal.renderKeyStep(s.key)...)
+		case s.field != "":
+			d.buf = append(append(d.buf, '.'), s.field...)
+		default:
+			d.buf = append(strconv.AppendInt(append(d.buf, '['), int64(s.index), 10), ']')
+		}
+	}
+	return d.buf
+}
+
+func (d *differ) report(v1, v2 reflect.Value, res int, comparator string) {
+	d.reported++
+	if d.paths != nil {
+		// Patches have to carry the actual values to apply.
+		d.diffs = append(d.diffs, Difference{Path: d.path(), A: interfaceOf(v1), B: interfaceOf(v2), Result: res, Comparator: comparator})
+		d.paths = append(d.paths, append([]pathStep(nil), d.steps...))
+		return
+	}
+	diff := Difference{
+		A:          d.traversal.reportValue(v1),
+		B:          d.traversal.reportValue(v2),
+		Result:     res,
+		Comparator: comparator,
+	}
+	if d.fn == nil {
+		diff.Path = d.path()
+		d.diffs = append(d.diffs, diff)
+		return
+	}
+	if d.traversal.reuseReports {
+		// The path is only valid until fn returns, see ReuseReports.
+		buf := d.renderPath()
+		diff.Path = *(*string)(unsafe.Pointer(&buf))
+	} else {
+		diff.Path = d.path()
+	}
+	d.stopped = !d.fn(diff)
+}
+
+// interfaceOf returns the value of v as an interface{}, or nil if v is invalid or unexported.
+func interfaceOf(v reflect.Value) interface{} {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil
+	}
+	return v.Interface()
+}
+
+// reportAt reports the values at the path step s, of which only one is present.
+func (d *differ) reportAt(s pathStep, v1, v2 reflect.Value, comparator string) {
+	if d.full() {
+		return
+	}
+	s.redacted = d.traversal.redact && s.key.IsValid()
+	d.steps = append(d.steps, s)
+	d.report(v1, v2, compareBool(v1.IsValid(), v2.IsValid()), comparator)
+	d.steps = d.steps[:len(d.steps)-1]
+}
+
+// diffAt diffs v1 and v2 at the path step s.
+func (d *differ) diffAt(s pathStep, v1, v2 reflect.Value, depth int) error {
+	s.redacted = d.traversal.redact && s.key.IsValid()
+	d.steps = append(d.steps, s)
+	defer func() { d.steps = d.steps[:len(d.steps)-1] }()
+	if t := d.traversal; t.paths != nil {
+		t.enterStep(s)
+		defer t.leaveStep()
+	}
+	return d.diff(v1, v2, depth)
+}
+
+// compare compares v1 and v2 as a whole, reporting them if they differ.
+func (d *differ) compare(v1, v2 reflect.Value, depth int) error {
+	t := d.traversal
+	field, derefs := t.field, t.derefs
+	res, err := t.deepValueCompare(v1, v2, depth)
+	if err != nil {
+		return prependStep(err, d.path())
+	}
+	if res != 0 {
+		if field.compare.IsValid() {
+			d.report(v1, v2, res, funcName(field.compare))
+		} else {
+			d.report(v1, v2, res, t.describe(v1, v2, derefs))
+		}
+	}
+	return nil
+}
+
+func (d *differ) diff(v1, v2 reflect.Value, depth int) error {
+	t := d.traversal
+	field, derefs := t.field, t.derefs
+	t.field, t.derefs = structField{}, 0
+	if d.full() {
+		return nil
+	}
+	if t.done != nil {
+		if err := t.interrupted(); err != nil {
+			return prependStep(err, d.path())
+		}
+	}
+	v1, v2 = t.applyDefaults(field, v1, v2)
+	if v, ok := referenceVisit(v1, v2); ok {
+		// Like reflect.DeepEqual, references encountered again while diffing them
+		// are assumed equal, as their differences are reported further up.
+		if _, ok := d.entered[v]; ok {
+			return nil
+		}
+		if d.entered == nil {
+			d.entered = make(map[visit]struct{})
+		}
+		d.entered[v] = struct{}{}
+		defer delete(d.entered, v)
+	}
+	if field.redact && !t.redact {
+		prev := t.scope
+		t.setScope(prev.redactedChild())
+		defer t.setScope(prev)
+	}
+	// Values rendered by formatters or compared by comparison functions of their fields are reported as a whole.
+	if !v1.IsValid() || !v2.IsValid() || field.compare.IsValid() || !t.plain(v1.Type()) || t.formatters[v1.Type()].IsValid() {
+		t.field = field
+		return d.compare(v1, v2, depth)
+	}
+
+	switch v1.Kind() {
+	case reflect.Struct:
+		fields, err := t.structFields(v1.Type())
+		if err != nil {
+			return err
+		}
+		for _, f := range fields {
+			t.field = f
+			if err := d.diffAt(fieldElem(f.name), v1.Field(f.index), v2.Field(f.index), depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Array:
+		for i := 0; i < v1.Len(); i++ {
+			if err := d.diffAt(indexElem(i), v1.Index(i), v2.Index(i), depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Slice:
+		if v1.Len() == 0 || v2.Len() == 0 {
+			// Empty slices are compared as a whole, see DeepCompare.
+			return d.compare(v1, v2, depth)
+		}
+		if key := t.mergeKey(v1.Type(), field.mergeKey); key != nil {
+			return d.diffKeyedLists(v1, v2, key, depth)
+		}
+		for i := 0; i < v1.Len() || i < v2.Len(); i++ {
+			var e1, e2 reflect.Value
+			if i < v1.Len() {
+				e1 = v1.Index(i)
+			}
+			if i < v2.Len() {
+				e2 = v2.Index(i)
+			}
+			if !e1.IsValid() || !e2.IsValid() {
+				d.reportAt(indexElem(i), e1, e2, "length")
+				continue
+			}
+			if err := d.diffAt(indexElem(i), e1, e2, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Map:
+		if t.compareAsSets && isSetType(v1.Type()) {
+			return d.diffSets(v1, v2, depth)
+		}
+		if v1.Len() == 0 || v2.Len() == 0 {
+			return d.compare(v1, v2, depth)
+		}
+		return d.diffMaps(v1, v2, depth)
+	case reflect.Ptr:
+		if v1.IsNil() || v2.IsNil() {
+			t.derefs = derefs
+			return d.compare(v1, v2, depth)
+		}
+		t.derefs = derefs + 1
+		return d.diff(v1.Elem(), v2.Elem(), depth+1)
+	case reflect.Interface:
+		if v1.IsNil() || v2.IsNil() || v1.Elem().Type() != v2.Elem().Type() {
+			return d.compare(v1, v2, depth)
+		}
+		return d.diff(v1.Elem(), v2.Elem(), depth+1)
+	default:
+		return d.compare(v1, v2, depth)
+	}
+}
+
+// referenceVisit returns the visit of the references v1 and v2, if both are non-nil
+// pointers, maps or slices.
+func referenceVisit(v1, v2 reflect.Value) (visit, bool) {
+	if !v1.IsValid() || !v2.IsValid() {
+		return visit{}, false
+	}
+	switch v1.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if v1.IsNil() || v2.IsNil() {
+			return visit{}, false
+		}
+		return visit{v1.Pointer(), v2.Pointer(), v1.Type()}, true
+	}
+	return visit{}, false
+}
+
+// diffMaps diffs the entries of the maps m1 and m2 in key order.
+func (d *differ) diffMaps(m1, m2 reflect.Value, depth int) error {
+	t := d.traversal
+	keys := m1.MapKeys()
+	for iter := m2.MapRange(); iter.Next(); {
+		if !m1.MapIndex(iter.Key()).IsValid() {
+			keys = append(keys, iter.Key())
+		}
+	}
+	if err := t.sortValues(keys); err != nil {
+		return prependStep(err, d.path())
+	}
+	for _, k := range keys {
+		e1, e2 := m1.MapIndex(k), m2.MapIndex(k)
+		if e1.IsValid() && e2.IsValid() || t.missingAsZero && !t.strictMaps {
+			if !e1.IsValid() {
+				e1 = reflect.Zero(m1.Type().Elem())
+			}
+			if !e2.IsValid() {
+				e2 = reflect.Zero(m2.Type().Elem())
+			}
+			if err := d.diffAt(keyElem(k), e1, e2, depth+1); err != nil {
+				return err
+			}
+			continue
+		}
+		d.reportAt(keyElem(k), e1, e2, "key missing")
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"testing"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Diff", func() {
+	c := make(Comparisons)
+	o1 := Object{
+		Name:   "a",
+		Labels: map[string]string{"app": "web", "tier": "frontend"},
+		Spec:   &Spec{Image: "nginx"},
+	}
+	o2 := Object{
+		Name:   "b",
+		Labels: map[string]string{"app": "api", "env": "prod"},
+		Spec:   &Spec{Image: "alpine"},
+	}
+
+	It("should report all differences in order", func() {
+		diffs, err := c.Diff(o1, o2)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diffs).To(Equal([]Difference{
+			{Path: ".Name", A: "a", B: "b", Result: -1, Comparator: "string"},
+			{Path: `.Labels["app"]`, A: "web", B: "api", Result: 1, Comparator: "string"},
+			{Path: `.Labels["env"]`, B: "prod", Result: -1, Comparator: "key missing"},
+			{Path: `.Labels["tier"]`, A: "frontend", Result: 1, Comparator: "key missing"},
+			{Path: ".Spec.Image", A: "nginx", B: "alpine", Result: 1, Comparator: "string"},
+		}))
+	})
+
+	It("should report nothing for equal values", func() {
+		Expect(c.Diff(o1, o1)).To(BeEmpty())
+	})
+
+	It("should stop after MaxDifferences", func() {
+		diffs, err := c.Diff(o1, o2, MaxDifferences(2))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diffs).To(HaveLen(2))
+		Expect(diffs[1].Path).To(Equal(`.Labels["app"]`))
+	})
+
+	It("should report elements present in only one slice", func() {
+		diffs, err := c.Diff([]int{1, 2}, []int{1, 3, 4})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diffs).To(Equal([]Difference{
+			{Path: "[1]", A: 2, B: 3, Result: -1, Comparator: "int"},
+			{Path: "[2]", B: 4, Result: -1, Comparator: "length"},
+		}))
+	})
+
+	It("should report values with comparison functions as a whole", func() {
+		c := NewComparisonsOrDie(compareSpecsByImage)
+		diffs, err := c.Diff(o1, o2)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diffs[len(diffs)-1]).To(Equal(Difference{
+			Path: ".Spec", A: *o1.Spec, B: *o2.Spec, Result: 1,
+			Comparator: "github.com/adracus/reflcompare_test.compareSpecsByImage",
+		}))
+	})
+
+	It("should report the level of nils in pointer chains", func() {
+		type Chains struct {
+			A **int
+			B ***int
+		}
+		diffs, err := c.Diff(Chains{A: intPtrPtr(intPtr(1)), B: new(**int)}, Chains{A: new(*int), B: intPtrPtrPtr(intPtrPtr(intPtr(1)))})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diffs).To(HaveLen(2))
+		Expect(diffs[0].Path).To(Equal(".A"))
+		Expect(diffs[0].Comparator).To(Equal("nil at level 2"))
+		Expect(diffs[1].Path).To(Equal(".B"))
+		Expect(diffs[1].Comparator).To(Equal("nil at level 2"))
+
+		diffs, err = c.Diff(Chains{B: intPtrPtrPtr(intPtrPtr(nil))}, Chains{B: intPtrPtrPtr(intPtrPtr(intPtr(1)))})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diffs).To(HaveLen(1))
+		Expect(diffs[0].Comparator).To(Equal("nil at level 3"))
+	})
+
+	It("should stop at cyclic values", func() {
+		type Node struct {
+			Value int
+			Next  *Node
+		}
+		n1 := &Node{Value: 1}
+		n1.Next = n1
+		n2 := &Node{Value: 2}
+		n2.Next = n2
+		Expect(c.Diff(n1, n2)).To(Equal([]Difference{
+			{Path: ".Value", A: 1, B: 2, Result: -1, Comparator: "int"},
+		}))
+		Expect(c.Diff(n1, n1)).To(BeEmpty())
+	})
+
+	It("should return errors with the path", func() {
+		_, err := c.Diff([]interface{}{1}, []interface{}{"a"})
+		Expect(err).To(MatchError(ContainSubstring("[0]")))
+		_, err = c.Diff(1, "a")
+		Expect(err).To(MatchError(ErrTypeMismatch))
+	})
+
+	Describe("DiffFunc", func() {
+		collect := func(a, b interface{}, opts ...Option) []Difference {
+			var diffs []Difference
+			Expect(c.DiffFunc(a, b, func(d Difference) bool {
+				d.Path = string([]byte(d.Path))
+				diffs = append(diffs, d)
+				return true
+			}, opts...)).To(Succeed())
+			return diffs
+		}
+
+		It("should report the same differences as Diff", func() {
+			diffs, err := c.Diff(o1, o2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(collect(o1, o2)).To(Equal(diffs))
+			Expect(collect(o1, o2, ReuseReports())).To(Equal(diffs))
+		})
+
+		It("should stop if fn returns false", func() {
+			var paths []string
+			Expect(c.DiffFunc(o1, o2, func(d Difference) bool {
+				paths = append(paths, d.Path)
+				return len(paths) < 2
+			})).To(Succeed())
+			Expect(paths).To(Equal([]string{".Name", `.Labels["app"]`}))
+		})
+
+		It("should reuse the memory of paths with ReuseReports", func() {
+			a := make([][]int, 100)
+			b := make([][]int, 100)
+			for i := range a {
+				a[i], b[i] = []int{i}, []int{i + 1}
+			}
+			diff := func(opts ...Option) float64 {
+				return testing.AllocsPerRun(10, func() {
+					_ = c.DiffFunc(a, b, func(Difference) bool { return true }, opts...)
+				})
+			}
+			Expect(diff(ReuseReports())).To(BeNumerically("<", diff()))
+		})
+
+		It("should return errors", func() {
+			Expect(c.DiffFunc(1, "a", func(Difference) bool { return true })).To(MatchError(ErrTypeMismatch))
+		})
+	})
+})
@@ -0,0 +1,50 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+// Divergence is a pair of values two Comparisons order differently.
+type Divergence struct {
+	// A and B are the compared values.
+	A, B interface{}
+	// Old and New are the signs (-1, 0 or 1) of comparing A to B with the old and the new Comparisons.
+	Old, New int
+	// OldErr and NewErr are the errors comparing A to B with the old and the new Comparisons, if any.
+	OldErr, NewErr error
+}
+
+// CompareComparers compares all pairs of values of the corpus using both cOld and cNew
+// (like TryDeepCompare) and reports the pairs they order differently, in corpus order.
+// Pairs one of the Comparisons fails to compare diverge unless both fail.
+// This allows safely rolling out changes of comparison functions that affect persisted
+// sort orders.
+func CompareComparers(cOld, cNew Comparisons, corpus []interface{}) []Divergence {
+	var res []Divergence
+	for i := range corpus {
+		for j := i + 1; j < len(corpus); j++ {
+			a, b := corpus[i], corpus[j]
+			o, oErr := cOld.TryDeepCompare(a, b)
+			n, nErr := cNew.TryDeepCompare(a, b)
+			o, n = sign(o), sign(n)
+			if (oErr != nil) != (nErr != nil) || oErr == nil && o != n {
+				res = append(res, Divergence{A: a, B: b, Old: o, New: n, OldErr: oErr, NewErr: nErr})
+			}
+		}
+	}
+	return res
+}
+
+func sign(res int) int {
+	return compareInt64(int64(res), 0)
+}
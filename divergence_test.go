@@ -0,0 +1,50 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CompareComparers", func() {
+	cOld := make(Comparisons)
+	cNew := NewComparisonsOrDie(func(s1, s2 Severity) int {
+		return len(s1) - len(s2)
+	})
+
+	It("should report pairs ordered differently", func() {
+		divergences := CompareComparers(cOld, cNew, []interface{}{Low, High, Critical})
+		Expect(divergences).To(Equal([]Divergence{
+			{A: Low, B: High, Old: 1, New: -1},
+			{A: Low, B: Critical, Old: 1, New: -1},
+			{A: High, B: Critical, Old: 1, New: -1},
+		}))
+	})
+
+	It("should report pairs only one of the Comparisons fails to compare", func() {
+		Expect(CompareComparers(cOld, cNew, []interface{}{Low, "low"})).To(BeEmpty())
+
+		cFail := NewComparisonsOrDie(func(s1, s2 Severity) int { panic("boom") })
+		divergences := CompareComparers(cOld, cFail, []interface{}{Low, High})
+		Expect(divergences).To(HaveLen(1))
+		Expect(divergences[0].NewErr).To(MatchError("boom"))
+	})
+
+	It("should report nothing for equivalent Comparisons", func() {
+		Expect(CompareComparers(cOld, make(Comparisons), []interface{}{1, 2, 3})).To(BeEmpty())
+	})
+})
@@ -0,0 +1,27 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+// DeepEqual reports whether a1 and a2 are deeply equal, i.e. whether DeepCompare
+// returns 0. Like DeepCompare, it panics if the values cannot be compared.
+//
+// As only equality matters, DeepEqual does not remember the results of comparisons
+// already made, but only which comparisons have been entered. This makes checking
+// cyclic values for equality cheaper than comparing them.
+func (c Comparisons) DeepEqual(a1, a2 interface{}) bool {
+	t := c.newTraversal()
+	t.seen = make(map[visit]struct{})
+	return t.compare(a1, a2) == 0
+}
@@ -0,0 +1,51 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeepEqual", func() {
+	c := make(Comparisons)
+
+	cycle := func(values ...int) *Link {
+		head := newLinks(values...)
+		last := head
+		for last.Next != nil {
+			last = last.Next
+		}
+		last.Next = head
+		return head
+	}
+
+	It("should report whether values are equal", func() {
+		Expect(c.DeepEqual(newLinks(1, 2), newLinks(1, 2))).To(BeTrue())
+		Expect(c.DeepEqual(newLinks(1, 2), newLinks(1, 3))).To(BeFalse())
+		Expect(c.DeepEqual(nil, nil)).To(BeTrue())
+	})
+
+	It("should check cyclic values", func() {
+		Expect(c.DeepEqual(cycle(1, 2), cycle(1, 2))).To(BeTrue())
+		Expect(c.DeepEqual(cycle(1, 2), cycle(1, 3))).To(BeFalse())
+		Expect(c.DeepEqual(cycle(1, 2, 1, 2), cycle(1, 2))).To(BeTrue())
+	})
+
+	It("should panic if the values cannot be compared", func() {
+		Expect(func() { c.DeepEqual(1, "1") }).To(Panic())
+	})
+})
@@ -0,0 +1,133 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Sentinel errors the errors returned by the error-returning APIs (e.g. TryDeepCompare) and
+// the values the panicking APIs panic with match via errors.Is, depending on why values
+// could not be compared.
+var (
+	// ErrTypeMismatch indicates values of different types.
+	ErrTypeMismatch = errors.New("cannot compare different types")
+	// ErrUnexportedField indicates values only reachable via unexported fields (see UnexportedFieldError).
+	ErrUnexportedField = errors.New("an unexported field was encountered")
+	// ErrFuncCompare indicates two non-nil funcs.
+	ErrFuncCompare = errors.New("cannot compare two non-nil functions")
+	// ErrDepthExceeded indicates values nested deeper than allowed via MaxDepth.
+	ErrDepthExceeded = errors.New("maximum depth exceeded")
+	// ErrNotNumeric indicates strings compared via NumericStrings that are not numbers.
+	ErrNotNumeric = errors.New("not a numeric string")
+	// ErrTypeNotComparable indicates different values of a type that can only be compared
+	// for equality, e.g. channels or complex numbers.
+	ErrTypeNotComparable = errors.New("cannot compare values of type")
+)
+
+// UnexportedFieldError is returned when values cannot be compared because they are only
+// reachable via unexported fields, e.g. values with a comparison function or channels.
+// It usually indicates a programmer error, so the panicking APIs panic with it.
+type UnexportedFieldError struct {
+	// Path is the path to the values that could not be compared, e.g. '.Spec.ch'.
+	Path string
+	// Types are the types of the values along Path, from the compared values down to
+	// the values that could not be compared.
+	Types []reflect.Type
+}
+
+// Error implements error.
+func (u *UnexportedFieldError) Error() string {
+	strs := make([]string, len(u.Types))
+	for i, t := range u.Types {
+		strs[i] = fmt.Sprintf("%v", t)
+	}
+	var at string
+	if u.Path != "" {
+		at = " at " + u.Path
+	}
+	return fmt.Sprintf("%v%s, nested like this: %s", ErrUnexportedField, at, strings.Join(strs, " -> "))
+}
+
+// Is reports whether target is ErrUnexportedField.
+func (u *UnexportedFieldError) Is(target error) bool {
+	return target == ErrUnexportedField
+}
+
+// typeMismatchError is returned when values of different types are compared, either
+// directly or as the dynamic values of interfaces.
+type typeMismatchError struct {
+	path   string
+	t1, t2 reflect.Type
+}
+
+func (e *typeMismatchError) Error() string {
+	if e.path == "" {
+		return fmt.Sprintf("%v: %v - %v", ErrTypeMismatch, e.t1, e.t2)
+	}
+	return fmt.Sprintf("%v at %s: %v - %v", ErrTypeMismatch, e.path, e.t1, e.t2)
+}
+
+// Is reports whether target is ErrTypeMismatch.
+func (e *typeMismatchError) Is(target error) bool {
+	return target == ErrTypeMismatch
+}
+
+// pathError is an error that occurred while comparing the values at path.
+type pathError struct {
+	path string
+	err  error
+}
+
+func (e *pathError) Error() string {
+	if e.path == "" {
+		return e.err.Error()
+	}
+	return fmt.Sprintf("%v at %s", e.err, e.path)
+}
+
+func (e *pathError) Unwrap() error {
+	return e.err
+}
+
+// prependStep prepends the path step s to the path of err, if err has a path.
+func prependStep(err error, s string) error {
+	switch err := err.(type) {
+	case *UnexportedFieldError:
+		err.Path = s + err.Path
+	case *typeMismatchError:
+		err.path = s + err.path
+	case *pathError:
+		err.path = s + err.path
+	case *IncompleteError:
+		err.Path = s + err.Path
+	}
+	return err
+}
+
+func indexStep(i int) string {
+	return fmt.Sprintf("[%d]", i)
+}
+
+func fieldStep(name string) string {
+	return "." + name
+}
+
+func keyStep(k reflect.Value) string {
+	return fmt.Sprintf("[%#v]", k)
+}
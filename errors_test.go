@@ -0,0 +1,101 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"errors"
+	"reflect"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Errors", func() {
+	type Hidden struct{ c chan int }
+	type Outer struct {
+		Items map[string][]Hidden
+	}
+
+	Describe("UnexportedFieldError", func() {
+		It("should report the path and types to the unexported field", func() {
+			o1 := Outer{Items: map[string][]Hidden{"a": {{make(chan int)}}}}
+			o2 := Outer{Items: map[string][]Hidden{"a": {{make(chan int)}}}}
+			_, err := make(Comparisons).TryDeepCompare(o1, o2)
+			Expect(err).To(BeAssignableToTypeOf(&UnexportedFieldError{}))
+			u := err.(*UnexportedFieldError)
+			Expect(u.Path).To(Equal(`.Items["a"][0].c`))
+			Expect(u.Types).To(Equal([]reflect.Type{
+				reflect.TypeOf(Outer{}),
+				reflect.TypeOf(map[string][]Hidden{}),
+				reflect.TypeOf([]Hidden{}),
+				reflect.TypeOf(Hidden{}),
+				reflect.TypeOf(make(chan int)),
+			}))
+			Expect(err).To(MatchError(ContainSubstring(`at .Items["a"][0].c`)))
+		})
+
+		It("should report the path and type of fields with comparison functions", func() {
+			type Inner struct{ V int }
+			type WithHidden struct{ s Inner }
+			c := NewComparisonsOrDie(func(a, b Inner) int { return a.V - b.V })
+			_, err := c.TryDeepCompare(WithHidden{Inner{1}}, WithHidden{Inner{2}})
+			Expect(err).To(BeAssignableToTypeOf(&UnexportedFieldError{}))
+			u := err.(*UnexportedFieldError)
+			Expect(u.Path).To(Equal(".s"))
+			Expect(u.Types).To(Equal([]reflect.Type{reflect.TypeOf(WithHidden{}), reflect.TypeOf(Inner{})}))
+			Expect(err).To(MatchError(ContainSubstring("at .s, nested like this: reflcompare_test.WithHidden -> reflcompare_test.Inner")))
+		})
+
+		It("should be the panic value of DeepCompare", func() {
+			Expect(func() { make(Comparisons).DeepCompare(Hidden{make(chan int)}, Hidden{make(chan int)}) }).
+				To(PanicWith(BeAssignableToTypeOf(&UnexportedFieldError{})))
+		})
+	})
+
+	Describe("type mismatches", func() {
+		It("should report the path to dynamic values of different types", func() {
+			_, err := make(Comparisons).TryDeepCompare([]interface{}{1, 2}, []interface{}{1, "b"})
+			Expect(err).To(MatchError("cannot compare different types at [1]: int - string"))
+		})
+	})
+
+	Describe("sentinel errors", func() {
+		type Node struct{ Next *Node }
+
+		It("should be matched by the returned errors", func() {
+			c := make(Comparisons)
+			_, err := c.TryDeepCompare(1, "foo")
+			Expect(errors.Is(err, ErrTypeMismatch)).To(BeTrue())
+			_, err = c.TryDeepCompare(Hidden{make(chan int)}, Hidden{make(chan int)})
+			Expect(errors.Is(err, ErrUnexportedField)).To(BeTrue())
+			_, err = c.TryDeepCompare(struct{ F func() }{func() {}}, struct{ F func() }{func() {}})
+			Expect(errors.Is(err, ErrFuncCompare)).To(BeTrue())
+			Expect(err).To(MatchError("cannot compare two non-nil functions at .F"))
+			_, err = c.TryDeepCompare(struct{ C complex128 }{1}, struct{ C complex128 }{2})
+			Expect(errors.Is(err, ErrTypeNotComparable)).To(BeTrue())
+			Expect(err).To(MatchError("cannot compare values of type complex128 at .C"))
+		})
+
+		It("should limit the depth with MaxDepth", func() {
+			n1, n2 := &Node{}, &Node{}
+			n1.Next, n2.Next = n1, n2
+			cmp := make(Comparisons).NewComparer(MaxDepth(10))
+			_, err := cmp.TryCompare(n1, n2)
+			Expect(errors.Is(err, ErrDepthExceeded)).To(BeTrue())
+			Expect(cmp.TryCompare(&Node{&Node{}}, &Node{})).To(Equal(1))
+		})
+	})
+})
@@ -0,0 +1,171 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Result is the result of a comparison along with what decided it.
+type Result struct {
+	// Value is the comparison result as returned by DeepCompare.
+	Value int
+	// Path is the path to the values that decided the comparison, e.g. '.Spec.Containers[0].Name'.
+	// It is empty if the values are equal or if the comparison was decided at the root.
+	Path string
+	// Comparator describes what decided the comparison. It is either the name of the
+	// comparison function, 'nil' if only one value was nil, 'nil at level n' if only one of
+	// the pointers at the n-th level of a pointer chain like **T was nil, 'length' if the lengths
+	// of slices or maps differed, 'key missing' if a map key was only present in one of the maps
+	// (Path then ends with that key), 'dynamic type' if the dynamic types of interface values
+	// differed or otherwise the kind of the values, e.g. 'int'.
+	// It is empty if the values are equal.
+	Comparator string
+}
+
+// String implements fmt.Stringer.
+func (r Result) String() string {
+	var verdict string
+	switch {
+	case r.Value < 0:
+		verdict = "less"
+	case r.Value > 0:
+		verdict = "greater"
+	default:
+		return "equal"
+	}
+	if r.Path == "" {
+		return fmt.Sprintf("%s by %s", verdict, r.Comparator)
+	}
+	return fmt.Sprintf("%s at %s by %s", verdict, r.Path, r.Comparator)
+}
+
+// provenance records what decided a comparison.
+type provenance struct {
+	decided    bool
+	comparator string
+	// steps are the steps of the path to the deciding values in reverse order.
+	steps []string
+}
+
+// Explain compares two values like DeepCompare and reports what decided the comparison.
+// This allows branching on *why* values are ordered the way they are.
+func (c Comparisons) Explain(a1, a2 interface{}) Result {
+	return c.newTraversal().explain(a1, a2)
+}
+
+// Explain compares a1 and a2 like Compare and reports what decided the comparison.
+func (c *Comparer) Explain(a1, a2 interface{}) Result {
+	defer c.traversal.reset()
+	return c.traversal.explain(a1, a2)
+}
+
+func (t *traversal) explain(a1, a2 interface{}) Result {
+	t.provenance = &provenance{}
+	defer func() { t.provenance = nil }()
+	res := Result{Value: t.compare(a1, a2)}
+	if res.Value == 0 {
+		return res
+	}
+
+	steps := t.provenance.steps
+	var sb strings.Builder
+	for i := len(steps) - 1; i >= 0; i-- {
+		sb.WriteString(steps[i])
+	}
+	res.Path = sb.String()
+	res.Comparator = t.provenance.comparator
+	return res
+}
+
+// settle records v1 and v2 as the deciding values if res is non-zero and nothing else decided yet.
+func (t *traversal) settle(res int, v1, v2 reflect.Value, derefs int) {
+	if res == 0 || t.provenance.decided {
+		return
+	}
+	t.provenance.decided = true
+	t.provenance.comparator = t.describe(v1, v2, derefs)
+}
+
+// decideBy records the comparison function fv as the deciding comparator.
+func (t *traversal) decideBy(fv reflect.Value) {
+	if t.provenance == nil {
+		return
+	}
+	t.provenance.decided = true
+	t.provenance.comparator = funcName(fv)
+}
+
+// describe describes why v1 and v2 are different, assuming no nested values decided it.
+// derefs is the number of pointers of a pointer chain dereferenced to reach v1 and v2.
+func (t *traversal) describe(v1, v2 reflect.Value, derefs int) string {
+	if !v1.IsValid() || !v2.IsValid() {
+		return "nil"
+	}
+	if fv, ok := t.lookup(v1.Type()); ok {
+		return funcName(fv)
+	}
+	switch v1.Kind() {
+	case reflect.Slice, reflect.Map:
+		if v1.Len() != v2.Len() {
+			return "length"
+		}
+	case reflect.Ptr, reflect.Interface, reflect.Func, reflect.Chan:
+		if v1.IsNil() != v2.IsNil() {
+			if v1.Kind() == reflect.Ptr && derefs > 0 {
+				return fmt.Sprintf("nil at level %d", derefs+1)
+			}
+			return "nil"
+		}
+		if v1.Kind() == reflect.Interface && v1.Elem().Type() != v2.Elem().Type() {
+			return "dynamic type"
+		}
+	}
+	return v1.Kind().String()
+}
+
+// missKey records the key k, present in only one of the compared maps, as deciding.
+func (t *traversal) missKey(k reflect.Value) {
+	if t.provenance == nil || t.provenance.decided {
+		return
+	}
+	t.provenance.decided = true
+	t.provenance.comparator = "key missing"
+	t.stepKey(k)
+}
+
+func (t *traversal) step(s string) {
+	t.provenance.steps = append(t.provenance.steps, s)
+}
+
+func (t *traversal) stepIndex(i int) {
+	if t.provenance != nil {
+		t.step(indexStep(i))
+	}
+}
+
+func (t *traversal) stepField(name string) {
+	if t.provenance != nil {
+		t.step(fieldStep(name))
+	}
+}
+
+func (t *traversal) stepKey(k reflect.Value) {
+	if t.provenance != nil {
+		t.step(t.keyStep(k))
+	}
+}
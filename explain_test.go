@@ -0,0 +1,86 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+func compareSpecsByImage(s1, s2 Spec) int {
+	return CompareHexID(s1.Image, s2.Image)
+}
+
+var _ = Describe("Explain", func() {
+	c := make(Comparisons)
+
+	DescribeTable("Explain",
+		func(c Comparisons, v1, v2 interface{}, expect Result) {
+			Expect(c.Explain(v1, v2)).To(Equal(expect))
+		},
+		Entry("equal", c, 1, 1, Result{}),
+		Entry("root", c, 1, 2, Result{Value: -1, Comparator: "int"}),
+		Entry("struct field", c,
+			Object{Name: "a", Spec: &Spec{Replicas: intPtr(2)}},
+			Object{Name: "a", Spec: &Spec{Replicas: intPtr(1)}},
+			Result{Value: 1, Path: ".Spec.Replicas", Comparator: "int"},
+		),
+		Entry("nil", c, Object{}, Object{Spec: &Spec{}}, Result{Value: -1, Path: ".Spec", Comparator: "nil"}),
+		Entry("nil in pointer chain", c, struct{ P **int }{P: new(*int)}, struct{ P **int }{P: intPtrPtr(intPtr(1))},
+			Result{Value: -1, Path: ".P", Comparator: "nil at level 2"},
+		),
+		Entry("nil in deep pointer chain", c, struct{ P ***int }{P: intPtrPtrPtr(intPtrPtr(nil))}, struct{ P ***int }{P: intPtrPtrPtr(intPtrPtr(intPtr(1)))},
+			Result{Value: -1, Path: ".P", Comparator: "nil at level 3"},
+		),
+		Entry("slice index", c, []string{"a", "b"}, []string{"a", "c"}, Result{Value: -1, Path: "[1]", Comparator: "string"}),
+		Entry("length", c, []int{1}, []int{1, 2}, Result{Value: -1, Comparator: "length"}),
+		Entry("map key", c, map[string]int{"foo": 1}, map[string]int{"foo": 2}, Result{Value: -1, Path: `["foo"]`, Comparator: "int"}),
+		Entry("comparison function", NewComparisonsOrDie(compareSpecsByImage),
+			[]Object{{Spec: &Spec{Image: "a"}}},
+			[]Object{{Spec: &Spec{Image: "b"}}},
+			Result{Value: -1, Path: "[0].Spec", Comparator: "github.com/adracus/reflcompare_test.compareSpecsByImage"},
+		),
+		Entry("comparison function for field", NewComparisonsOrDie(compareSpans),
+			struct{ R Span }{Span{0, 1}},
+			struct{ R Span }{Span{2, 3}},
+			Result{Value: -1, Path: ".R", Comparator: "github.com/adracus/reflcompare_test.compareSpans"},
+		),
+	)
+
+	It("should report keys missing from a map", func() {
+		res := c.Explain(map[string]int{"a": 1}, map[string]int{"b": 1})
+		Expect(res.Comparator).To(Equal("key missing"))
+		Expect(res.Value).To(Equal(1))
+		Expect(res.Path).To(Or(Equal(`["a"]`), Equal(`["b"]`)))
+	})
+
+	It("should report the smallest key missing from a map with StrictMaps", func() {
+		cmp := c.NewComparer(StrictMaps())
+		Expect(cmp.Explain(map[string]int{"a": 1, "c": 1}, map[string]int{"b": 1, "c": 1})).To(Equal(
+			Result{Value: -1, Path: `["a"]`, Comparator: "key missing"},
+		))
+		Expect(cmp.Explain(map[string]int{"c": 1}, map[string]int{"c": 2})).To(Equal(
+			Result{Value: -1, Path: `["c"]`, Comparator: "int"},
+		))
+	})
+
+	It("should describe the result", func() {
+		Expect(Result{}.String()).To(Equal("equal"))
+		Expect(Result{Value: -1, Comparator: "int"}.String()).To(Equal("less by int"))
+		Expect(Result{Value: 1, Path: ".Name", Comparator: "string"}.String()).To(Equal("greater at .Name by string"))
+	})
+})
@@ -0,0 +1,135 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// exprTerm is a compiled term of a comparator expression.
+type exprTerm struct {
+	path fieldPath
+	// length compares the lengths of the values of path instead of the values themselves.
+	length     bool
+	descending bool
+}
+
+// AddExpr adds a comparison function for the struct type of sample defined by the given
+// expression, e.g. 'len(.Items) desc, .Name'. This allows configuration-driven orderings.
+//
+// An expression is a comma-separated list of terms, each optionally followed by 'asc'
+// (the default) or 'desc'. A term is either a path of fields (see ParseOrdering), optionally
+// prefixed by a dot, or 'len(<path>)' for the length of a slice, map, array or string field.
+// Terms are compared in order using c and the options of the comparison; a nil pointer on
+// a path is less than any value.
+//
+// The expression is compiled and validated against the type of sample when adding it.
+func (c Comparisons) AddExpr(sample interface{}, expr string) error {
+	t := reflect.TypeOf(sample)
+	if t == nil {
+		return fmt.Errorf("expected sample value, got: nil")
+	}
+	terms, err := compileExpr(t, expr)
+	if err != nil {
+		return fmt.Errorf("invalid expression %q: %w", expr, err)
+	}
+
+	c.addTraversalFunc(t, "AddExpr("+expr+")", func(tr *traversal, v1, v2 reflect.Value, depth int) (int, error) {
+		return tr.compareTerms(v1, v2, terms, depth)
+	})
+	return nil
+}
+
+func compileExpr(t reflect.Type, expr string) ([]exprTerm, error) {
+	var terms []exprTerm
+	for _, part := range strings.Split(expr, ",") {
+		fields := strings.Fields(part)
+		if len(fields) == 0 || len(fields) > 2 {
+			return nil, fmt.Errorf("expected '<term> [asc|desc]', got %q", strings.TrimSpace(part))
+		}
+		var term exprTerm
+		if len(fields) == 2 {
+			switch strings.ToLower(fields[1]) {
+			case "asc":
+			case "desc":
+				term.descending = true
+			default:
+				return nil, fmt.Errorf("unknown direction %q", fields[1])
+			}
+		}
+
+		operand := fields[0]
+		if strings.HasPrefix(operand, "len(") && strings.HasSuffix(operand, ")") {
+			term.length = true
+			operand = strings.TrimSuffix(strings.TrimPrefix(operand, "len("), ")")
+		}
+		column, err := resolveOrderingColumn(t, strings.TrimPrefix(operand, "."))
+		if err != nil {
+			return nil, err
+		}
+		if term.path, err = resolveFieldPath(t, column); err != nil {
+			return nil, err
+		}
+		if typ, _ := term.path.typeOf(t); term.length && !hasLen(typ) {
+			return nil, fmt.Errorf("cannot take length of %q of type %v", operand, typ)
+		}
+		terms = append(terms, term)
+	}
+	return terms, nil
+}
+
+func hasLen(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return true
+	}
+	return false
+}
+
+// compareTerms compares v1 and v2, nested depth levels deep, by the given terms.
+func (t *traversal) compareTerms(v1, v2 reflect.Value, terms []exprTerm, depth int) (int, error) {
+	for _, term := range terms {
+		f1, f2 := term.path.get(v1), term.path.get(v2)
+		var (
+			res int
+			err error
+		)
+		if term.length {
+			res = compareInt64(int64(length(f1)), int64(length(f2)))
+		} else if res, err = t.deepValueCompare(f1, f2, depth+1); err != nil {
+			return 0, err
+		}
+		if term.descending {
+			res = -res
+		}
+		if res != 0 {
+			return res, nil
+		}
+	}
+	return 0, nil
+}
+
+// length returns the length of v, dereferencing pointers. If v is invalid or a nil pointer, it returns -1.
+func length(v reflect.Value) int {
+	for v.IsValid() && v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return -1
+	}
+	return v.Len()
+}
@@ -0,0 +1,70 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type Queue struct {
+	Name  string   `json:"name"`
+	Items []string `json:"items"`
+	Spec  *Spec    `json:"spec"`
+}
+
+var _ = Describe("AddExpr", func() {
+	It("should compare by the terms of the expression", func() {
+		c := make(Comparisons)
+		Expect(c.AddExpr(Queue{}, "len(.Items) desc, .Name")).To(Succeed())
+
+		Expect(c.DeepCompare(Queue{Name: "a", Items: []string{"x"}}, Queue{Name: "b", Items: []string{"x", "y"}})).To(Equal(1))
+		Expect(c.DeepCompare(Queue{Name: "a", Items: []string{"x"}}, Queue{Name: "b", Items: []string{"y"}})).To(Equal(-1))
+		Expect(c.DeepCompare(Queue{Name: "a", Items: []string{"x"}}, Queue{Name: "a", Items: []string{"y"}})).To(Equal(0))
+	})
+
+	It("should resolve json names and nested paths", func() {
+		c := make(Comparisons)
+		Expect(c.AddExpr(Queue{}, "spec.image desc, len(name)")).To(Succeed())
+
+		Expect(c.DeepCompare(Queue{Spec: &Spec{Image: "a"}}, Queue{Spec: &Spec{Image: "b"}})).To(Equal(1))
+		Expect(c.DeepCompare(Queue{Name: "aa", Spec: &Spec{}}, Queue{Name: "b", Spec: &Spec{}})).To(Equal(1))
+		Expect(c.DeepCompare(Queue{}, Queue{Spec: &Spec{}})).To(Equal(1))
+	})
+
+	It("should be used when comparing nested values", func() {
+		c := make(Comparisons)
+		Expect(c.AddExpr(Queue{}, ".Name desc")).To(Succeed())
+		Expect(c.DeepCompare([]Queue{{Name: "a"}}, []Queue{{Name: "b"}})).To(Equal(1))
+	})
+
+	It("should compare the terms with the options of the comparison", func() {
+		c := make(Comparisons)
+		Expect(c.AddExpr(Queue{}, ".Name")).To(Succeed())
+		Expect(c.DeepCompare(Queue{Name: "10"}, Queue{Name: "9"})).To(Equal(-1))
+		Expect(c.NewComparer(NumericStrings()).Compare(Queue{Name: "10"}, Queue{Name: "9"})).To(Equal(1))
+	})
+
+	It("should error on invalid expressions", func() {
+		c := make(Comparisons)
+		Expect(c.AddExpr(nil, ".Name")).NotTo(Succeed())
+		Expect(c.AddExpr(Queue{}, ".Unknown")).To(MatchError(ContainSubstring("has no field")))
+		Expect(c.AddExpr(Queue{}, ".Name sideways")).To(MatchError(ContainSubstring(`unknown direction "sideways"`)))
+		Expect(c.AddExpr(Queue{}, "len(.Spec)")).To(MatchError(ContainSubstring("cannot take length")))
+		Expect(c.AddExpr(Queue{}, ".Name,")).NotTo(Succeed())
+		Expect(c).To(BeEmpty())
+	})
+})
@@ -0,0 +1,89 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	intType    = reflect.TypeOf(0)
+	stringType = reflect.TypeOf("")
+	timeType   = reflect.TypeOf(time.Time{})
+)
+
+// CompareTime compares two points in time. An earlier point in time is less than
+// a later one, regardless of the locations of the times.
+//
+// CompareTime is part of StandardFuncs.
+func CompareTime(t1, t2 time.Time) int {
+	switch {
+	case t1.Before(t2):
+		return -1
+	case t1.After(t2):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// CompareInts compares two ints without the overhead of reflection.
+// If a comparison function for int is registered, it is used instead.
+func (c Comparisons) CompareInts(i1, i2 int) int {
+	if fv, ok := c[intType]; ok {
+		if f, ok := fv.Interface().(func(int, int) int); ok {
+			return f(i1, i2)
+		}
+		return c.callTotal(fv, i1, i2)
+	}
+	return compareInt64(int64(i1), int64(i2))
+}
+
+// CompareStrings compares two strings without the overhead of reflection.
+// If a comparison function for string is registered, it is used instead.
+func (c Comparisons) CompareStrings(s1, s2 string) int {
+	if fv, ok := c[stringType]; ok {
+		if f, ok := fv.Interface().(func(string, string) int); ok {
+			return f(s1, s2)
+		}
+		return c.callTotal(fv, s1, s2)
+	}
+	return strings.Compare(s1, s2)
+}
+
+// CompareTime compares two times without the overhead of reflection.
+// If a comparison function for time.Time is registered, it is used instead,
+// otherwise the times are compared via the package-level CompareTime.
+func (c Comparisons) CompareTime(t1, t2 time.Time) int {
+	if fv, ok := c[timeType]; ok {
+		if f, ok := fv.Interface().(func(time.Time, time.Time) int); ok {
+			return f(t1, t2)
+		}
+		return c.callTotal(fv, t1, t2)
+	}
+	return CompareTime(t1, t2)
+}
+
+// callTotal calls the comparison function fv of c with a1 and a2 via reflection, requiring a total order.
+func (c Comparisons) callTotal(fv reflect.Value, a1, a2 interface{}) int {
+	v1, v2 := reflect.ValueOf(a1), reflect.ValueOf(a2)
+	res, err := c.newTraversal().callCompare(fv, v1, v2, 0)
+	if err != nil {
+		panic(err)
+	}
+	return checkTotal(res, v1.Type())
+}
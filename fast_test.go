@@ -0,0 +1,59 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"strings"
+	"time"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Fast", func() {
+	var (
+		t1 = time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+		t2 = t1.Add(time.Hour)
+	)
+
+	Describe("CompareTime", func() {
+		It("should compare the times by instant", func() {
+			Expect(CompareTime(t1, t2)).To(Equal(-1))
+			Expect(CompareTime(t2, t1)).To(Equal(1))
+			Expect(CompareTime(t1, t1.Local())).To(Equal(0))
+		})
+	})
+
+	Describe("Comparisons", func() {
+		It("should compare without overrides", func() {
+			c := make(Comparisons)
+			Expect(c.CompareInts(1, 2)).To(Equal(-1))
+			Expect(c.CompareStrings("b", "a")).To(Equal(1))
+			Expect(c.CompareTime(t1, t2)).To(Equal(-1))
+		})
+
+		It("should honor overrides", func() {
+			c := NewComparisonsOrDie(
+				func(i1, i2 int) int { return i2 - i1 },
+				func(s1, s2 string) int { return strings.Compare(strings.ToLower(s1), strings.ToLower(s2)) },
+				func(t1, t2 time.Time) int { return 0 },
+			)
+			Expect(c.CompareInts(1, 2)).To(Equal(1))
+			Expect(c.CompareStrings("A", "a")).To(Equal(0))
+			Expect(c.CompareTime(t1, t2)).To(Equal(0))
+		})
+	})
+})
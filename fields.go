@@ -0,0 +1,210 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// structField is the metadata of a struct field needed to compare it.
+type structField struct {
+	index int
+	name  string
+	// compare is the comparison function registered for the field type, if any.
+	compare reflect.Value
+	// weight is the weight of the field; fields with higher weights are compared first.
+	weight int
+	// redact masks the values of the field in reports, see Redact.
+	redact bool
+	// mergeKey is the merge key of the elements of the field's list, if declared by its tag.
+	mergeKey []int
+	// def is the default of the field if it is unset, see FieldDefaults.
+	def reflect.Value
+}
+
+// structFields returns the fields to compare for the given struct type, in the order
+// to compare them. The result is computed once per type and traversal.
+func (t *traversal) structFields(typ reflect.Type) ([]structField, error) {
+	if fields, ok := t.structs[typ]; ok {
+		return fields, nil
+	}
+	fields := make([]structField, 0, typ.NumField())
+	weighted := false
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		tag, err := parseTagOf(t.fieldTags, typ, f)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s tag of %v: %w", tagKey, typ, err)
+		}
+		if tag.ignore || (t.asOfVersion > 0 && tag.since > t.asOfVersion) {
+			continue
+		}
+		weight, ok := t.fieldWeights[typ][f.Name]
+		if !ok {
+			weight = tag.weight
+		}
+		weighted = weighted || weight != 0
+
+		fv, err := tagComparison(t.tagComparisons, f, tag)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s tag of %v: %w", tagKey, typ, err)
+		}
+		mergeKey, err := fieldMergeKey(f)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s tag of %v: %w", mergeKeyTag, typ, err)
+		}
+		def, ok := t.fieldDefaults[typ][f.Name]
+		if !ok {
+			def = t.defaults[f.Type]
+		}
+		if !fv.IsValid() && !t.markers[f.Type] {
+			// Marker types are handled when traversing the field.
+			fv, _ = t.lookup(f.Type)
+		}
+		fields = append(fields, structField{
+			index:    i,
+			name:     f.Name,
+			compare:  fv,
+			weight:   weight,
+			redact:   tag.redact,
+			mergeKey: mergeKey,
+			def:      def,
+		})
+	}
+	if weighted {
+		sort.SliceStable(fields, func(i, j int) bool {
+			return fields[i].weight > fields[j].weight
+		})
+		if t.significantFields > 0 && t.significantFields < len(fields) {
+			fields = fields[:t.significantFields]
+		}
+	}
+	t.structs[typ] = fields
+	return fields, nil
+}
+
+// incomparableStruct returns the error comparing any values of the struct type typ with the
+// given fields fails with, if it can be told from the type alone. This is the case if the
+// first compared field is unexported and has a comparison function, is of a kind that is
+// compared by equality, like channels, or is such a struct itself.
+// The verdict is cached per type, so the values need not be traversed to fail again.
+func (t *traversal) incomparableStruct(typ reflect.Type, fields []structField) *UnexportedFieldError {
+	if t.lenient || t.debugTotalOrder || t.paths != nil || len(fields) == 0 {
+		return nil
+	}
+	u, ok := t.incomparable[typ]
+	if !ok {
+		u = t.firstFieldIncomparable(typ, fields[0])
+		t.incomparable[typ] = u
+	}
+	if u == nil {
+		return nil
+	}
+	// Callers prepend to the returned error, so it must not be shared.
+	return &UnexportedFieldError{Path: u.Path, Types: append([]reflect.Type(nil), u.Types...)}
+}
+
+// firstFieldIncomparable computes the verdict of incomparableStruct for typ, whose first compared field is f.
+func (t *traversal) firstFieldIncomparable(typ reflect.Type, f structField) *UnexportedFieldError {
+	sf := typ.Field(f.index)
+	if sf.PkgPath == "" || f.def.IsValid() {
+		return nil
+	}
+	u := &UnexportedFieldError{Path: fieldStep(f.name), Types: []reflect.Type{sf.Type}}
+	if f.compare.IsValid() {
+		return u
+	}
+	if t.maxDepth > 0 || !t.plain(sf.Type) {
+		return nil
+	}
+	switch sf.Type.Kind() {
+	case reflect.Chan, reflect.Complex64, reflect.Complex128, reflect.UnsafePointer:
+		return u
+	case reflect.Struct:
+		fields, err := t.structFields(sf.Type)
+		if err != nil {
+			return nil
+		}
+		if inner := t.incomparableStruct(sf.Type, fields); inner != nil {
+			u.Path += inner.Path
+			u.Types = append(u.Types, inner.Types...)
+			return u
+		}
+	}
+	return nil
+}
+
+// plain reports whether values of typ are compared by the default rules, so comparing
+// them can be broken down into comparing their elements or fields.
+func (t *traversal) plain(typ reflect.Type) bool {
+	t.resolveTypeScopes(typ)
+	if _, ok := t.lookup(typ); ok {
+		return false
+	}
+	if _, ok := t.scoped[typ]; ok {
+		return false
+	}
+	if _, ok := t.versions[typ]; ok {
+		return false
+	}
+	if t.markers[typ] || t.opaque[typ] || isOpaqueType(typ) {
+		return false
+	}
+	return !typ.Implements(customComparerType) && !reflect.PtrTo(typ).Implements(customComparerType) && !typ.Implements(recordType)
+}
+
+// traversalFunc is a comparison function comparing values with the calling traversal, so
+// the comparison functions and options of the traversal apply to the values it compares.
+// It is stored in Comparisons as a *traversalFunc instead of a func.
+type traversalFunc struct {
+	// name names the comparison function in reports.
+	name    string
+	compare func(t *traversal, v1, v2 reflect.Value, depth int) (int, error)
+}
+
+var traversalFuncType = reflect.TypeOf((*traversalFunc)(nil))
+
+// addTraversalFunc adds the traversalFunc of the given name and compare func for values of type t.
+func (c Comparisons) addTraversalFunc(t reflect.Type, name string, compare func(t *traversal, v1, v2 reflect.Value, depth int) (int, error)) {
+	c[t] = reflect.ValueOf(&traversalFunc{name: name, compare: compare})
+}
+
+// callCompare compares v1 and v2 with the comparison function fv.
+func (t *traversal) callCompare(fv, v1, v2 reflect.Value, depth int) (int, error) {
+	if fv.Type() == traversalFuncType {
+		return fv.Interface().(*traversalFunc).compare(t, v1, v2, depth)
+	}
+	return callFunc(fv, v1, v2), nil
+}
+
+// compareBypassing compares the values v1 and v2 of type typ like deepValueCompare, but
+// without the comparison function for typ, e.g. to fall back to the default rules from it.
+func (t *traversal) compareBypassing(typ reflect.Type, v1, v2 reflect.Value, depth int) (int, error) {
+	prev := t.bypass
+	t.bypass = typ
+	defer func() { t.bypass = prev }()
+	return t.deepValueCompare(v1, v2, depth)
+}
+
+// callFunc calls the comparison function fv with v1 and v2.
+func callFunc(fv, v1, v2 reflect.Value) int {
+	out := fv.Call([]reflect.Value{v1, v2})[0]
+	if out.Type() == orderingType {
+		return fromOrdering(Ordering(out.Int()))
+	}
+	return int(out.Int())
+}
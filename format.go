@@ -0,0 +1,82 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Formatted is a value rendered by a formatter in a report, see Formatters.
+type Formatted struct {
+	// Value is the formatted value.
+	Value interface{}
+	// Text is the rendered value.
+	Text string
+}
+
+// String implements fmt.Stringer.
+func (f Formatted) String() string {
+	return f.Text
+}
+
+// GoString implements fmt.GoStringer.
+func (f Formatted) GoString() string {
+	return f.Text
+}
+
+// Formatters registers the given formatters to render values in reports. A formatter is a
+// func(T) string, e.g. func(t time.Time) string { return t.Format(time.RFC3339) }.
+// Diff reports values of type T as a whole, as Formatted values holding their renderings,
+// and map keys of type T are rendered by the formatter in the paths reported by Diff, Explain
+// and errors. Values nested in reported values, e.g. in structs reported as a whole, are not
+// rendered by formatters. Formatted values are neither truncated (see Truncate) nor used in
+// JSON patches.
+//
+// Formatters panics if any argument is not a formatter.
+func Formatters(formatters ...interface{}) Option {
+	fvs := make([]reflect.Value, len(formatters))
+	for i, formatter := range formatters {
+		fv := reflect.ValueOf(formatter)
+		ft := fv.Type()
+		if ft.Kind() != reflect.Func || ft.NumIn() != 1 || ft.NumOut() != 1 || ft.IsVariadic() || ft.Out(0) != stringType {
+			panic(fmt.Sprintf("expected formatter, got: %T", formatter))
+		}
+		fvs[i] = fv
+	}
+	return func(o *options) {
+		if o.formatters == nil {
+			o.formatters = make(map[reflect.Type]reflect.Value)
+		}
+		for _, fv := range fvs {
+			o.formatters[fv.Type().In(0)] = fv
+		}
+	}
+}
+
+// formatted returns v rendered by the formatter registered for its type, if any.
+func (t *traversal) formatted(v reflect.Value) (Formatted, bool) {
+	if len(t.formatters) == 0 || !v.IsValid() || !v.CanInterface() {
+		return Formatted{}, false
+	}
+	if v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+	fv, ok := t.formatters[v.Type()]
+	if !ok {
+		return Formatted{}, false
+	}
+	return Formatted{Value: v.Interface(), Text: fv.Call([]reflect.Value{v})[0].String()}, true
+}
@@ -0,0 +1,93 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type Occurrence struct {
+	Name     string
+	At       time.Time
+	Checksum []byte
+}
+
+var _ = Describe("Formatters", func() {
+	c := make(Comparisons)
+	rfc3339 := func(t time.Time) string { return t.Format(time.RFC3339) }
+	hexPrefix := func(b []byte) string {
+		if len(b) > 2 {
+			return hex.EncodeToString(b[:2]) + "..."
+		}
+		return hex.EncodeToString(b)
+	}
+	t1 := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	It("should render reported values by their formatters", func() {
+		diffs, err := c.Diff(
+			Occurrence{Name: "a", At: t1, Checksum: []byte{1, 2, 3}},
+			Occurrence{Name: "b", At: t2, Checksum: []byte{1, 2, 3}},
+			Formatters(rfc3339, hexPrefix),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diffs).To(Equal([]Difference{
+			{Path: ".Name", A: "a", B: "b", Result: -1, Comparator: "string"},
+			{
+				Path:   ".At",
+				A:      Formatted{Value: t1, Text: "2021-01-01T00:00:00Z"},
+				B:      Formatted{Value: t2, Text: "2021-01-02T00:00:00Z"},
+				Result: -1, Comparator: "struct",
+			},
+		}))
+		Expect(fmt.Sprint(diffs[1].A)).To(Equal("2021-01-01T00:00:00Z"))
+	})
+
+	It("should render values reported as a whole", func() {
+		diffs, err := c.Diff([][]byte{{1}}, [][]byte{{1}, {0xab, 0xcd, 0xef}}, Formatters(hexPrefix))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diffs).To(Equal([]Difference{
+			{Path: "[1]", B: Formatted{Value: []byte{0xab, 0xcd, 0xef}, Text: "abcd..."}, Result: -1, Comparator: "length"},
+		}))
+	})
+
+	It("should render keys in paths", func() {
+		m1 := map[time.Time]int{t1: 1}
+		m2 := map[time.Time]int{t1: 2}
+		diffs, err := c.Diff(m1, m2, Formatters(rfc3339))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diffs[0].Path).To(Equal("[2021-01-01T00:00:00Z]"))
+
+		cmp := c.NewComparer(Formatters(rfc3339))
+		Expect(cmp.Explain(m1, m2).Path).To(Equal("[2021-01-01T00:00:00Z]"))
+	})
+
+	It("should apply to values of interfaces by their dynamic types", func() {
+		diffs, err := c.Diff([]interface{}{t1}, []interface{}{t2}, Formatters(rfc3339))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diffs[0].A).To(Equal(Formatted{Value: t1, Text: "2021-01-01T00:00:00Z"}))
+	})
+
+	It("should panic if any argument is not a formatter", func() {
+		Expect(func() { Formatters(func(t time.Time) int { return 0 }) }).To(Panic())
+		Expect(func() { Formatters("") }).To(Panic())
+	})
+})
@@ -0,0 +1,58 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+// FrozenComparisons is an immutable view of Comparisons.
+//
+// As it cannot be modified, it is safe to share across goroutines without any locking.
+// The zero value has no comparison functions.
+type FrozenComparisons struct {
+	comparisons Comparisons
+}
+
+// Freeze creates an immutable snapshot of c. Subsequent modifications of c do not affect the snapshot.
+func (c Comparisons) Freeze() FrozenComparisons {
+	return FrozenComparisons{comparisons: c.copy()}
+}
+
+// copy copies c into a new Comparisons.
+func (c Comparisons) copy() Comparisons {
+	res := make(Comparisons, len(c))
+	for t, fv := range c {
+		res[t] = fv
+	}
+	return res
+}
+
+// Copy returns a mutable copy of the snapshot.
+func (f FrozenComparisons) Copy() Comparisons {
+	return f.comparisons.copy()
+}
+
+// DeepCompare compares two values. See Comparisons.DeepCompare for more details.
+func (f FrozenComparisons) DeepCompare(a1, a2 interface{}) int {
+	return f.comparisons.DeepCompare(a1, a2)
+}
+
+// TryDeepCompare compares two values, returning an error if they cannot be compared.
+// See Comparisons.TryDeepCompare for more details.
+func (f FrozenComparisons) TryDeepCompare(a1, a2 interface{}) (int, error) {
+	return f.comparisons.TryDeepCompare(a1, a2)
+}
+
+// NewComparer creates a new Comparer using the snapshot, configured by the given options.
+func (f FrozenComparisons) NewComparer(opts ...Option) *Comparer {
+	return f.comparisons.NewComparer(opts...)
+}
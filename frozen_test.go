@@ -0,0 +1,76 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"sync"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FrozenComparisons", func() {
+	reverse := func(a, b int) int { return b - a }
+
+	It("should compare using the functions at the time of freezing", func() {
+		c := NewComparisonsOrDie(reverse)
+		f := c.Freeze()
+		delete(c, reflectTypeOfInt)
+
+		Expect(f.DeepCompare(1, 2)).To(Equal(1))
+		Expect(f.NewComparer().Compare(1, 2)).To(Equal(1))
+		Expect(c.DeepCompare(1, 2)).To(Equal(-1))
+	})
+
+	It("should return errors via TryDeepCompare", func() {
+		_, err := make(Comparisons).Freeze().TryDeepCompare(1, "foo")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should be usable as a DeepComparer", func() {
+		compare := func(c DeepComparer) int { return c.DeepCompare(1, 2) }
+		Expect(compare(NewComparisonsOrDie(reverse))).To(Equal(1))
+		Expect(compare(NewComparisonsOrDie(reverse).Freeze())).To(Equal(1))
+	})
+
+	It("should compare without comparison functions if zero", func() {
+		var f FrozenComparisons
+		Expect(f.DeepCompare(1, 2)).To(Equal(-1))
+	})
+
+	It("should return a mutable copy", func() {
+		f := NewComparisonsOrDie(reverse).Freeze()
+		c := f.Copy()
+		delete(c, reflectTypeOfInt)
+		Expect(f.DeepCompare(1, 2)).To(Equal(1))
+	})
+
+	It("should be safe for concurrent use", func() {
+		f := NewComparisonsOrDie(reverse).Freeze()
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					Expect(f.DeepCompare([]int{1}, []int{2})).To(Equal(1))
+				}
+			}()
+		}
+		wg.Wait()
+	})
+})
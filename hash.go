@@ -0,0 +1,178 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"reflect"
+)
+
+// DeepHash computes a 64-bit fingerprint of v that is consistent with DeepCompare for values
+// without comparison functions: Values DeepCompare considers equal have equal fingerprints.
+// Nil and empty slices and maps hash alike, and so do 0 and -0.
+//
+// Fingerprints are only meaningful for values of the same type. They can be precomputed
+// and stored next to large values to quickly tell that values are different, e.g. when
+// deduplicating them. They do not tell how different values are ordered.
+//
+// DeepHash returns an error for values of types with comparison functions or implementing
+// CustomComparer, as their equality is opaque, for non-nil funcs and for cyclic values.
+func (c Comparisons) DeepHash(v interface{}) (uint64, error) {
+	h := &hasher{
+		comparisons: c,
+		pointers:    make(map[uintptr]bool),
+	}
+	return h.hash(reflect.ValueOf(v))
+}
+
+type hasher struct {
+	comparisons Comparisons
+	// pointers are the pointers on the path to the currently hashed value.
+	pointers map[uintptr]bool
+}
+
+// sum hashes the given parts, seeded by kind to distinguish e.g. nil pointers from nil interfaces.
+func sum(kind reflect.Kind, parts ...uint64) uint64 {
+	f := fnv.New64a()
+	var buf [8]byte
+	buf[0] = byte(kind)
+	f.Write(buf[:1])
+	for _, part := range parts {
+		binary.LittleEndian.PutUint64(buf[:], part)
+		f.Write(buf[:])
+	}
+	return f.Sum64()
+}
+
+func hashFloat(f float64) uint64 {
+	if f == 0 {
+		// Normalize -0, which is equal to 0.
+		f = 0
+	}
+	return math.Float64bits(f)
+}
+
+func (h *hasher) hash(v reflect.Value) (uint64, error) {
+	if !v.IsValid() {
+		return sum(reflect.Invalid), nil
+	}
+	t := v.Type()
+	if _, ok := h.comparisons[t]; ok {
+		return 0, fmt.Errorf("cannot hash value of type %v with comparison function", t)
+	}
+	if t.Kind() != reflect.Interface && (t.Implements(customComparerType) || reflect.PtrTo(t).Implements(customComparerType)) {
+		return 0, fmt.Errorf("cannot hash value of type %v implementing CustomComparer", t)
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		var b uint64
+		if v.Bool() {
+			b = 1
+		}
+		return sum(reflect.Bool, b), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return sum(reflect.Int, uint64(v.Int())), nil
+	case reflect.Uint, reflect.Uintptr, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return sum(reflect.Uint, v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return sum(reflect.Float64, hashFloat(v.Float())), nil
+	case reflect.Complex64, reflect.Complex128:
+		return sum(reflect.Complex128, hashFloat(real(v.Complex())), hashFloat(imag(v.Complex()))), nil
+	case reflect.String:
+		f := fnv.New64a()
+		if t == jsonNumberType {
+			f.Write([]byte(canonicalNumber(v)))
+		} else {
+			f.Write([]byte(v.String()))
+		}
+		return sum(reflect.String, f.Sum64()), nil
+	case reflect.Array, reflect.Slice:
+		parts := make([]uint64, v.Len())
+		for i := range parts {
+			part, err := h.hash(v.Index(i))
+			if err != nil {
+				return 0, err
+			}
+			parts[i] = part
+		}
+		return sum(reflect.Slice, parts...), nil
+	case reflect.Struct:
+		var parts []uint64
+		for i := 0; i < v.NumField(); i++ {
+			tag, err := parseFieldTag(t.Field(i))
+			if err != nil {
+				return 0, fmt.Errorf("invalid %s tag of %v: %w", tagKey, t, err)
+			}
+			if tag.ignore {
+				continue
+			}
+			if tag.named != "" {
+				return 0, fmt.Errorf("cannot hash field %s of %v compared by %q", t.Field(i).Name, t, tag.named)
+			}
+			part, err := h.hash(v.Field(i))
+			if err != nil {
+				return 0, err
+			}
+			parts = append(parts, part)
+		}
+		return sum(reflect.Struct, parts...), nil
+	case reflect.Map:
+		// Sum the entries, so the fingerprint does not depend on the iteration order.
+		var entries uint64
+		for iter := v.MapRange(); iter.Next(); {
+			k, err := h.hash(iter.Key())
+			if err != nil {
+				return 0, err
+			}
+			e, err := h.hash(iter.Value())
+			if err != nil {
+				return 0, err
+			}
+			entries += sum(reflect.Map, k, e)
+		}
+		return sum(reflect.Map, entries), nil
+	case reflect.Ptr:
+		if v.IsNil() {
+			return sum(reflect.Ptr), nil
+		}
+		ptr := v.Pointer()
+		if h.pointers[ptr] {
+			return 0, fmt.Errorf("cannot hash cyclic value of type %v", t)
+		}
+		h.pointers[ptr] = true
+		defer delete(h.pointers, ptr)
+		elem, err := h.hash(v.Elem())
+		return sum(reflect.Ptr, elem), err
+	case reflect.Interface:
+		if v.IsNil() {
+			return sum(reflect.Interface), nil
+		}
+		elem, err := h.hash(v.Elem())
+		return sum(reflect.Interface, elem), err
+	case reflect.Chan, reflect.UnsafePointer:
+		return sum(reflect.Chan, uint64(v.Pointer())), nil
+	case reflect.Func:
+		if !v.IsNil() {
+			return 0, fmt.Errorf("cannot hash non-nil value of type %v", t)
+		}
+		return sum(reflect.Func), nil
+	default:
+		return 0, fmt.Errorf("cannot hash value of type %v", t)
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"math"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeepHash", func() {
+	c := make(Comparisons)
+
+	hash := func(v interface{}) uint64 {
+		h, err := c.DeepHash(v)
+		Expect(err).NotTo(HaveOccurred())
+		return h
+	}
+
+	DescribeTable("equal values",
+		func(v1, v2 interface{}) {
+			Expect(c.DeepCompare(v1, v2)).To(Equal(0))
+			Expect(hash(v1)).To(Equal(hash(v2)))
+		},
+		Entry("ints", 1, 1),
+		Entry("zeros", 0.0, math.Copysign(0, -1)),
+		Entry("nil and empty slices", []int(nil), []int{}),
+		Entry("nil and empty maps", map[string]int(nil), map[string]int{}),
+		Entry("maps", map[string]int{"a": 1, "b": 2, "c": 3}, map[string]int{"c": 3, "b": 2, "a": 1}),
+		Entry("pointers", &Spec{Image: "a"}, &Spec{Image: "a"}),
+		Entry("interfaces", []interface{}{1, "a"}, []interface{}{1, "a"}),
+	)
+
+	DescribeTable("different values",
+		func(v1, v2 interface{}) {
+			Expect(hash(v1)).NotTo(Equal(hash(v2)))
+		},
+		Entry("ints", 1, 2),
+		Entry("strings", "a", "b"),
+		Entry("slices", []int{1, 2}, []int{2, 1}),
+		Entry("maps", map[string]int{"a": 1}, map[string]int{"a": 2}),
+		Entry("nil pointers", (*Spec)(nil), &Spec{}),
+		Entry("structs", Spec{Image: "a"}, Spec{Image: "b"}),
+	)
+
+	It("should skip ignored fields", func() {
+		type Tagged struct {
+			Name  string
+			Cache []int `compare:"ignore"`
+		}
+		Expect(hash(Tagged{"a", []int{1}})).To(Equal(hash(Tagged{"a", []int{2}})))
+	})
+
+	It("should error on values whose equality is opaque", func() {
+		_, err := NewComparisonsOrDie(CompareTime).DeepHash(struct{ T interface{} }{})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = NewComparisonsOrDie(func(s1, s2 Spec) int { return 0 }).DeepHash([]Spec{{}})
+		Expect(err).To(HaveOccurred())
+		_, err = c.DeepHash(func() {})
+		Expect(err).To(HaveOccurred())
+		_, err = c.DeepHash(struct {
+			Version string `compare:"semver"`
+		}{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should error on cyclic values", func() {
+		type Node struct{ Next *Node }
+		n := &Node{}
+		n.Next = n
+		_, err := c.DeepHash(n)
+		Expect(err).To(MatchError(ContainSubstring("cyclic")))
+	})
+})
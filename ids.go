@@ -0,0 +1,137 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"bytes"
+	"net"
+	"strings"
+)
+
+// CompareHardwareAddr compares two hardware addresses by their underlying bytes.
+//
+// It can be added to Comparisons via Comparisons.AddFunc.
+func CompareHardwareAddr(a1, a2 net.HardwareAddr) int {
+	return bytes.Compare(a1, a2)
+}
+
+// CompareHexID compares two hex encoded identifiers, e.g. MAC addresses in their
+// textual form ('00:1A:2b:3c:4d:5e'), by their underlying bytes.
+//
+// Separators (':', '-', '.' and ' ') are ignored and hex digits are compared
+// case-insensitively. Identifiers that are not valid hex are ordered after all valid
+// ones and compared among each other as case-folded strings (with separators removed).
+//
+// As identifiers are usually plain strings, CompareHexID is not meant to be registered
+// for the string type but to be wrapped in a function for a dedicated identifier type.
+func CompareHexID(id1, id2 string) int {
+	s1, s2 := normalizeHexID(id1), normalizeHexID(id2)
+	b1, ok1 := decodeHex(s1)
+	b2, ok2 := decodeHex(s2)
+	if !ok1 || !ok2 {
+		if ok1 != ok2 {
+			return compareBool(ok2, ok1)
+		}
+		return strings.Compare(s1, s2)
+	}
+	if res := compareInt64(int64(len(b1)), int64(len(b2))); res != 0 {
+		return res
+	}
+	return bytes.Compare(b1, b2)
+}
+
+// normalizeHexID removes all separators of the given identifier and lower-cases it.
+func normalizeHexID(id string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ':', '-', '.', ' ':
+			return -1
+		}
+		if 'A' <= r && r <= 'Z' {
+			return r + ('a' - 'A')
+		}
+		return r
+	}, id)
+}
+
+// decodeHex decodes the given lower-case hex string. Unlike hex.DecodeString,
+// an odd number of digits is treated as if prefixed by a zero.
+func decodeHex(s string) ([]byte, bool) {
+	if len(s)%2 != 0 {
+		s = "0" + s
+	}
+	res := make([]byte, len(s)/2)
+	for i := 0; i < len(s); i += 2 {
+		hi, ok := fromHexChar(s[i])
+		if !ok {
+			return nil, false
+		}
+		lo, ok := fromHexChar(s[i+1])
+		if !ok {
+			return nil, false
+		}
+		res[i/2] = hi<<4 | lo
+	}
+	return res, true
+}
+
+func fromHexChar(c byte) (byte, bool) {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0', true
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10, true
+	}
+	return 0, false
+}
+
+// CompareUUID compares two UUIDs in their binary form by byte value.
+//
+// CompareUUID is part of StandardFuncs. For custom UUID types (e.g. `type UUID [16]byte`),
+// use AdaptFunc to obtain a comparison function for that type.
+func CompareUUID(u1, u2 [16]byte) int {
+	return bytes.Compare(u1[:], u2[:])
+}
+
+// CompareUUIDString compares two UUIDs in their canonical textual form
+// ('123e4567-e89b-12d3-a456-426614174000') by byte value.
+//
+// Hex digits are compared case-insensitively. If any of the given strings is not
+// a valid UUID, both strings are compared as case-folded strings (with separators removed).
+//
+// For custom UUID types (e.g. `type UUID string`), use AdaptFunc to obtain a
+// comparison function for that type.
+func CompareUUIDString(s1, s2 string) int {
+	u1, ok1 := parseUUID(s1)
+	u2, ok2 := parseUUID(s2)
+	if !ok1 || !ok2 {
+		return strings.Compare(normalizeHexID(s1), normalizeHexID(s2))
+	}
+	return CompareUUID(u1, u2)
+}
+
+// parseUUID parses the canonical textual form of an UUID.
+func parseUUID(s string) ([16]byte, bool) {
+	var u [16]byte
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return u, false
+	}
+	b, ok := decodeHex(normalizeHexID(s))
+	if !ok || len(b) != len(u) {
+		return u, false
+	}
+	copy(u[:], b)
+	return u, true
+}
@@ -0,0 +1,104 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"net"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+func mustParseMAC(s string) net.HardwareAddr {
+	addr, err := net.ParseMAC(s)
+	if err != nil {
+		panic(err)
+	}
+	return addr
+}
+
+var _ = Describe("IDs", func() {
+	DescribeTable("CompareHardwareAddr",
+		func(a1, a2 net.HardwareAddr, expect int) {
+			Expect(CompareHardwareAddr(a1, a2)).To(Equal(expect))
+			Expect(CompareHardwareAddr(a2, a1)).To(Equal(-expect))
+		},
+		Entry("equal", mustParseMAC("00:1a:2b:3c:4d:5e"), mustParseMAC("00:1A:2B:3C:4D:5E"), 0),
+		Entry("less", mustParseMAC("00:1a:2b:3c:4d:5e"), mustParseMAC("00:1a:2b:3c:4d:5f"), -1),
+		Entry("nil less", net.HardwareAddr(nil), mustParseMAC("00:00:00:00:00:00"), -1),
+	)
+
+	DescribeTable("CompareHexID",
+		func(id1, id2 string, expect int) {
+			Expect(CompareHexID(id1, id2)).To(Equal(expect))
+			Expect(CompareHexID(id2, id1)).To(Equal(-expect))
+		},
+		Entry("mixed case", "00:1a:2B:3c:4d:5e", "00:1A:2b:3C:4D:5E", 0),
+		Entry("different separators", "00-1a-2b-3c-4d-5e", "001a.2b3c.4d5e", 0),
+		Entry("mixed case order", "0A:00", "0b:00", -1),
+		Entry("shorter is less", "ff:ff", "00:00:00", -1),
+		Entry("invalid hex falls back to folded strings", "zz:00", "ZZ:01", -1),
+		Entry("valid before invalid hex", "ff:ff:ff", "0g", -1),
+	)
+
+	DescribeTable("CompareUUID",
+		func(u1, u2 [16]byte, expect int) {
+			Expect(CompareUUID(u1, u2)).To(Equal(expect))
+			Expect(CompareUUID(u2, u1)).To(Equal(-expect))
+		},
+		Entry("equal", [16]byte{1, 2}, [16]byte{1, 2}, 0),
+		Entry("less", [16]byte{1, 2}, [16]byte{1, 3}, -1),
+	)
+
+	DescribeTable("CompareUUIDString",
+		func(s1, s2 string, expect int) {
+			Expect(CompareUUIDString(s1, s2)).To(Equal(expect))
+			Expect(CompareUUIDString(s2, s1)).To(Equal(-expect))
+		},
+		Entry("mixed case", "123e4567-e89b-12d3-a456-426614174000", "123E4567-E89B-12D3-A456-426614174000", 0),
+		Entry("byte order", "a23e4567-e89b-12d3-a456-426614174000", "B23e4567-e89b-12d3-a456-426614174000", -1),
+		Entry("invalid falls back to folded strings", "not-a-uuid", "NOT-A-UUID", 0),
+	)
+
+	It("should be adaptable to custom UUID types", func() {
+		type UUID string
+		c := NewComparisonsOrDie(AdaptFuncOrDie(CompareUUIDString, UUID("")))
+		Expect(c.DeepCompare(
+			UUID("A23E4567-E89B-12D3-A456-426614174000"),
+			UUID("b23e4567-e89b-12d3-a456-426614174000"),
+		)).To(Equal(-1))
+	})
+
+	It("should order mixed valid and invalid identifiers transitively", func() {
+		ids := []string{"0a", "9", "5g", "1", "g", "ff:ff", "09", "zz", "00:0b", "10"}
+		for _, a := range ids {
+			for _, b := range ids {
+				for _, c := range ids {
+					if CompareHexID(a, b) <= 0 && CompareHexID(b, c) <= 0 {
+						Expect(CompareHexID(a, c)).To(BeNumerically("<=", 0), "%s <= %s <= %s", a, b, c)
+					}
+				}
+			}
+		}
+	})
+
+	It("should be registrable for a dedicated identifier type", func() {
+		type MAC string
+		c := NewComparisonsOrDie(func(m1, m2 MAC) int { return CompareHexID(string(m1), string(m2)) })
+		Expect(c.DeepCompare([]MAC{"AA:00"}, []MAC{"ab:00"})).To(Equal(-1))
+	})
+})
@@ -0,0 +1,118 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package interval provides operations on intervals over values ordered by
+// reflcompare.Comparisons, e.g. to order, overlap and merge time or IP ranges,
+// and an interval tree indexing values by such intervals.
+package interval
+
+import (
+	"sort"
+
+	"github.com/adracus/reflcompare"
+)
+
+// Interval is the closed interval [Lo, Hi]. Lo and Hi have to be of the same type.
+type Interval struct {
+	Lo, Hi interface{}
+}
+
+// Intervals provides operations on intervals whose bounds are ordered by Comparisons.
+// All operations panic if bounds cannot be compared, see reflcompare.Comparisons.DeepCompare.
+type Intervals struct {
+	comparisons reflcompare.Comparisons
+}
+
+// New creates new Intervals ordering interval bounds by the given Comparisons.
+func New(c reflcompare.Comparisons) *Intervals {
+	return &Intervals{comparisons: c}
+}
+
+func (x *Intervals) compare(a1, a2 interface{}) int {
+	return x.comparisons.DeepCompare(a1, a2)
+}
+
+// Valid reports whether i is a valid interval, i.e. whether i.Lo is not greater than i.Hi.
+func (x *Intervals) Valid(i Interval) bool {
+	return x.compare(i.Lo, i.Hi) <= 0
+}
+
+// Compare orders intervals by their lower bounds first and by their upper bounds second.
+func (x *Intervals) Compare(i1, i2 Interval) int {
+	if res := x.compare(i1.Lo, i2.Lo); res != 0 {
+		return res
+	}
+	return x.compare(i1.Hi, i2.Hi)
+}
+
+// Contains reports whether v is within i.
+func (x *Intervals) Contains(i Interval, v interface{}) bool {
+	return x.compare(i.Lo, v) <= 0 && x.compare(v, i.Hi) <= 0
+}
+
+// Overlaps reports whether i1 and i2 have at least one value in common.
+func (x *Intervals) Overlaps(i1, i2 Interval) bool {
+	return x.compare(i1.Lo, i2.Hi) <= 0 && x.compare(i2.Lo, i1.Hi) <= 0
+}
+
+// Intersect returns the intersection of i1 and i2. If they do not overlap, it returns false.
+func (x *Intervals) Intersect(i1, i2 Interval) (Interval, bool) {
+	if !x.Overlaps(i1, i2) {
+		return Interval{}, false
+	}
+	return Interval{Lo: x.max(i1.Lo, i2.Lo), Hi: x.min(i1.Hi, i2.Hi)}, true
+}
+
+// Sort sorts the given intervals in place, see Compare.
+func (x *Intervals) Sort(intervals []Interval) {
+	sort.SliceStable(intervals, func(i, j int) bool {
+		return x.Compare(intervals[i], intervals[j]) < 0
+	})
+}
+
+// Merge merges overlapping intervals and returns the resulting disjoint intervals in order.
+// As the intervals are closed, intervals sharing a bound are merged as well.
+// The given slice is not modified.
+func (x *Intervals) Merge(intervals []Interval) []Interval {
+	if len(intervals) == 0 {
+		return nil
+	}
+	sorted := append([]Interval(nil), intervals...)
+	x.Sort(sorted)
+
+	res := []Interval{sorted[0]}
+	for _, i := range sorted[1:] {
+		last := &res[len(res)-1]
+		if x.compare(i.Lo, last.Hi) <= 0 {
+			last.Hi = x.max(last.Hi, i.Hi)
+			continue
+		}
+		res = append(res, i)
+	}
+	return res
+}
+
+func (x *Intervals) min(a1, a2 interface{}) interface{} {
+	if x.compare(a1, a2) <= 0 {
+		return a1
+	}
+	return a2
+}
+
+func (x *Intervals) max(a1, a2 interface{}) interface{} {
+	if x.compare(a1, a2) >= 0 {
+		return a1
+	}
+	return a2
+}
@@ -0,0 +1,104 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interval_test
+
+import (
+	"net"
+	"time"
+
+	"github.com/adracus/reflcompare"
+	. "github.com/adracus/reflcompare/interval"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+func ip(s string) net.IP {
+	return net.ParseIP(s).To4()
+}
+
+var _ = Describe("Intervals", func() {
+	x := New(make(reflcompare.Comparisons))
+
+	It("should check validity", func() {
+		Expect(x.Valid(Interval{1, 2})).To(BeTrue())
+		Expect(x.Valid(Interval{2, 2})).To(BeTrue())
+		Expect(x.Valid(Interval{3, 2})).To(BeFalse())
+	})
+
+	DescribeTable("Compare",
+		func(i1, i2 Interval, expect int) {
+			Expect(x.Compare(i1, i2)).To(Equal(expect))
+			Expect(x.Compare(i2, i1)).To(Equal(-expect))
+		},
+		Entry("equal", Interval{1, 2}, Interval{1, 2}, 0),
+		Entry("by lower bound", Interval{1, 5}, Interval{2, 3}, -1),
+		Entry("by upper bound", Interval{1, 2}, Interval{1, 3}, -1),
+	)
+
+	DescribeTable("Overlaps",
+		func(i1, i2 Interval, expect bool) {
+			Expect(x.Overlaps(i1, i2)).To(Equal(expect))
+			Expect(x.Overlaps(i2, i1)).To(Equal(expect))
+		},
+		Entry("disjoint", Interval{1, 2}, Interval{3, 4}, false),
+		Entry("sharing a bound", Interval{1, 2}, Interval{2, 4}, true),
+		Entry("overlapping", Interval{1, 3}, Interval{2, 4}, true),
+		Entry("containing", Interval{1, 4}, Interval{2, 3}, true),
+	)
+
+	It("should check containment", func() {
+		Expect(x.Contains(Interval{1, 3}, 1)).To(BeTrue())
+		Expect(x.Contains(Interval{1, 3}, 3)).To(BeTrue())
+		Expect(x.Contains(Interval{1, 3}, 4)).To(BeFalse())
+	})
+
+	It("should intersect intervals", func() {
+		i, ok := x.Intersect(Interval{1, 3}, Interval{2, 4})
+		Expect(ok).To(BeTrue())
+		Expect(i).To(Equal(Interval{2, 3}))
+		_, ok = x.Intersect(Interval{1, 2}, Interval{3, 4})
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should sort intervals", func() {
+		intervals := []Interval{{3, 4}, {1, 5}, {1, 2}}
+		x.Sort(intervals)
+		Expect(intervals).To(Equal([]Interval{{1, 2}, {1, 5}, {3, 4}}))
+	})
+
+	It("should merge intervals", func() {
+		intervals := []Interval{{5, 6}, {1, 2}, {2, 3}, {8, 9}, {5, 5}}
+		Expect(x.Merge(intervals)).To(Equal([]Interval{{1, 3}, {5, 6}, {8, 9}}))
+		Expect(intervals).To(Equal([]Interval{{5, 6}, {1, 2}, {2, 3}, {8, 9}, {5, 5}}))
+		Expect(x.Merge(nil)).To(BeNil())
+	})
+
+	It("should use comparison functions", func() {
+		x := New(reflcompare.NewComparisonsOrDie(reflcompare.CompareTime))
+		now := time.Now()
+		Expect(x.Merge([]Interval{
+			{now, now.Add(time.Hour)},
+			{now.Add(30 * time.Minute), now.Add(2 * time.Hour)},
+		})).To(Equal([]Interval{{now, now.Add(2 * time.Hour)}}))
+	})
+
+	It("should merge IP ranges", func() {
+		Expect(x.Merge([]Interval{
+			{ip("10.0.0.0"), ip("10.0.0.255")},
+			{ip("10.0.0.128"), ip("10.0.1.255")},
+		})).To(Equal([]Interval{{ip("10.0.0.0"), ip("10.0.1.255")}}))
+	})
+})
@@ -0,0 +1,171 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interval
+
+import (
+	"math/rand"
+)
+
+type node struct {
+	interval    Interval
+	value       interface{}
+	priority    uint32
+	left, right *node
+	// maxHi is the greatest upper bound of the intervals in the subtree of the node.
+	maxHi interface{}
+}
+
+// Tree is an interval tree, indexing values by intervals for stabbing and overlap queries.
+// Intervals are ordered like Intervals.Compare; equal intervals are ordered by insertion.
+//
+// Insertions, deletions and queries take O(log n) comparisons (plus O(k) for reporting
+// k results), expected. A Tree is not safe for concurrent use.
+type Tree struct {
+	intervals *Intervals
+	root      *node
+	size      int
+}
+
+// NewTree creates a new, empty Tree ordering interval bounds by the Comparisons of x.
+func (x *Intervals) NewTree() *Tree {
+	return &Tree{intervals: x}
+}
+
+// Len returns the number of intervals in the Tree.
+func (t *Tree) Len() int {
+	return t.size
+}
+
+func (t *Tree) update(n *node) {
+	n.maxHi = n.interval.Hi
+	for _, child := range []*node{n.left, n.right} {
+		if child != nil {
+			n.maxHi = t.intervals.max(n.maxHi, child.maxHi)
+		}
+	}
+}
+
+// Insert adds value indexed by i.
+func (t *Tree) Insert(i Interval, value interface{}) {
+	n := &node{interval: i, value: value, priority: rand.Uint32(), maxHi: i.Hi}
+	l, r := t.split(t.root, i)
+	t.root = t.merge(t.merge(l, n), r)
+	t.size++
+}
+
+// Delete removes the first value indexed by an interval equal to i and reports whether there was one.
+func (t *Tree) Delete(i Interval) bool {
+	var deleted bool
+	t.root = t.delete(t.root, i, &deleted)
+	if deleted {
+		t.size--
+	}
+	return deleted
+}
+
+func (t *Tree) delete(n *node, i Interval, deleted *bool) *node {
+	if n == nil {
+		return nil
+	}
+	switch res := t.intervals.Compare(i, n.interval); {
+	case res < 0:
+		n.left = t.delete(n.left, i, deleted)
+	case res > 0:
+		n.right = t.delete(n.right, i, deleted)
+	default:
+		// Prefer an equal interval inserted earlier.
+		if n.left = t.delete(n.left, i, deleted); !*deleted {
+			*deleted = true
+			return t.merge(n.left, n.right)
+		}
+	}
+	t.update(n)
+	return n
+}
+
+// Stab calls fn for every interval containing v and its value, in order. If fn returns false, Stab stops.
+func (t *Tree) Stab(v interface{}, fn func(i Interval, value interface{}) bool) {
+	t.Overlapping(Interval{Lo: v, Hi: v}, fn)
+}
+
+// Overlapping calls fn for every interval overlapping i and its value, in order.
+// If fn returns false, Overlapping stops.
+func (t *Tree) Overlapping(i Interval, fn func(i Interval, value interface{}) bool) {
+	t.overlapping(t.root, i, fn)
+}
+
+func (t *Tree) overlapping(n *node, i Interval, fn func(i Interval, value interface{}) bool) bool {
+	x := t.intervals
+	if n == nil || x.compare(n.maxHi, i.Lo) < 0 {
+		// No interval of the subtree ends at or after i.Lo.
+		return true
+	}
+	if !t.overlapping(n.left, i, fn) {
+		return false
+	}
+	if x.compare(n.interval.Lo, i.Hi) > 0 {
+		// All following intervals start after i.Hi.
+		return true
+	}
+	if x.Overlaps(n.interval, i) && !fn(n.interval, n.value) {
+		return false
+	}
+	return t.overlapping(n.right, i, fn)
+}
+
+// Ascend calls fn for every interval and its value in order. If fn returns false, Ascend stops.
+func (t *Tree) Ascend(fn func(i Interval, value interface{}) bool) {
+	ascend(t.root, fn)
+}
+
+func ascend(n *node, fn func(i Interval, value interface{}) bool) bool {
+	if n == nil {
+		return true
+	}
+	return ascend(n.left, fn) && fn(n.interval, n.value) && ascend(n.right, fn)
+}
+
+// split splits n into the nodes whose intervals are not greater than i and the nodes whose intervals are.
+func (t *Tree) split(n *node, i Interval) (l, r *node) {
+	if n == nil {
+		return nil, nil
+	}
+	if t.intervals.Compare(n.interval, i) <= 0 {
+		n.right, r = t.split(n.right, i)
+		t.update(n)
+		return n, r
+	}
+	l, n.left = t.split(n.left, i)
+	t.update(n)
+	return l, n
+}
+
+// merge merges l and r, assuming all nodes of l are ordered before all nodes of r.
+func (t *Tree) merge(l, r *node) *node {
+	switch {
+	case l == nil:
+		return r
+	case r == nil:
+		return l
+	case l.priority > r.priority:
+		l.right = t.merge(l.right, r)
+		t.update(l)
+		return l
+	default:
+		r.left = t.merge(l, r.left)
+		t.update(r)
+		return r
+	}
+}
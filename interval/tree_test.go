@@ -0,0 +1,128 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interval_test
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/adracus/reflcompare"
+	. "github.com/adracus/reflcompare/interval"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tree", func() {
+	x := New(make(reflcompare.Comparisons))
+	var t *Tree
+	BeforeEach(func() {
+		t = x.NewTree()
+		t.Insert(Interval{1, 5}, "a")
+		t.Insert(Interval{3, 4}, "b")
+		t.Insert(Interval{6, 9}, "c")
+		t.Insert(Interval{1, 5}, "d")
+	})
+
+	values := func(query func(fn func(Interval, interface{}) bool)) []interface{} {
+		var res []interface{}
+		query(func(_ Interval, value interface{}) bool {
+			res = append(res, value)
+			return true
+		})
+		return res
+	}
+
+	It("should order intervals", func() {
+		Expect(t.Len()).To(Equal(4))
+		Expect(values(t.Ascend)).To(Equal([]interface{}{"a", "d", "b", "c"}))
+	})
+
+	It("should find intervals containing a value", func() {
+		stab := func(v interface{}) []interface{} {
+			return values(func(fn func(Interval, interface{}) bool) { t.Stab(v, fn) })
+		}
+		Expect(stab(4)).To(Equal([]interface{}{"a", "d", "b"}))
+		Expect(stab(5)).To(Equal([]interface{}{"a", "d"}))
+		Expect(stab(0)).To(BeEmpty())
+		Expect(stab(9)).To(Equal([]interface{}{"c"}))
+	})
+
+	It("should find overlapping intervals", func() {
+		overlapping := func(i Interval) []interface{} {
+			return values(func(fn func(Interval, interface{}) bool) { t.Overlapping(i, fn) })
+		}
+		Expect(overlapping(Interval{5, 6})).To(Equal([]interface{}{"a", "d", "c"}))
+		Expect(overlapping(Interval{10, 20})).To(BeEmpty())
+	})
+
+	It("should delete intervals in insertion order", func() {
+		Expect(t.Delete(Interval{1, 5})).To(BeTrue())
+		Expect(values(t.Ascend)).To(Equal([]interface{}{"d", "b", "c"}))
+		Expect(t.Delete(Interval{1, 6})).To(BeFalse())
+		Expect(t.Len()).To(Equal(3))
+	})
+
+	It("should index times", func() {
+		t := New(reflcompare.NewComparisonsOrDie(reflcompare.CompareTime)).NewTree()
+		day := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+		t.Insert(Interval{day, day.Add(24 * time.Hour)}, "day")
+		var n int
+		t.Stab(day.Add(12*time.Hour).In(time.FixedZone("X", 3600)), func(Interval, interface{}) bool {
+			n++
+			return true
+		})
+		Expect(n).To(Equal(1))
+	})
+
+	It("should agree with a linear scan", func() {
+		r := rand.New(rand.NewSource(1))
+		t := x.NewTree()
+		var all []Interval
+		for n := 0; n < 200; n++ {
+			lo := r.Intn(100)
+			i := Interval{lo, lo + r.Intn(10)}
+			all = append(all, i)
+			t.Insert(i, nil)
+			if n%3 == 0 {
+				d := all[r.Intn(len(all))]
+				if t.Delete(d) {
+					for k, i := range all {
+						if x.Compare(i, d) == 0 {
+							all = append(all[:k], all[k+1:]...)
+							break
+						}
+					}
+				}
+			}
+		}
+		Expect(t.Len()).To(Equal(len(all)))
+		for q := 0; q < 50; q++ {
+			lo := r.Intn(110)
+			query := Interval{lo, lo + r.Intn(5)}
+			var expected []Interval
+			for _, i := range all {
+				if x.Overlaps(i, query) {
+					expected = append(expected, i)
+				}
+			}
+			var actual []Interval
+			t.Overlapping(query, func(i Interval, _ interface{}) bool {
+				actual = append(actual, i)
+				return true
+			})
+			Expect(actual).To(ConsistOf(expected))
+		}
+	})
+})
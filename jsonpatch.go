@@ -0,0 +1,190 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/adracus/reflcompare/jsonpatch"
+)
+
+// JSONPatch returns the JSON Patch (RFC 6902) operations transforming a into b, derived from
+// their differences as found by Diff. Paths are JSON Pointers to the values as marshaled by
+// encoding/json, respecting json struct tags. The operations are in the order of the
+// differences, except that removals of trailing slice elements are reversed, so applying
+// the operations in order keeps indices valid.
+//
+// Values considered equal are not patched, e.g. nil and empty slices, and neither are
+// values that are not marshaled, e.g. fields tagged with `json:"-"`.
+//
+// JSONPatch returns an error if the values cannot be compared.
+func (c Comparisons) JSONPatch(a, b interface{}) ([]jsonpatch.Op, error) {
+	v1 := reflect.ValueOf(a)
+	v2 := reflect.ValueOf(b)
+	if v1.IsValid() && v2.IsValid() && v1.Type() != v2.Type() {
+		return nil, &typeMismatchError{t1: v1.Type(), t2: v2.Type()}
+	}
+	d := newDiffer(c.newTraversal())
+	defer d.release()
+	d.paths = [][]pathStep{}
+	if err := d.diff(v1, v2, 0); err != nil {
+		return nil, err
+	}
+
+	var ops, removals []jsonpatch.Op
+	flush := func() {
+		for i := len(removals) - 1; i >= 0; i-- {
+			ops = append(ops, removals[i])
+		}
+		removals = removals[:0]
+	}
+	for i, diff := range d.diffs {
+		path, absent, ok, err := jsonPointer(v1, d.paths[i])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", diff.Path, err)
+		}
+		if !ok {
+			continue
+		}
+		// Values present in only one of a and b are reported with the result of comparing
+		// their presence.
+		missing := diff.Comparator == "length" || diff.Comparator == "key missing"
+		switch {
+		case missing && diff.Result > 0 && diff.Comparator == "length":
+			removals = append(removals, jsonpatch.Op{Op: jsonpatch.Remove, Path: path})
+			continue
+		case missing && diff.Result > 0:
+			flush()
+			ops = append(ops, jsonpatch.Op{Op: jsonpatch.Remove, Path: path})
+		case missing || absent:
+			flush()
+			ops = append(ops, jsonpatch.Op{Op: jsonpatch.Add, Path: path, Value: diff.B})
+		default:
+			flush()
+			ops = append(ops, jsonpatch.Op{Op: jsonpatch.Replace, Path: path, Value: diff.B})
+		}
+	}
+	flush()
+	return ops, nil
+}
+
+// jsonPointer returns the JSON Pointer to the value at the path of steps in v. The path has
+// to exist in v up to its last step. If the value is not marshaled, ok is false. If the value
+// is a field omitted because it is empty, absent is true.
+func jsonPointer(v reflect.Value, steps []pathStep) (pointer string, absent, ok bool, err error) {
+	var tokens []string
+	for _, s := range steps {
+		absent = false
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			v = v.Elem()
+		}
+		switch {
+		case s.field != "":
+			f, _ := v.Type().FieldByName(s.field)
+			name, omitEmpty, marshaled := jsonFieldName(f)
+			if !marshaled {
+				return "", false, false, nil
+			}
+			if name != "" {
+				tokens = append(tokens, name)
+			}
+			v = v.FieldByIndex(f.Index)
+			absent = omitEmpty && isEmptyJSON(v)
+		case s.key.IsValid() && v.Kind() == reflect.Slice:
+			// Elements of keyed lists are identified by their keys, but patched by their indices.
+			tokens = append(tokens, strconv.Itoa(s.index))
+			if s.index < v.Len() {
+				v = v.Index(s.index)
+			}
+		case s.key.IsValid():
+			key, err := jsonKey(s.key)
+			if err != nil {
+				return "", false, false, err
+			}
+			tokens = append(tokens, key)
+			v = v.MapIndex(s.key)
+		default:
+			tokens = append(tokens, strconv.Itoa(s.index))
+			if s.index < v.Len() {
+				v = v.Index(s.index)
+			}
+		}
+	}
+	return jsonpatch.Pointer(tokens...), absent, true, nil
+}
+
+// jsonFieldName returns the name of the struct field f as marshaled by encoding/json and
+// whether it is omitted if empty. The name is empty if the fields of f are inlined into its
+// parent. If f is not marshaled at all, marshaled is false.
+func jsonFieldName(f reflect.StructField) (name string, omitEmpty, marshaled bool) {
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return "", false, false
+	}
+	name = tag
+	if i := strings.IndexByte(tag, ','); i >= 0 {
+		name = tag[:i]
+		omitEmpty = strings.Contains(tag[i:], ",omitempty")
+	}
+	if name != "" {
+		return name, omitEmpty, f.PkgPath == "" || f.Anonymous
+	}
+	if f.Anonymous {
+		t := f.Type
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() == reflect.Struct {
+			return "", false, true
+		}
+	}
+	return f.Name, omitEmpty, f.PkgPath == ""
+}
+
+// isEmptyJSON reports whether v is empty as defined by the omitempty option of encoding/json.
+func isEmptyJSON(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return v.IsZero()
+	}
+	return false
+}
+
+// jsonKey returns the map key k as marshaled by encoding/json.
+func jsonKey(k reflect.Value) (string, error) {
+	if k.Kind() == reflect.String {
+		return k.String(), nil
+	}
+	if tm, ok := k.Interface().(interface{ MarshalText() ([]byte, error) }); ok {
+		text, err := tm.MarshalText()
+		return string(text), err
+	}
+	switch k.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(k.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(k.Uint(), 10), nil
+	}
+	return "", fmt.Errorf("unsupported map key type %v", k.Type())
+}
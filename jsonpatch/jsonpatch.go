@@ -0,0 +1,91 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jsonpatch provides the operations of JSON Patch documents (RFC 6902),
+// as generated by reflcompare.Comparisons.JSONPatch.
+package jsonpatch
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// The supported operations. Generated patches only use Add, Remove and Replace.
+const (
+	Add     = "add"
+	Remove  = "remove"
+	Replace = "replace"
+	Move    = "move"
+	Copy    = "copy"
+	Test    = "test"
+)
+
+// Op is an operation of a JSON Patch document.
+type Op struct {
+	// Op is the operation, e.g. Add.
+	Op string
+	// Path is the JSON Pointer (RFC 6901) to the target location, e.g. '/spec/replicas'.
+	Path string
+	// From is the JSON Pointer to the source location of Move and Copy.
+	From string
+	// Value is the value of Add, Replace and Test. It is marshaled as JSON.
+	Value interface{}
+}
+
+type op struct {
+	Op    string       `json:"op"`
+	Path  string       `json:"path"`
+	From  string       `json:"from,omitempty"`
+	Value *interface{} `json:"value,omitempty"`
+}
+
+// MarshalJSON encodes o as a JSON Patch operation object. Value is included for the
+// operations that require it, even if it is nil.
+func (o Op) MarshalJSON() ([]byte, error) {
+	res := op{Op: o.Op, Path: o.Path}
+	switch o.Op {
+	case Add, Replace, Test:
+		res.Value = &o.Value
+	case Move, Copy:
+		res.From = o.From
+	}
+	return json.Marshal(res)
+}
+
+// UnmarshalJSON decodes o from a JSON Patch operation object. Values are decoded as by
+// json.Unmarshal into an interface{}.
+func (o *Op) UnmarshalJSON(data []byte) error {
+	var res op
+	if err := json.Unmarshal(data, &res); err != nil {
+		return err
+	}
+	*o = Op{Op: res.Op, Path: res.Path, From: res.From}
+	if res.Value != nil {
+		o.Value = *res.Value
+	}
+	return nil
+}
+
+var escaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// Pointer returns the JSON Pointer (RFC 6901) consisting of the given reference tokens,
+// escaping them as necessary. Pointer() is the empty pointer referencing the whole document.
+func Pointer(tokens ...string) string {
+	var sb strings.Builder
+	for _, token := range tokens {
+		sb.WriteByte('/')
+		escaper.WriteString(&sb, token)
+	}
+	return sb.String()
+}
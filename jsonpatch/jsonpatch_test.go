@@ -0,0 +1,57 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpatch_test
+
+import (
+	"encoding/json"
+
+	. "github.com/adracus/reflcompare/jsonpatch"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Op", func() {
+	It("should marshal operations as RFC 6902 objects", func() {
+		data, err := json.Marshal([]Op{
+			{Op: Replace, Path: "/a", Value: nil},
+			{Op: Remove, Path: "/b", Value: 1},
+			{Op: Move, Path: "/c", From: "/d"},
+			{Op: Add, Path: "/e", Value: map[string]int{"x": 1}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(MatchJSON(`[
+			{"op": "replace", "path": "/a", "value": null},
+			{"op": "remove", "path": "/b"},
+			{"op": "move", "path": "/c", "from": "/d"},
+			{"op": "add", "path": "/e", "value": {"x": 1}}
+		]`))
+	})
+
+	It("should unmarshal operations", func() {
+		var ops []Op
+		Expect(json.Unmarshal([]byte(`[{"op": "test", "path": "/a", "value": [1]}, {"op": "copy", "path": "/b", "from": "/a"}]`), &ops)).To(Succeed())
+		Expect(ops).To(Equal([]Op{
+			{Op: Test, Path: "/a", Value: []interface{}{1.0}},
+			{Op: Copy, Path: "/b", From: "/a"},
+		}))
+	})
+})
+
+var _ = Describe("Pointer", func() {
+	It("should escape reference tokens", func() {
+		Expect(Pointer()).To(Equal(""))
+		Expect(Pointer("a", "", "b/c", "d~e", "0")).To(Equal("/a//b~1c/d~0e/0"))
+	})
+})
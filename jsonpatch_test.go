@@ -0,0 +1,98 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	. "github.com/adracus/reflcompare"
+	"github.com/adracus/reflcompare/jsonpatch"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JSONPatch", func() {
+	type Meta struct {
+		Name   string            `json:"name"`
+		Labels map[string]string `json:"labels,omitempty"`
+	}
+	type Port struct {
+		Port int `json:"port"`
+	}
+	type Service struct {
+		Meta
+		Ports    []Port `json:"ports"`
+		Replicas *int   `json:"replicas,omitempty"`
+		Note     string `json:"note,omitempty"`
+		Internal string `json:"-"`
+	}
+	c := make(Comparisons)
+
+	It("should replace different values", func() {
+		ops, err := c.JSONPatch(
+			Service{Meta: Meta{Name: "a"}, Ports: []Port{{80}}, Note: "x"},
+			Service{Meta: Meta{Name: "b"}, Ports: []Port{{8080}}, Note: "y"},
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ops).To(Equal([]jsonpatch.Op{
+			{Op: jsonpatch.Replace, Path: "/name", Value: "b"},
+			{Op: jsonpatch.Replace, Path: "/ports/0/port", Value: 8080},
+			{Op: jsonpatch.Replace, Path: "/note", Value: "y"},
+		}))
+	})
+
+	It("should add and remove slice elements and map entries", func() {
+		ops, err := c.JSONPatch(
+			Service{Meta: Meta{Labels: map[string]string{"a/b": "1", "c": "2"}}, Ports: []Port{{1}, {2}, {3}}},
+			Service{Meta: Meta{Labels: map[string]string{"c": "2", "d~": "3"}}, Ports: []Port{{1}}},
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ops).To(Equal([]jsonpatch.Op{
+			{Op: jsonpatch.Remove, Path: "/labels/a~1b"},
+			{Op: jsonpatch.Add, Path: "/labels/d~0", Value: "3"},
+			{Op: jsonpatch.Remove, Path: "/ports/2"},
+			{Op: jsonpatch.Remove, Path: "/ports/1"},
+		}))
+
+		ops, err = c.JSONPatch(Service{Ports: []Port{{1}}}, Service{Ports: []Port{{1}, {2}}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ops).To(Equal([]jsonpatch.Op{{Op: jsonpatch.Add, Path: "/ports/1", Value: Port{2}}}))
+	})
+
+	It("should add omitted fields", func() {
+		replicas := 3
+		ops, err := c.JSONPatch(Service{}, Service{Replicas: &replicas, Note: "x"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ops).To(Equal([]jsonpatch.Op{
+			{Op: jsonpatch.Add, Path: "/replicas", Value: &replicas},
+			{Op: jsonpatch.Add, Path: "/note", Value: "x"},
+		}))
+	})
+
+	It("should skip fields that are not marshaled", func() {
+		ops, err := c.JSONPatch(Service{Internal: "a"}, Service{Internal: "b"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ops).To(BeEmpty())
+	})
+
+	It("should replace the whole value at the root", func() {
+		ops, err := c.JSONPatch(1, 2)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ops).To(Equal([]jsonpatch.Op{{Op: jsonpatch.Replace, Path: "", Value: 2}}))
+	})
+
+	It("should error on values that cannot be compared", func() {
+		_, err := c.JSONPatch(1, "1")
+		Expect(err).To(HaveOccurred())
+	})
+})
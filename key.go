@@ -0,0 +1,58 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Key is a comparable wrapper of a value, usable as a Go map key even for values of types
+// that are not comparable, e.g. slices and maps. Keys are equal if the wrapped values have
+// the same type and the same canonical encoding (see Comparisons.MarshalCanonical), so nil
+// and empty slices and maps yield equal keys. The zero Key wraps the untyped nil.
+type Key struct {
+	typ reflect.Type
+	enc string
+}
+
+// Type returns the type of the wrapped value, or nil for the untyped nil.
+func (k Key) Type() reflect.Type {
+	return k.typ
+}
+
+// String implements fmt.Stringer.
+func (k Key) String() string {
+	if k.typ == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("%v(%s)", k.typ, k.enc)
+}
+
+// Key returns the Key of v, e.g. to deduplicate values via a map[Key]T. Values with equal
+// keys are equal according to DeepCompare. Values compared by comparison functions that
+// consider distinct values equal (e.g. strings ignoring case) may have different keys, though.
+//
+// Key returns an error if v cannot be encoded canonically, see Comparisons.MarshalCanonical.
+func (c Comparisons) Key(v interface{}) (Key, error) {
+	if v == nil {
+		return Key{}, nil
+	}
+	enc, err := c.MarshalCanonical(v)
+	if err != nil {
+		return Key{}, err
+	}
+	return Key{typ: reflect.TypeOf(v), enc: string(enc)}, nil
+}
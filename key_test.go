@@ -0,0 +1,67 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"reflect"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Key", func() {
+	c := make(Comparisons)
+
+	key := func(v interface{}) Key {
+		k, err := c.Key(v)
+		Expect(err).NotTo(HaveOccurred())
+		return k
+	}
+
+	It("should deduplicate values that are not comparable", func() {
+		seen := make(map[Key]int)
+		for _, v := range []interface{}{
+			[]int{1, 2}, []int{1, 2}, []int{2, 1},
+			map[string]int{"a": 1, "b": 2}, map[string]int{"b": 2, "a": 1},
+			[]int(nil), []int{},
+		} {
+			seen[key(v)]++
+		}
+		Expect(seen).To(HaveLen(4))
+		Expect(seen[key([]int{1, 2})]).To(Equal(2))
+		Expect(seen[key(map[string]int{"a": 1, "b": 2})]).To(Equal(2))
+		Expect(seen[key([]int(nil))]).To(Equal(2))
+	})
+
+	It("should distinguish values of different types", func() {
+		Expect(key(1)).NotTo(Equal(key(int64(1))))
+		Expect(key([]int{1}).Type()).To(Equal(reflect.TypeOf([]int{})))
+	})
+
+	It("should wrap the untyped nil in the zero key", func() {
+		Expect(key(nil)).To(Equal(Key{}))
+		Expect(key(nil).String()).To(Equal("<nil>"))
+	})
+
+	It("should render the type and encoding", func() {
+		Expect(key([]string{"a"}).String()).To(Equal(`[]string(["a"])`))
+	})
+
+	It("should fail for values that cannot be encoded", func() {
+		_, err := c.Key(func() {})
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,134 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import "reflect"
+
+// LayeredComparisons are Comparisons layered on top of parent Comparisons.
+//
+// Types without a comparison function in the own layer fall back to the comparison
+// functions of the parents. The parents are neither copied nor modified, so comparison
+// functions added to them later on are visible in the LayeredComparisons as well. This
+// allows layering application-specific comparison functions on top of a shared base.
+type LayeredComparisons struct {
+	// layers are the own Comparisons, followed by the ones of the parents, nearest first.
+	layers []Comparisons
+}
+
+// NewComparisonsWithParent creates new LayeredComparisons without own comparison functions
+// that fall back to the comparison functions of parent.
+//
+// The link to parent is held by the returned value only, so it goes away with it.
+func NewComparisonsWithParent(parent Comparisons) LayeredComparisons {
+	layers := []Comparisons{make(Comparisons)}
+	if parent != nil {
+		layers = append(layers, parent)
+	}
+	return LayeredComparisons{layers: layers}
+}
+
+// NewChild creates new LayeredComparisons without own comparison functions that fall
+// back to the comparison functions of l.
+func (l LayeredComparisons) NewChild() LayeredComparisons {
+	layers := make([]Comparisons, 0, len(l.layers)+1)
+	layers = append(layers, make(Comparisons))
+	return LayeredComparisons{layers: append(layers, l.layers...)}
+}
+
+// own returns the own comparison functions of l.
+func (l LayeredComparisons) own() Comparisons {
+	if len(l.layers) == 0 {
+		return nil
+	}
+	return l.layers[0]
+}
+
+// parents returns the Comparisons l falls back to.
+func (l LayeredComparisons) parents() []Comparisons {
+	if len(l.layers) == 0 {
+		return nil
+	}
+	return l.layers[1:]
+}
+
+// AddFunc adds the given comparison function to the own comparison functions of l.
+// See Comparisons.AddFunc for more details.
+func (l LayeredComparisons) AddFunc(compareFunc interface{}) error {
+	return l.own().AddFunc(compareFunc)
+}
+
+// AddFuncs adds the given comparison functions to the own comparison functions of l.
+// See Comparisons.AddFuncs for more details.
+func (l LayeredComparisons) AddFuncs(funcs ...interface{}) error {
+	return l.own().AddFuncs(funcs...)
+}
+
+// Copy resolves the comparison functions of l and its parents into new Comparisons.
+// Comparison functions of l take precedence over the ones of its parents.
+func (l LayeredComparisons) Copy() Comparisons {
+	res := make(Comparisons)
+	for i := len(l.layers) - 1; i >= 0; i-- {
+		for t, fv := range l.layers[i] {
+			res[t] = fv
+		}
+	}
+	return res
+}
+
+// Freeze creates an immutable snapshot of l including the comparison functions of
+// its parents. Subsequent modifications of l or its parents do not affect the snapshot.
+func (l LayeredComparisons) Freeze() FrozenComparisons {
+	return FrozenComparisons{comparisons: l.Copy()}
+}
+
+// DeepCompare compares two values. See Comparisons.DeepCompare for more details.
+func (l LayeredComparisons) DeepCompare(a1, a2 interface{}) int {
+	return l.newTraversal().compare(a1, a2)
+}
+
+// TryDeepCompare compares two values, returning an error if they cannot be compared.
+// See Comparisons.TryDeepCompare for more details.
+func (l LayeredComparisons) TryDeepCompare(a1, a2 interface{}) (res int, err error) {
+	// Comparison functions may still panic.
+	defer func() {
+		if x := recover(); x != nil {
+			err = recoveredError(x)
+		}
+	}()
+	return l.newTraversal().tryCompare(a1, a2)
+}
+
+// newTraversal creates a new traversal looking up comparison functions in all layers of l.
+func (l LayeredComparisons) newTraversal(opts ...Option) *traversal {
+	t := l.own().newTraversal(opts...)
+	t.parents = l.parents()
+	return t
+}
+
+// lookupLayers looks up the comparison function for t in the given layers, nearest first.
+func lookupLayers(layers []Comparisons, t reflect.Type) (reflect.Value, bool) {
+	for _, c := range layers {
+		if fv, ok := c[t]; ok {
+			return fv, true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// hasLayer reports whether any of the given layers has a comparison function for t.
+func hasLayer(layers []Comparisons, t reflect.Type) bool {
+	_, ok := lookupLayers(layers, t)
+	return ok
+}
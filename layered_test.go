@@ -0,0 +1,64 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LayeredComparisons", func() {
+	reverse := func(a, b int) int { return b - a }
+
+	It("should fall back to the parent's comparison functions", func() {
+		parent := make(Comparisons)
+		c := NewComparisonsWithParent(parent).NewChild()
+		Expect(c.DeepCompare(1, 2)).To(Equal(-1))
+
+		Expect(parent.AddFunc(reverse)).To(Succeed())
+		Expect(c.DeepCompare(1, 2)).To(Equal(1))
+		Expect(c.DeepCompare([]Struct{{A: 1}}, []Struct{{A: 2}})).To(Equal(1))
+		Expect(c.NewComparer().Compare(1, 2)).To(Equal(1))
+	})
+
+	It("should prefer its own comparison functions without modifying the parent", func() {
+		parent := NewComparisonsOrDie(reverse)
+		c := NewComparisonsWithParent(parent)
+		Expect(c.AddFunc(func(a, b int) int { return 0 })).To(Succeed())
+		Expect(c.DeepCompare(1, 2)).To(Equal(0))
+		Expect(parent.DeepCompare(1, 2)).To(Equal(1))
+	})
+
+	It("should resolve the parent's comparison functions when freezing", func() {
+		parent := NewComparisonsOrDie(reverse)
+		f := NewComparisonsWithParent(parent).Freeze()
+		delete(parent, reflectTypeOfInt)
+		Expect(f.DeepCompare(1, 2)).To(Equal(1))
+	})
+
+	It("should resolve its own and the parent's comparison functions when copying", func() {
+		parent := NewComparisonsOrDie(reverse)
+		c := NewComparisonsWithParent(parent)
+		Expect(c.AddFunc(func(a, b string) int { return 0 })).To(Succeed())
+		Expect(parent).To(HaveLen(1))
+		Expect(c.Copy()).To(HaveLen(2))
+		Expect(c.Copy().DeepCompare(1, 2)).To(Equal(1))
+	})
+
+	It("should work without a parent", func() {
+		Expect(NewComparisonsWithParent(nil).DeepCompare(1, 2)).To(Equal(-1))
+	})
+})
@@ -0,0 +1,244 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// mergeKeyTag is the key of struct tags declaring the merge key of the elements of a list
+// field, e.g. `patchMergeKey:"name"`, as used by Kubernetes. The value is the Go or JSON name
+// of the key field of the elements.
+const mergeKeyTag = "patchMergeKey"
+
+// MergeKey declares the field of the struct type of sample named field as the merge key
+// of lists (slices) of the type or of pointers to it, like the `patchMergeKey` tag of list
+// fields does for single lists. The merge key identifies the elements of such keyed lists:
+//
+// Keyed lists of equal length are ordered by the sequences of their keys first and by their
+// elements second. Diff matches the elements of keyed lists by their keys instead of their
+// indices, so moving elements is not reported as a difference, and reports elements with
+// keys missing from the other list as 'key missing'.
+//
+// MergeKey panics if sample is not a struct or a pointer to one or if field does not
+// denote a field of it.
+func MergeKey(sample interface{}, field string) Option {
+	t := reflect.TypeOf(sample)
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("expected struct, got: %T", sample))
+	}
+	f, ok := t.FieldByName(field)
+	if !ok {
+		panic(fmt.Sprintf("type %v has no field %s", t, field))
+	}
+	return func(o *options) {
+		if o.mergeKeys == nil {
+			o.mergeKeys = make(map[reflect.Type][]int)
+		}
+		o.mergeKeys[t] = f.Index
+	}
+}
+
+// SortKeyedLists compares keyed lists (see MergeKey) as if their elements were sorted by
+// their keys first and by their remaining fields second, so lists holding the same elements
+// in different orders are equal.
+func SortKeyedLists() Option {
+	return func(o *options) {
+		o.sortKeyedLists = true
+	}
+}
+
+// fieldMergeKey resolves the merge key declared via the patchMergeKey tag of the field f, if any.
+func fieldMergeKey(f reflect.StructField) ([]int, error) {
+	name, ok := f.Tag.Lookup(mergeKeyTag)
+	if !ok {
+		return nil, nil
+	}
+	if f.Type.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("field %s: %s tag on non-slice type %v", f.Name, mergeKeyTag, f.Type)
+	}
+	elem := f.Type.Elem()
+	if elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("field %s: %s tag on list of non-struct type %v", f.Name, mergeKeyTag, elem)
+	}
+	for i := 0; i < elem.NumField(); i++ {
+		ef := elem.Field(i)
+		jsonName := ef.Tag.Get("json")
+		if j := strings.IndexByte(jsonName, ','); j >= 0 {
+			jsonName = jsonName[:j]
+		}
+		if ef.Name == name || jsonName == name {
+			return ef.Index, nil
+		}
+	}
+	return nil, fmt.Errorf("field %s: %s %q does not denote a field of %v", f.Name, mergeKeyTag, name, elem)
+}
+
+// mergeKey returns the merge key of the elements of lists of type typ, preferring the merge
+// key declared by the field holding the list, if any.
+func (t *traversal) mergeKey(typ reflect.Type, fieldKey []int) []int {
+	if fieldKey != nil || t.mergeKeys == nil {
+		return fieldKey
+	}
+	elem := typ.Elem()
+	if elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	return t.mergeKeys[elem]
+}
+
+// listKey returns the merge key of the list element v, which is invalid if v is a nil pointer.
+func listKey(v reflect.Value, key []int) reflect.Value {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v.FieldByIndex(key)
+}
+
+// compareKeyedLists compares the lists l1 and l2 of equal length by the sequences of the keys
+// of their elements first and by their elements second. If keyed lists are sorted, the
+// elements are compared in sorted order.
+func (t *traversal) compareKeyedLists(l1, l2 reflect.Value, key []int, depth int) (int, error) {
+	order1, err := t.keyedOrder(l1, key, depth)
+	if err != nil {
+		return 0, err
+	}
+	order2, err := t.keyedOrder(l2, key, depth)
+	if err != nil {
+		return 0, err
+	}
+	for i := range order1 {
+		i1, i2 := order1[i], order2[i]
+		res, err := t.deepValueCompare(listKey(l1.Index(i1), key), listKey(l2.Index(i2), key), depth+1)
+		if err != nil {
+			return 0, prependStep(err, indexStep(i1))
+		}
+		if res != 0 {
+			t.stepIndex(i1)
+			return res, nil
+		}
+	}
+	for i := range order1 {
+		i1, i2 := order1[i], order2[i]
+		res, err := t.deepValueCompareAt(indexElem(i1), l1.Index(i1), l2.Index(i2), depth+1)
+		if err != nil {
+			return 0, prependStep(err, indexStep(i1))
+		}
+		if res != 0 {
+			t.stepIndex(i1)
+			return res, nil
+		}
+	}
+	return 0, nil
+}
+
+// keyedOrder returns the indices of the elements of the keyed list l in the order to compare
+// them: sorted by their keys first and by themselves second if keyed lists are sorted, in
+// list order otherwise.
+func (t *traversal) keyedOrder(l reflect.Value, key []int, depth int) ([]int, error) {
+	order := make([]int, l.Len())
+	for i := range order {
+		order[i] = i
+	}
+	if !t.sortKeyedLists {
+		return order, nil
+	}
+	// Sorting compares elements beyond their first difference, see sortValues.
+	seen := t.seen
+	t.seen = nil
+	defer func() { t.seen = seen }()
+	p := t.provenance
+	t.provenance = nil
+	defer func() { t.provenance = p }()
+	var err error
+	sort.SliceStable(order, func(i, j int) bool {
+		if err != nil {
+			return false
+		}
+		e1, e2 := l.Index(order[i]), l.Index(order[j])
+		var res int
+		res, err = t.deepValueCompare(listKey(e1, key), listKey(e2, key), depth+1)
+		if err == nil && res == 0 {
+			res, err = t.deepValueCompare(e1, e2, depth+1)
+		}
+		return res < 0
+	})
+	return order, err
+}
+
+// keyedElem is the step to the element with index i of a keyed list, identified by its key k.
+func keyedElem(k reflect.Value, i int) pathStep {
+	return pathStep{key: k, index: i}
+}
+
+// diffKeyedLists diffs the elements of the lists l1 and l2 matched by their keys. Elements
+// present in both lists are diffed in the order of l1, followed by the elements only present
+// in l2 in their order and by the elements only present in l1 in reverse order.
+func (d *differ) diffKeyedLists(l1, l2 reflect.Value, key []int, depth int) error {
+	t := d.traversal
+	matched := make([]bool, l2.Len())
+	var removed []int
+	for i := 0; i < l1.Len(); i++ {
+		e1 := l1.Index(i)
+		k := listKey(e1, key)
+		j := -1
+		for c := 0; c < l2.Len() && j < 0; c++ {
+			if matched[c] {
+				continue
+			}
+			res, err := t.deepValueCompare(k, listKey(l2.Index(c), key), depth+1)
+			if err != nil {
+				return prependStep(err, d.path()+indexStep(c))
+			}
+			if res == 0 {
+				j = c
+			}
+		}
+		if j < 0 {
+			removed = append(removed, i)
+			continue
+		}
+		matched[j] = true
+		if err := d.diffAt(keyedElem(k, i), e1, l2.Index(j), depth+1); err != nil {
+			return err
+		}
+	}
+	// Elements only present in l2 are appended to l1.
+	n := l1.Len()
+	for j := 0; j < l2.Len(); j++ {
+		if !matched[j] {
+			e2 := l2.Index(j)
+			d.reportAt(keyedElem(listKey(e2, key), n), reflect.Value{}, e2, "key missing")
+			n++
+		}
+	}
+	for i := len(removed) - 1; i >= 0; i-- {
+		e1 := l1.Index(removed[i])
+		d.reportAt(keyedElem(listKey(e1, key), removed[i]), e1, reflect.Value{}, "key missing")
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	. "github.com/adracus/reflcompare"
+	"github.com/adracus/reflcompare/jsonpatch"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type Volume struct {
+	Name string `json:"name"`
+	Size int    `json:"size"`
+}
+
+type PodSpec struct {
+	Volumes []Volume `json:"volumes" patchMergeKey:"name"`
+	Mounts  []Volume `json:"mounts"`
+}
+
+var _ = Describe("Keyed lists", func() {
+	c := make(Comparisons)
+
+	Describe("ordering", func() {
+		It("should order keyed lists by their key sequences first", func() {
+			Expect(c.DeepCompare(
+				PodSpec{Volumes: []Volume{{"a", 2}, {"b", 1}}},
+				PodSpec{Volumes: []Volume{{"b", 1}, {"a", 1}}},
+			)).To(Equal(-1))
+			Expect(c.DeepCompare(
+				PodSpec{Mounts: []Volume{{"a", 2}, {"b", 1}}},
+				PodSpec{Mounts: []Volume{{"b", 1}, {"a", 1}}},
+			)).To(Equal(-1))
+			Expect(c.DeepCompare(
+				PodSpec{Volumes: []Volume{{"a", 2}}},
+				PodSpec{Volumes: []Volume{{"a", 1}}},
+			)).To(Equal(1))
+		})
+
+		It("should use merge keys declared via MergeKey", func() {
+			cmp := c.NewComparer(MergeKey(Volume{}, "Size"))
+			Expect(cmp.Compare([]Volume{{"a", 1}, {"b", 2}}, []Volume{{"b", 1}, {"a", 2}})).To(Equal(-1))
+			Expect(cmp.Compare([]*Volume{{"b", 1}, nil}, []*Volume{{"a", 1}, {"a", 2}})).To(Equal(-1))
+		})
+
+		It("should compare keyed lists in key order with SortKeyedLists", func() {
+			cmp := c.NewComparer(SortKeyedLists())
+			Expect(cmp.Compare(
+				PodSpec{Volumes: []Volume{{"a", 1}, {"b", 2}}},
+				PodSpec{Volumes: []Volume{{"b", 2}, {"a", 1}}},
+			)).To(Equal(0))
+			Expect(cmp.Compare(
+				PodSpec{Volumes: []Volume{{"b", 2}, {"a", 1}}},
+				PodSpec{Volumes: []Volume{{"c", 0}, {"a", 1}}},
+			)).To(Equal(-1))
+			Expect(cmp.Compare(
+				PodSpec{Volumes: []Volume{{"a", 2}, {"a", 1}}},
+				PodSpec{Volumes: []Volume{{"a", 1}, {"a", 2}}},
+			)).To(Equal(0))
+			Expect(cmp.Compare(
+				PodSpec{Mounts: []Volume{{"a", 1}, {"b", 2}}},
+				PodSpec{Mounts: []Volume{{"b", 2}, {"a", 1}}},
+			)).To(Equal(-1))
+		})
+
+		It("should error on invalid tags", func() {
+			type Invalid struct {
+				Volumes []Volume `patchMergeKey:"id"`
+			}
+			_, err := c.TryDeepCompare(Invalid{}, Invalid{})
+			Expect(err).To(MatchError(ContainSubstring(`field Volumes: patchMergeKey "id" does not denote a field of`)))
+		})
+
+		It("should panic on invalid merge keys", func() {
+			Expect(func() { MergeKey(Volume{}, "ID") }).To(Panic())
+			Expect(func() { MergeKey(1, "ID") }).To(Panic())
+		})
+	})
+
+	Describe("diffing", func() {
+		a := PodSpec{Volumes: []Volume{{"a", 1}, {"b", 2}, {"c", 3}}}
+		b := PodSpec{Volumes: []Volume{{"d", 4}, {"c", 3}, {"b", 5}}}
+
+		It("should match elements by key", func() {
+			diffs, err := c.Diff(a, b)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diffs).To(Equal([]Difference{
+				{Path: `.Volumes["b"].Size`, A: 2, B: 5, Result: -1, Comparator: "int"},
+				{Path: `.Volumes["d"]`, B: Volume{"d", 4}, Result: -1, Comparator: "key missing"},
+				{Path: `.Volumes["a"]`, A: Volume{"a", 1}, Result: 1, Comparator: "key missing"},
+			}))
+		})
+
+		It("should not report moved elements", func() {
+			diffs, err := c.Diff(a, PodSpec{Volumes: []Volume{{"c", 3}, {"a", 1}, {"b", 2}}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diffs).To(BeEmpty())
+		})
+
+		It("should patch elements by index", func() {
+			ops, err := c.JSONPatch(a, b)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ops).To(Equal([]jsonpatch.Op{
+				{Op: jsonpatch.Replace, Path: "/volumes/1/size", Value: 5},
+				{Op: jsonpatch.Add, Path: "/volumes/3", Value: Volume{"d", 4}},
+				{Op: jsonpatch.Remove, Path: "/volumes/0"},
+			}))
+		})
+	})
+})
@@ -0,0 +1,139 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import "strings"
+
+// languageAliases are the deprecated ISO 639 language codes by their preferred codes.
+var languageAliases = map[string]string{
+	"in": "id",
+	"iw": "he",
+	"ji": "yi",
+	"jw": "jv",
+	"mo": "ro",
+}
+
+// grandfatheredLanguageTags are the grandfathered and redundant BCP 47 language tags
+// by their preferred tags, in lowercase.
+var grandfatheredLanguageTags = map[string]string{
+	"art-lojban": "jbo",
+	"i-hak":      "hak",
+	"i-klingon":  "tlh",
+	"i-lux":      "lb",
+	"i-navajo":   "nv",
+	"i-pwn":      "pwn",
+	"i-tao":      "tao",
+	"i-tay":      "tay",
+	"i-tsu":      "tsu",
+	"no-bok":     "nb",
+	"no-nyn":     "nn",
+	"sgn-be-fr":  "sfb",
+	"sgn-be-nl":  "vgt",
+	"sgn-ch-de":  "sgg",
+	"zh-guoyu":   "cmn",
+	"zh-hakka":   "hak",
+	"zh-xiang":   "hsn",
+}
+
+// regionAliases are the deprecated ISO 3166 country codes by their preferred codes.
+var regionAliases = map[string]string{
+	"BU": "MM",
+	"DD": "DE",
+	"FX": "FR",
+	"TP": "TL",
+	"YD": "YE",
+	"ZR": "CD",
+}
+
+// countryAliases are the reserved ISO 3166 country codes in common use by their preferred
+// codes, in addition to regionAliases.
+var countryAliases = map[string]string{
+	"EL": "GR",
+	"UK": "GB",
+}
+
+// canonicalLanguageTag returns the BCP 47 language tag s in canonical form, e.g. "zh-Hant-TW"
+// for "ZH_hant_tw" and "he" for "iw". Subtags are separated by hyphens and cased as recommended,
+// deprecated language and region subtags and grandfathered tags are replaced.
+func canonicalLanguageTag(s string) string {
+	s = strings.ToLower(strings.Replace(s, "_", "-", -1))
+	if tag, ok := grandfatheredLanguageTags[s]; ok {
+		return tag
+	}
+	subtags := strings.Split(s, "-")
+	if alias, ok := languageAliases[subtags[0]]; ok {
+		subtags[0] = alias
+	}
+	for i := 1; i < len(subtags); i++ {
+		sub := subtags[i]
+		if len(sub) == 1 {
+			// Extensions and private use subtags are lowercase.
+			break
+		}
+		switch {
+		case len(sub) == 4 && isLetters(sub):
+			subtags[i] = strings.ToUpper(sub[:1]) + sub[1:]
+		case len(sub) == 2:
+			sub = strings.ToUpper(sub)
+			if alias, ok := regionAliases[sub]; ok {
+				sub = alias
+			}
+			subtags[i] = sub
+		}
+	}
+	return strings.Join(subtags, "-")
+}
+
+func isLetters(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 'a' || s[i] > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// CompareLanguageTags compares the BCP 47 language tags s1 and s2 (e.g. "en-US") by their
+// canonical forms, so tags differing by case or separator (e.g. "en_us") or by deprecated
+// aliases (e.g. "iw" for "he") are equal. Canonical forms are ordered lexically.
+//
+// CompareLanguageTags is predefined as the comparison function named language, so fields
+// tagged with `compare:"language"` are compared using it, see TagComparison.
+func CompareLanguageTags(s1, s2 string) int {
+	return strings.Compare(canonicalLanguageTag(s1), canonicalLanguageTag(s2))
+}
+
+// canonicalCountryCode returns the ISO 3166-1 alpha-2 country code s in canonical form, i.e.
+// in uppercase with deprecated and reserved aliases replaced, e.g. "GB" for "uk".
+func canonicalCountryCode(s string) string {
+	s = strings.ToUpper(s)
+	if alias, ok := regionAliases[s]; ok {
+		return alias
+	}
+	if alias, ok := countryAliases[s]; ok {
+		return alias
+	}
+	return s
+}
+
+// CompareCountryCodes compares the ISO 3166-1 alpha-2 country codes s1 and s2 by their
+// canonical forms, so codes differing by case (e.g. "de" and "DE") or by aliases (e.g. "UK"
+// for "GB") are equal. Canonical forms are ordered lexically.
+//
+// CompareCountryCodes is predefined as the comparison function named country, so fields
+// tagged with `compare:"country"` are compared using it, see TagComparison.
+func CompareCountryCodes(s1, s2 string) int {
+	return strings.Compare(canonicalCountryCode(s1), canonicalCountryCode(s2))
+}
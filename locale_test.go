@@ -0,0 +1,66 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+type Locale struct {
+	Language string `compare:"language"`
+	Country  string `compare:"country"`
+}
+
+var _ = Describe("Locales", func() {
+	DescribeTable("CompareLanguageTags",
+		func(s1, s2 string, expected int) {
+			Expect(CompareLanguageTags(s1, s2)).To(Equal(expected))
+			Expect(CompareLanguageTags(s2, s1)).To(Equal(-expected))
+		},
+		Entry("equal tags", "en-US", "en-US", 0),
+		Entry("case", "EN-us", "en-US", 0),
+		Entry("scripts", "zh-hant-tw", "zh-Hant-TW", 0),
+		Entry("underscores", "pt_BR", "pt-BR", 0),
+		Entry("deprecated languages", "iw-IL", "he-IL", 0),
+		Entry("deprecated regions", "my-BU", "my-MM", 0),
+		Entry("grandfathered tags", "i-klingon", "tlh", 0),
+		Entry("numeric regions", "es-419", "es-419", 0),
+		Entry("extensions", "en-US-u-CA-Gregory", "en-US-u-ca-gregory", 0),
+		Entry("variants", "de-CH-1901", "de-ch-1901", 0),
+		Entry("different languages", "de", "en", -1),
+		Entry("language before region", "en", "en-GB", -1),
+	)
+
+	DescribeTable("CompareCountryCodes",
+		func(s1, s2 string, expected int) {
+			Expect(CompareCountryCodes(s1, s2)).To(Equal(expected))
+			Expect(CompareCountryCodes(s2, s1)).To(Equal(-expected))
+		},
+		Entry("case", "de", "DE", 0),
+		Entry("reserved codes", "uk", "GB", 0),
+		Entry("deprecated codes", "ZR", "CD", 0),
+		Entry("different codes", "DE", "FR", -1),
+	)
+
+	It("should be predefined for tags", func() {
+		c := make(Comparisons)
+		Expect(c.DeepCompare(Locale{Language: "en_gb", Country: "uk"}, Locale{Language: "en-GB", Country: "GB"})).To(Equal(0))
+		Expect(c.DeepCompare(Locale{Language: "EN", Country: "us"}, Locale{Language: "en", Country: "GB"})).To(Equal(1))
+		Expect(c.ScanTypes(Locale{})).To(Succeed())
+	})
+})
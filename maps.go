@@ -0,0 +1,123 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// sortedKeys returns the keys of the map m, sorted by the traversal.
+// If any keys cannot be compared, it returns the first error encountered.
+func (t *traversal) sortedKeys(m reflect.Value) ([]reflect.Value, error) {
+	t.stats.MapKeySorts++
+	keys := m.MapKeys()
+	if err := t.sortValues(keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// sortValues sorts the values of the same type in place.
+// If any values cannot be compared, it returns the first error encountered.
+func (t *traversal) sortValues(keys []reflect.Value) error {
+	// Sorting compares values beyond their first difference, so they cannot be assumed equal
+	// when encountered again. Use the visited comparisons instead.
+	seen := t.seen
+	t.seen = nil
+	defer func() { t.seen = seen }()
+	var err error
+	sort.Slice(keys, func(i, j int) bool {
+		if err != nil {
+			return false
+		}
+		var res int
+		res, err = t.deepValueCompare(keys[i], keys[j], 0)
+		if err == nil {
+			res, err = totalOrder(res, keys[i].Type())
+		}
+		return res < 0
+	})
+	return err
+}
+
+// SortedMapRange calls fn for each key and value of the map m in the order of its
+// keys according to c. If fn returns false, SortedMapRange stops.
+//
+// SortedMapRange panics if m is not a map.
+func (c Comparisons) SortedMapRange(m interface{}, fn func(k, v interface{}) bool) {
+	mv := reflect.ValueOf(m)
+	if mv.Kind() != reflect.Map {
+		panic(fmt.Sprintf("expected map, got: %T", m))
+	}
+	keys, err := c.newTraversal().sortedKeys(mv)
+	if err != nil {
+		panic(err)
+	}
+	for _, k := range keys {
+		if !fn(k.Interface(), mv.MapIndex(k).Interface()) {
+			return
+		}
+	}
+}
+
+// compareMapsStrict compares the maps m1 and m2 by their lengths first, by their
+// sorted keys second and by their values in key order third.
+func (t *traversal) compareMapsStrict(m1, m2 reflect.Value, depth int) (int, error) {
+	if res := compareInt64(int64(m1.Len()), int64(m2.Len())); res != 0 {
+		return res, nil
+	}
+	// Keys only decide as a whole, so don't record which parts of them differ.
+	p := t.provenance
+	t.provenance = nil
+	defer func() { t.provenance = p }()
+	keys1, err := t.sortedKeys(m1)
+	if err != nil {
+		return 0, err
+	}
+	keys2, err := t.sortedKeys(m2)
+	if err != nil {
+		return 0, err
+	}
+	for i := range keys1 {
+		// The map holding the smallest key not present in the other map is less.
+		res, err := t.deepValueCompare(keys1[i], keys2[i], depth+1)
+		if err != nil {
+			return 0, err
+		}
+		if res != 0 {
+			t.provenance = p
+			if res < 0 {
+				t.missKey(keys1[i])
+			} else {
+				t.missKey(keys2[i])
+			}
+			return res, nil
+		}
+	}
+	t.provenance = p
+	for _, k := range keys1 {
+		res, err := t.deepValueCompareAt(keyElem(k), m1.MapIndex(k), m2.MapIndex(k), depth+1)
+		if err != nil {
+			return 0, prependStep(err, t.keyStep(k))
+		}
+		if res != 0 {
+			t.stepKey(k)
+			return res, nil
+		}
+	}
+	return 0, nil
+}
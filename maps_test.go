@@ -0,0 +1,124 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Maps", func() {
+	Describe("SortedMapRange", func() {
+		m := map[Severity]int{Low: 1, Critical: 3, High: 2}
+
+		collect := func(c Comparisons, m interface{}) []interface{} {
+			var res []interface{}
+			c.SortedMapRange(m, func(k, v interface{}) bool {
+				res = append(res, k, v)
+				return true
+			})
+			return res
+		}
+
+		It("should iterate in key order", func() {
+			Expect(collect(make(Comparisons), m)).To(Equal([]interface{}{Critical, 3, High, 2, Low, 1}))
+		})
+
+		It("should respect comparison functions for keys", func() {
+			c := make(Comparisons)
+			Expect(c.AddOrder(Low, High, Critical)).To(Succeed())
+			Expect(collect(c, m)).To(Equal([]interface{}{Low, 1, High, 2, Critical, 3}))
+		})
+
+		It("should stop if fn returns false", func() {
+			var n int
+			make(Comparisons).SortedMapRange(m, func(_, _ interface{}) bool {
+				n++
+				return false
+			})
+			Expect(n).To(Equal(1))
+		})
+
+		It("should not call fn for nil maps", func() {
+			Expect(collect(make(Comparisons), map[int]int(nil))).To(BeEmpty())
+		})
+
+		It("should panic if m is no map", func() {
+			Expect(func() { collect(make(Comparisons), []int{}) }).To(Panic())
+		})
+	})
+
+	Describe("MissingAsZero", func() {
+		It("should compare missing keys as zero values", func() {
+			cmp := make(Comparisons).NewComparer(MissingAsZero())
+			Expect(cmp.Compare(map[string]int{"a": 1}, map[string]int{"b": 0})).To(Equal(1))
+			Expect(cmp.Compare(map[string]int{"a": -1}, map[string]int{"b": 0})).To(Equal(-1))
+			Expect(cmp.Compare(map[string]int{"a": 0}, map[string]int{"b": 0})).To(Equal(0))
+		})
+
+		It("should order the map holding a missing key greater by default", func() {
+			cmp := make(Comparisons).NewComparer()
+			Expect(cmp.Compare(map[string]int{"a": -1}, map[string]int{"b": 0})).To(Equal(1))
+		})
+
+		It("should be overridden by StrictMaps", func() {
+			cmp := make(Comparisons).NewComparer(MissingAsZero(), StrictMaps())
+			Expect(cmp.Compare(map[string]int{"a": 0}, map[string]int{"b": 0})).To(Equal(-1))
+			Expect(cmp.Compare(map[string]int{"a": 1}, map[string]int{"b": 0})).To(Equal(-1))
+
+			diffs, err := make(Comparisons).Diff(map[string]int{"a": 0}, map[string]int{"b": 0}, MissingAsZero(), StrictMaps())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diffs).To(HaveLen(2))
+			Expect(diffs[0].Comparator).To(Equal("key missing"))
+			Expect(diffs[1].Comparator).To(Equal("key missing"))
+		})
+	})
+
+	Describe("StrictMaps", func() {
+		cmp := make(Comparisons).NewComparer(StrictMaps())
+
+		It("should order the map holding the smallest non-shared key first", func() {
+			m1 := map[string]int{"a": 1, "b": 9}
+			m2 := map[string]int{"a": 1, "c": 0}
+			for i := 0; i < 10; i++ {
+				Expect(cmp.Compare(m1, m2)).To(Equal(-1))
+				Expect(cmp.Compare(m2, m1)).To(Equal(1))
+			}
+		})
+
+		It("should compare values in key order", func() {
+			m1 := map[string]int{"a": 1, "b": 3}
+			m2 := map[string]int{"a": 2, "b": 1}
+			for i := 0; i < 10; i++ {
+				Expect(cmp.Compare(m1, m2)).To(Equal(-1))
+			}
+			Expect(cmp.Compare(m1, map[string]int{"b": 3, "a": 1})).To(Equal(0))
+		})
+
+		It("should order by length first", func() {
+			Expect(cmp.Compare(map[string]int{}, map[string]int{"a": 1})).To(Equal(-1))
+			Expect(cmp.Compare(map[string]int(nil), map[string]int{})).To(Equal(0))
+			Expect(cmp.Compare(map[string]int{"z": 1}, map[string]int{"a": 1, "b": 1})).To(Equal(-1))
+		})
+
+		It("should respect comparison functions for keys", func() {
+			c := make(Comparisons)
+			Expect(c.AddOrder(Low, High, Critical)).To(Succeed())
+			cmp := c.NewComparer(StrictMaps())
+			Expect(cmp.Compare(map[Severity]int{Critical: 1}, map[Severity]int{High: 1})).To(Equal(1))
+		})
+	})
+})
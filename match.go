@@ -0,0 +1,209 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Wildcard is a placeholder in patterns that matches values by their presence only,
+// see Comparisons.Matches.
+type Wildcard int
+
+const (
+	// Any matches any value, including nil values. It does not match missing map entries.
+	Any Wildcard = iota + 1
+	// Absent matches missing map entries and nil or empty values (see DeepCompare).
+	Absent
+)
+
+// Fields is a pattern for a struct (or a pointer to a struct), by field name.
+// Fields not listed have to be equal to their zero value according to DeepCompare,
+// so e.g. empty slices and maps match as well.
+type Fields map[string]interface{}
+
+var (
+	wildcardType = reflect.TypeOf(Any)
+	fieldsType   = reflect.TypeOf(Fields(nil))
+)
+
+// Matches reports whether value matches pattern.
+//
+// A pattern is a value of the same type as value where every position may be replaced by
+// a Wildcard if the pattern is dynamically typed there (e.g. in an []interface{}). A struct
+// may also be replaced by Fields, which allows wildcards for fields of any type:
+//
+//	c.Matches(pod, Fields{"Name": "foo", "UID": Any, "CreationTimestamp": Any})
+//
+// Without wildcards, values match if DeepCompare, configured by the given options, considers
+// them equal. Struct fields not compared by DeepCompare, e.g. fields tagged `compare:"ignore"`,
+// are not matched either.
+// Patterns may also be of a type convertible to the type of the value at their position
+// if they are of the same kind, so e.g. untyped constants in Fields match named types.
+//
+// Matches panics if Fields refer to fields not present in the matched struct.
+func (c Comparisons) Matches(value, pattern interface{}, opts ...Option) bool {
+	ok, err := c.newTraversal(opts...).match(reflect.ValueOf(value), reflect.ValueOf(pattern))
+	if err != nil {
+		panic(err)
+	}
+	return ok
+}
+
+// match matches v against the pattern p. An invalid v denotes a missing value.
+func (t *traversal) match(v, p reflect.Value) (bool, error) {
+	field := t.field
+	t.field = structField{}
+	if p.IsValid() && p.Kind() == reflect.Interface {
+		p = p.Elem()
+	}
+	if !p.IsValid() {
+		// An untyped nil pattern.
+		return !v.IsValid() || isNilOrEmpty(v), nil
+	}
+	if p.Type() == wildcardType {
+		switch Wildcard(p.Int()) {
+		case Any:
+			return v.IsValid(), nil
+		case Absent:
+			return !v.IsValid() || isNilOrEmpty(v), nil
+		}
+		return false, fmt.Errorf("invalid wildcard %d", p.Int())
+	}
+	if !v.IsValid() {
+		return false, nil
+	}
+	if v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return isNilOrEmpty(p), nil
+		}
+		v = v.Elem()
+	}
+
+	if p.Type() == fieldsType {
+		return t.matchFields(v, p.Interface().(Fields))
+	}
+	if p.Type() != v.Type() {
+		switch {
+		case p.Kind() != v.Kind():
+			return false, nil
+		case p.Kind() == reflect.Map && p.Type().Key() == v.Type().Key(),
+			p.Kind() == reflect.Slice, p.Kind() == reflect.Array && p.Len() == v.Len(),
+			p.Kind() == reflect.Ptr:
+			// Match elements, which may be wildcards.
+		case p.Type().ConvertibleTo(v.Type()):
+			p = p.Convert(v.Type())
+		default:
+			return false, nil
+		}
+	}
+	if p.Type() == v.Type() && (field.compare.IsValid() || !t.plain(v.Type())) {
+		t.field = field
+		return t.equal(v, p)
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if p.IsNil() || v.IsNil() {
+			return p.IsNil() == v.IsNil(), nil
+		}
+		return t.match(v.Elem(), p.Elem())
+	case reflect.Slice, reflect.Array:
+		if v.Len() != p.Len() {
+			return false, nil
+		}
+		for i := 0; i < v.Len(); i++ {
+			if ok, err := t.match(v.Index(i), p.Index(i)); !ok || err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+	case reflect.Map:
+		for iter := p.MapRange(); iter.Next(); {
+			if ok, err := t.match(v.MapIndex(iter.Key()), iter.Value()); !ok || err != nil {
+				return false, err
+			}
+		}
+		for iter := v.MapRange(); iter.Next(); {
+			if !p.MapIndex(iter.Key()).IsValid() {
+				return false, nil
+			}
+		}
+		return true, nil
+	case reflect.Struct:
+		fields, err := t.structFields(v.Type())
+		if err != nil {
+			return false, err
+		}
+		for _, f := range fields {
+			t.field = f
+			if ok, err := t.match(v.Field(f.index), p.Field(f.index)); !ok || err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+	default:
+		t.field = field
+		return t.equal(v, p)
+	}
+}
+
+// matchFields matches the struct v (or pointer to a struct) against the given fields.
+func (t *traversal) matchFields(v reflect.Value, fields Fields) (bool, error) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return false, nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return false, fmt.Errorf("cannot match %v against fields", v.Type())
+	}
+	for name := range fields {
+		if f, ok := v.Type().FieldByName(name); !ok || len(f.Index) != 1 {
+			return false, fmt.Errorf("type %v has no field %s", v.Type(), name)
+		}
+	}
+	structFields, err := t.structFields(v.Type())
+	if err != nil {
+		return false, err
+	}
+	for _, sf := range structFields {
+		f := v.Field(sf.index)
+		p, ok := fields[sf.name]
+		if !ok {
+			if f.IsZero() {
+				continue
+			}
+			t.field = sf
+			if ok, err := t.equal(f, reflect.Zero(f.Type())); !ok || err != nil {
+				return false, err
+			}
+			continue
+		}
+		t.field = sf
+		if ok, err := t.match(f, reflect.ValueOf(p)); !ok || err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// equal reports whether v1 and v2 are equal according to the traversal.
+func (t *traversal) equal(v1, v2 reflect.Value) (bool, error) {
+	res, err := t.deepValueCompare(v1, v2, 0)
+	return res == 0, err
+}
@@ -0,0 +1,98 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"strings"
+	"time"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+type Event struct {
+	ID      string
+	Reason  string
+	Created time.Time
+	Object  *Object
+}
+
+var _ = Describe("Matches", func() {
+	c := NewComparisonsOrDie(CompareTime)
+	event := Event{
+		ID:      "123e4567-e89b-12d3-a456-426614174000",
+		Reason:  "Scheduled",
+		Created: time.Now(),
+		Object:  &Object{Name: "foo", Labels: map[string]string{"app": "web"}},
+	}
+
+	DescribeTable("matching",
+		func(value, pattern interface{}, expect bool) {
+			Expect(c.Matches(value, pattern)).To(Equal(expect))
+		},
+		Entry("equal values", 1, 1, true),
+		Entry("different values", 1, 2, false),
+		Entry("any", 1, Any, true),
+		Entry("any nil", (*int)(nil), Any, true),
+		Entry("absent nil", (*int)(nil), Absent, true),
+		Entry("absent value", 1, Absent, false),
+		Entry("untyped nil pattern", []int{}, nil, true),
+		Entry("wildcards in slices", []interface{}{1, "a"}, []interface{}{Any, "a"}, true),
+		Entry("typed slice with wildcards", []string{"a", "b"}, []interface{}{"a", Any}, true),
+		Entry("length mismatch", []string{"a"}, []interface{}{"a", Any}, false),
+		Entry("map with wildcards", map[string]int{"a": 1, "b": 2}, map[string]interface{}{"a": 1, "b": Any}, true),
+		Entry("map missing entry", map[string]int{"a": 1}, map[string]interface{}{"a": 1, "b": Any}, false),
+		Entry("map absent entry", map[string]int{"a": 1}, map[string]interface{}{"a": 1, "b": Absent}, true),
+		Entry("map extra entry", map[string]int{"a": 1, "b": 2}, map[string]interface{}{"a": 1}, false),
+		Entry("fields with wildcards", event, Fields{
+			"ID":      Any,
+			"Reason":  "Scheduled",
+			"Created": Any,
+			"Object":  Fields{"Name": "foo", "Labels": map[string]interface{}{"app": Any}},
+		}, true),
+		Entry("fields with mismatch", event, Fields{"ID": Any, "Reason": "Pulled", "Created": Any, "Object": Any}, false),
+		Entry("fields not listed are zero", event, Fields{"ID": Any, "Reason": "Scheduled", "Created": Any}, false),
+		Entry("fields not listed are empty", Object{Name: "foo", Labels: map[string]string{}}, Fields{"Name": "foo"}, true),
+		Entry("fields of nil pointer", Event{}, Fields{"Object": Fields{}}, false),
+		Entry("fields of nil pointer absent", Event{}, Fields{"Object": Absent}, true),
+		Entry("convertible constants", High, "high", true),
+	)
+
+	It("should use comparison functions", func() {
+		c := NewComparisonsOrDie(func(s1, s2 string) int { return strings.Compare(strings.ToLower(s1), strings.ToLower(s2)) })
+		Expect(c.Matches(Event{Reason: "scheduled"}, Fields{"Reason": "Scheduled"})).To(BeTrue())
+	})
+
+	It("should not match ignored fields", func() {
+		type Lease struct {
+			Holder  string
+			Renewed int `compare:"ignore"`
+		}
+		Expect(c.Matches(Lease{Holder: "a", Renewed: 1}, Lease{Holder: "a", Renewed: 2})).To(BeTrue())
+		Expect(c.Matches(Lease{Holder: "a", Renewed: 1}, Fields{"Holder": "a"})).To(BeTrue())
+		Expect(c.Matches(Lease{Holder: "a"}, Lease{Holder: "b"})).To(BeFalse())
+	})
+
+	It("should apply options", func() {
+		Expect(c.Matches([]string{"1.0", "2"}, []interface{}{"1", Any})).To(BeFalse())
+		Expect(c.Matches([]string{"1.0", "2"}, []interface{}{"1", Any}, NumericStrings())).To(BeTrue())
+	})
+
+	It("should panic on unknown fields", func() {
+		Expect(func() { c.Matches(event, Fields{"Unknown": Any}) }).To(Panic())
+	})
+})
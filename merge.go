@@ -0,0 +1,114 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// ErrAmbiguousComparator is the error AmbiguousComparatorError matches via errors.Is.
+var ErrAmbiguousComparator = errors.New("ambiguous comparator")
+
+// AmbiguousComparatorError is returned if different comparison functions would apply to the same type.
+type AmbiguousComparatorError struct {
+	// Type is the type the comparison functions apply to.
+	Type reflect.Type
+	// Candidates are the conflicting comparison functions.
+	Candidates []interface{}
+}
+
+// Error implements error.
+func (e *AmbiguousComparatorError) Error() string {
+	names := make([]string, len(e.Candidates))
+	for i, candidate := range e.Candidates {
+		names[i] = funcName(reflect.ValueOf(candidate))
+	}
+	return fmt.Sprintf("%v for type %v: candidates %s", ErrAmbiguousComparator, e.Type, strings.Join(names, ", "))
+}
+
+// Is reports whether target is ErrAmbiguousComparator.
+func (e *AmbiguousComparatorError) Is(target error) bool {
+	return target == ErrAmbiguousComparator
+}
+
+func funcName(fv reflect.Value) string {
+	if fv.Type() == traversalFuncType {
+		return fv.Interface().(*traversalFunc).name
+	}
+	if f := runtime.FuncForPC(fv.Pointer()); f != nil {
+		return f.Name()
+	}
+	return fv.Type().String()
+}
+
+// Merge adds the comparison functions of others to c. To merge LayeredComparisons, merge
+// their resolved comparison functions, see LayeredComparisons.Copy.
+//
+// If different comparison functions would apply to the same type, including the ones already
+// in c, no function is added and an *AmbiguousComparatorError is returned.
+//
+// Only the very same function values are considered identical: distinct closures of the same
+// function literal and functions created by reflect.MakeFunc are different candidates, even if
+// they share their code.
+func (c Comparisons) Merge(others ...Comparisons) error {
+	merged := make(Comparisons)
+	candidates := make(map[reflect.Type][]reflect.Value)
+	add := func(t reflect.Type, fv reflect.Value) {
+		for _, candidate := range candidates[t] {
+			// Comparing the Values compares the function values themselves, whereas
+			// Value.Pointer only yields their (possibly shared) code pointers.
+			if candidate == fv {
+				return
+			}
+		}
+		candidates[t] = append(candidates[t], fv)
+	}
+
+	for _, other := range others {
+		for t, fv := range other {
+			if existing, ok := c[t]; ok && len(candidates[t]) == 0 {
+				add(t, existing)
+			}
+			add(t, fv)
+			merged[t] = fv
+		}
+	}
+
+	var conflicts []reflect.Type
+	for t, fvs := range candidates {
+		if len(fvs) > 1 {
+			conflicts = append(conflicts, t)
+		}
+	}
+	if len(conflicts) > 0 {
+		sort.Slice(conflicts, func(i, j int) bool { return conflicts[i].String() < conflicts[j].String() })
+		t := conflicts[0]
+		err := &AmbiguousComparatorError{Type: t}
+		for _, fv := range candidates[t] {
+			err.Candidates = append(err.Candidates, fv.Interface())
+		}
+		return err
+	}
+
+	for t, fv := range merged {
+		c[t] = fv
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func reverseInts(a, b int) int { return b - a }
+
+func reverseStrings(a, b string) int { return strings.Compare(b, a) }
+
+func ignoreInts(a, b int) int { return 0 }
+
+var _ = Describe("Merge", func() {
+	It("should add the comparison functions of all others", func() {
+		c := make(Comparisons)
+		Expect(c.Merge(NewComparisonsOrDie(reverseInts), NewComparisonsOrDie(reverseStrings))).To(Succeed())
+		Expect(c.DeepCompare(1, 2)).To(Equal(1))
+		Expect(c.DeepCompare("a", "b")).To(Equal(1))
+	})
+
+	It("should add the resolved comparison functions of LayeredComparisons", func() {
+		c := make(Comparisons)
+		Expect(c.Merge(NewComparisonsWithParent(NewComparisonsOrDie(reverseInts)).Copy())).To(Succeed())
+		Expect(c.DeepCompare(1, 2)).To(Equal(1))
+	})
+
+	It("should accept identical comparison functions", func() {
+		c := NewComparisonsOrDie(reverseInts)
+		Expect(c.Merge(NewComparisonsOrDie(reverseInts), NewComparisonsOrDie(reverseInts))).To(Succeed())
+	})
+
+	It("should report ambiguous comparison functions among others", func() {
+		c := make(Comparisons)
+		err := c.Merge(NewComparisonsOrDie(reverseInts, reverseStrings), NewComparisonsOrDie(ignoreInts))
+		Expect(errors.Is(err, ErrAmbiguousComparator)).To(BeTrue())
+
+		var ambiguous *AmbiguousComparatorError
+		Expect(errors.As(err, &ambiguous)).To(BeTrue())
+		Expect(ambiguous.Type).To(Equal(reflectTypeOfInt))
+		Expect(ambiguous.Candidates).To(HaveLen(2))
+		Expect(err.Error()).To(And(ContainSubstring("reverseInts"), ContainSubstring("ignoreInts")))
+		Expect(c).To(BeEmpty())
+	})
+
+	It("should report distinct closures of the same function literal", func() {
+		scaled := func(k int) func(a, b int) int {
+			return func(a, b int) int { return k * (a - b) }
+		}
+		c := make(Comparisons)
+		Expect(c.Merge(NewComparisonsOrDie(scaled(1)), NewComparisonsOrDie(scaled(-1)))).To(MatchError(ErrAmbiguousComparator))
+		Expect(c).To(BeEmpty())
+	})
+
+	It("should report distinct functions created by reflect.MakeFunc", func() {
+		makeFunc := func() interface{} {
+			return reflect.MakeFunc(reflect.TypeOf(reverseInts), func(args []reflect.Value) []reflect.Value {
+				return []reflect.Value{reflect.ValueOf(reverseInts(int(args[0].Int()), int(args[1].Int())))}
+			}).Interface()
+		}
+		c := make(Comparisons)
+		Expect(c.Merge(NewComparisonsOrDie(makeFunc()), NewComparisonsOrDie(makeFunc()))).To(MatchError(ErrAmbiguousComparator))
+	})
+
+	It("should accept the same comparison function reached via shared parents", func() {
+		base := NewComparisonsOrDie(func(a, b int) int { return b - a })
+		c := NewComparisonsWithParent(base).Copy()
+		Expect(c.Merge(NewComparisonsWithParent(base).Copy(), NewComparisonsWithParent(base).Copy())).To(Succeed())
+	})
+
+	It("should report comparison functions conflicting with existing ones", func() {
+		c := NewComparisonsOrDie(reverseInts)
+		Expect(c.Merge(NewComparisonsOrDie(ignoreInts))).To(MatchError(ErrAmbiguousComparator))
+	})
+})
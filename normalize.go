@@ -0,0 +1,133 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// AddNormalizer adds a normalizer for the type T, a func(*T) modifying a value to its normal
+// form, e.g. sorting a slice field, lowercasing a name or stripping default values. Values of
+// type T are then compared by normalizing deep copies of them first, so values that are
+// semantically equal compare equal without a comparison function for T. The compared values
+// themselves are not modified.
+//
+// The normalized copies are compared by the comparison function previously added for T, if any,
+// and by the default rules otherwise. Values of type T nested in a compared value are normalized
+// along with it; other nested values are compared using c.
+//
+// Like comparison functions, normalized values are compared with the options of the comparison.
+//
+// AddNormalizer returns an error if normalizer is not a func(*T).
+func (c Comparisons) AddNormalizer(normalizer interface{}) error {
+	fv := reflect.ValueOf(normalizer)
+	ft := reflect.TypeOf(normalizer)
+	if ft == nil || ft.Kind() != reflect.Func || ft.NumIn() != 1 || ft.NumOut() != 0 || ft.IsVariadic() || ft.In(0).Kind() != reflect.Ptr {
+		return fmt.Errorf("expected normalizer, got: %T", normalizer)
+	}
+	t := ft.In(0).Elem()
+	prev, hasPrev := c[t]
+	normalize := func(v reflect.Value) reflect.Value {
+		return deepCopy(v, make(map[uintptr]reflect.Value), t, func(v reflect.Value) {
+			fv.Call([]reflect.Value{v.Addr()})
+		})
+	}
+	c.addTraversalFunc(t, funcName(fv), func(tr *traversal, v1, v2 reflect.Value, depth int) (int, error) {
+		v1, v2 = normalize(v1), normalize(v2)
+		if hasPrev {
+			return tr.callCompare(prev, v1, v2, depth)
+		}
+		// Values of T nested in the copies are normalized already.
+		return tr.compareBypassing(t, v1, v2, depth)
+	})
+	return nil
+}
+
+// deepCopy copies v, copying the values pointers, slices and maps refer to recursively.
+// Unexported struct fields are copied shallowly. Copies of pointers are tracked in copies,
+// so cyclic values are copied as such. fn is called with each addressable copy of type t.
+func deepCopy(v reflect.Value, copies map[uintptr]reflect.Value, t reflect.Type, fn func(reflect.Value)) reflect.Value {
+	res := deepCopyValue(v, copies, t, fn)
+	if v.Type() != t {
+		return res
+	}
+	if !res.CanAddr() {
+		addressable := reflect.New(t).Elem()
+		addressable.Set(res)
+		res = addressable
+	}
+	fn(res)
+	return res
+}
+
+// deepCopyValue copies v like deepCopy, but without calling fn with the copy itself.
+func deepCopyValue(v reflect.Value, copies map[uintptr]reflect.Value, t reflect.Type, fn func(reflect.Value)) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		if res, ok := copies[v.Pointer()]; ok && res.Type() == v.Type() {
+			return res
+		}
+		res := reflect.New(v.Type().Elem())
+		copies[v.Pointer()] = res
+		res.Elem().Set(deepCopy(v.Elem(), copies, t, fn))
+		return res
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		res := reflect.New(v.Type()).Elem()
+		res.Set(deepCopy(v.Elem(), copies, t, fn))
+		return res
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		res := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			res.Index(i).Set(deepCopy(v.Index(i), copies, t, fn))
+		}
+		return res
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		res := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			res.SetMapIndex(deepCopy(iter.Key(), copies, t, fn), deepCopy(iter.Value(), copies, t, fn))
+		}
+		return res
+	case reflect.Array:
+		res := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			res.Index(i).Set(deepCopy(v.Index(i), copies, t, fn))
+		}
+		return res
+	case reflect.Struct:
+		res := reflect.New(v.Type()).Elem()
+		res.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath == "" {
+				res.Field(i).Set(deepCopy(v.Field(i), copies, t, fn))
+			}
+		}
+		return res
+	default:
+		return v
+	}
+}
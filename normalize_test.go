@@ -0,0 +1,112 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"sort"
+	"strings"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type Group struct {
+	Name     string
+	Members  []string
+	Replicas *int
+	Parent   *Group
+}
+
+func normalizeGroup(g *Group) {
+	g.Name = strings.ToLower(g.Name)
+	sort.Strings(g.Members)
+	if g.Replicas != nil && *g.Replicas == 1 {
+		// 1 is the default.
+		g.Replicas = nil
+	}
+}
+
+var _ = Describe("AddNormalizer", func() {
+	var c Comparisons
+	BeforeEach(func() {
+		c = make(Comparisons)
+		Expect(c.AddNormalizer(normalizeGroup)).To(Succeed())
+	})
+
+	one, two := 1, 2
+
+	It("should compare normalized values", func() {
+		Expect(c.DeepCompare(
+			Group{Name: "Admins", Members: []string{"b", "a"}, Replicas: &one},
+			Group{Name: "admins", Members: []string{"a", "b"}},
+		)).To(Equal(0))
+		Expect(c.DeepCompare(Group{Name: "a", Replicas: &two}, Group{Name: "A"})).To(Equal(1))
+		Expect(c.DeepCompare(Group{Name: "B"}, Group{Name: "a"})).To(Equal(1))
+	})
+
+	It("should normalize nested values", func() {
+		Expect(c.DeepCompare(
+			[]*Group{{Name: "X", Parent: &Group{Members: []string{"b", "a"}}}},
+			[]*Group{{Name: "x", Parent: &Group{Members: []string{"a", "b"}}}},
+		)).To(Equal(0))
+	})
+
+	It("should not modify the compared values", func() {
+		g := Group{Name: "Admins", Members: []string{"b", "a"}, Replicas: &one}
+		Expect(c.DeepCompare(g, g)).To(Equal(0))
+		Expect(g).To(Equal(Group{Name: "Admins", Members: []string{"b", "a"}, Replicas: &one}))
+	})
+
+	It("should compare normalized values by a previously added comparison function", func() {
+		c := NewComparisonsOrDie(func(g1, g2 Group) int { return strings.Compare(g1.Name, g2.Name) })
+		Expect(c.AddNormalizer(normalizeGroup)).To(Succeed())
+		Expect(c.DeepCompare(Group{Name: "A", Members: []string{"x"}}, Group{Name: "a"})).To(Equal(0))
+	})
+
+	It("should normalize values nested in normalized values", func() {
+		Expect(c.DeepCompare(
+			Group{Name: "x", Parent: &Group{Name: "Y", Replicas: &one}},
+			Group{Name: "X", Parent: &Group{Name: "y"}},
+		)).To(Equal(0))
+		Expect(c.DeepCompare(
+			Group{Name: "x", Parent: &Group{Name: "Y", Replicas: &two}},
+			Group{Name: "X", Parent: &Group{Name: "y"}},
+		)).To(Equal(1))
+	})
+
+	It("should normalize values of non-struct types", func() {
+		type Labels []string
+		type Name string
+		c := make(Comparisons)
+		Expect(c.AddNormalizer(func(l *Labels) { sort.Strings(*l) })).To(Succeed())
+		Expect(c.AddNormalizer(func(n *Name) { *n = Name(strings.ToLower(string(*n))) })).To(Succeed())
+		Expect(c.DeepCompare(Labels{"b", "a"}, Labels{"a", "b"})).To(Equal(0))
+		Expect(c.DeepCompare(Name("A"), Name("a"))).To(Equal(0))
+		Expect(c.DeepCompare(map[Name]Labels{"x": {"b", "a"}}, map[Name]Labels{"x": {"a", "b"}})).To(Equal(0))
+	})
+
+	It("should compare normalized values with the options of the comparison", func() {
+		Expect(c.DeepCompare(Group{Name: "10"}, Group{Name: "9"})).To(Equal(-1))
+		Expect(c.NewComparer(NumericStrings()).Compare(Group{Name: "10"}, Group{Name: "9"})).To(Equal(1))
+	})
+
+	It("should fail for functions that are no normalizers", func() {
+		Expect(c.AddNormalizer(func(Group) {})).NotTo(Succeed())
+		Expect(c.AddNormalizer(func(*Group) bool { return true })).NotTo(Succeed())
+		Expect(c.AddNormalizer(1)).NotTo(Succeed())
+		Expect(c.AddNormalizer(nil)).NotTo(Succeed())
+	})
+})
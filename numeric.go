@@ -0,0 +1,158 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// jsonNumberType is the type of JSON numbers decoded into interfaces with json.Decoder.UseNumber.
+// Its values are always compared as decimal numbers, see NumericStrings.
+var jsonNumberType = reflect.TypeOf(json.Number(""))
+
+// NumericStrings compares strings as decimal numbers of arbitrary precision, e.g. the big
+// integers and decimals JSON APIs encode as strings, so "9" < "10" and "1.50" == "1.5".
+// Values of json.Number are always compared as decimal numbers, with the unset, empty
+// json.Number ordered before all numbers.
+// Numbers consist of an optional sign, digits with an optional decimal point and an optional
+// exponent, e.g. "-12", "0.5", ".5" or "1e-3".
+//
+// Comparing strings that are not numbers fails with an error matching ErrNotNumeric.
+// Use ForType or AtPath to restrict NumericStrings to the strings holding numbers.
+func NumericStrings() Option {
+	return func(o *options) {
+		o.numericStrings = true
+	}
+}
+
+// decimal is a parsed decimal number 0.digits * 10^exp.
+type decimal struct {
+	neg bool
+	// digits are the significant digits, without leading and trailing zeros.
+	// They are empty for zero.
+	digits string
+	exp    int
+}
+
+// parseDecimal parses the decimal number s.
+func parseDecimal(s string) (decimal, bool) {
+	var d decimal
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		d.neg = s[0] == '-'
+		s = s[1:]
+	}
+	mantissa := s
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		mantissa = s[:i]
+		exp, err := strconv.Atoi(s[i+1:])
+		if err != nil {
+			return d, false
+		}
+		d.exp = exp
+	}
+	integer, fraction := mantissa, ""
+	if i := strings.IndexByte(mantissa, '.'); i >= 0 {
+		integer, fraction = mantissa[:i], mantissa[i+1:]
+	}
+	if (integer == "" && fraction == "") || !isDigits(integer) || !isDigits(fraction) {
+		return d, false
+	}
+	all := integer + fraction
+	digits := strings.TrimLeft(all, "0")
+	// Leading zeros are not significant, e.g. 0.05 is 0.5 * 10^-1.
+	d.exp += len(integer) - (len(all) - len(digits))
+	d.digits = strings.TrimRight(digits, "0")
+	if d.digits == "" {
+		return decimal{}, true
+	}
+	return d, true
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// compareNumericStrings compares the decimal numbers s1 and s2.
+func compareNumericStrings(s1, s2 string) (int, error) {
+	d1, ok := parseDecimal(s1)
+	if !ok {
+		return 0, &pathError{err: fmt.Errorf("%w: %q", ErrNotNumeric, s1)}
+	}
+	d2, ok := parseDecimal(s2)
+	if !ok {
+		return 0, &pathError{err: fmt.Errorf("%w: %q", ErrNotNumeric, s2)}
+	}
+	if res := compareInt64(int64(d1.sign()), int64(d2.sign())); res != 0 || d1.digits == "" {
+		return res, nil
+	}
+	res := compareInt64(int64(d1.exp), int64(d2.exp))
+	if res == 0 {
+		res = strings.Compare(d1.digits, d2.digits)
+	}
+	if d1.neg {
+		return -res, nil
+	}
+	return res, nil
+}
+
+// String returns d in canonical form, e.g. "-0.15e3" for -150.
+func (d decimal) String() string {
+	switch {
+	case d.digits == "":
+		return "0"
+	case d.neg:
+		return "-0." + d.digits + "e" + strconv.Itoa(d.exp)
+	}
+	return "0." + d.digits + "e" + strconv.Itoa(d.exp)
+}
+
+// canonicalNumber returns the json.Number v in canonical form, so equal numbers are encoded
+// alike, e.g. by DeepHash. Numbers that cannot be parsed are returned as is, so the empty
+// json.Number is encoded as "", unlike any number.
+func canonicalNumber(v reflect.Value) string {
+	if d, ok := parseDecimal(v.String()); ok {
+		return d.String()
+	}
+	return v.String()
+}
+
+// compareJSONNumbers compares the json.Number values s1 and s2 as decimal numbers. The empty
+// json.Number, i.e. the zero value of unset fields, is less than all numbers.
+func compareJSONNumbers(s1, s2 string) (int, error) {
+	if s1 == "" || s2 == "" {
+		return compareBool(s1 != "", s2 != ""), nil
+	}
+	return compareNumericStrings(s1, s2)
+}
+
+// sign returns -1, 0 or 1 if d is negative, zero or positive.
+func (d decimal) sign() int {
+	switch {
+	case d.digits == "":
+		return 0
+	case d.neg:
+		return -1
+	}
+	return 1
+}
@@ -0,0 +1,135 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"encoding/json"
+	"strings"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NumericStrings", func() {
+	cmp := make(Comparisons).NewComparer(NumericStrings())
+
+	DescribeTable("should compare strings numerically",
+		func(s1, s2 string, expected int) {
+			Expect(cmp.TryCompare(s1, s2)).To(Equal(expected))
+			Expect(cmp.TryCompare(s2, s1)).To(Equal(-expected))
+		},
+		Entry("integers", "9", "10", -1),
+		Entry("equal integers", "10", "010", 0),
+		Entry("big integers", "123456789012345678901234567890", "123456789012345678901234567891", -1),
+		Entry("decimals", "1.5", "1.25", 1),
+		Entry("trailing zeros", "1.50", "1.5", 0),
+		Entry("leading decimal point", ".5", "0.5", 0),
+		Entry("trailing decimal point", "5.", "5", 0),
+		Entry("negative numbers", "-2", "-10", 1),
+		Entry("signs", "-1", "+1", -1),
+		Entry("zeros", "-0.0", "0", 0),
+		Entry("zero and positive", "0", "0.001", -1),
+		Entry("exponents", "1e3", "999", 1),
+		Entry("negative exponents", "1E-3", "0.001", 0),
+		Entry("small decimals", "0.05", "0.5", -1),
+	)
+
+	It("should compare nested strings", func() {
+		type Balance struct {
+			Account string
+			Amount  string
+		}
+		cmp := make(Comparisons).NewComparer(AtPath("Amount", NumericStrings()))
+		Expect(cmp.Compare(Balance{Account: "a", Amount: "9.99"}, Balance{Account: "a", Amount: "10"})).To(Equal(-1))
+		Expect(cmp.Compare(Balance{Account: "b"}, Balance{Account: "a"})).To(Equal(1))
+	})
+
+	It("should error on strings that are no numbers", func() {
+		for _, s := range []string{"", "-", ".", "1x", "1e", "1.2.3", "0x10", "1/3", "Inf", " 1"} {
+			_, err := cmp.TryCompare("1", s)
+			Expect(err).To(MatchError(ErrNotNumeric), s)
+		}
+		_, err := cmp.TryCompare([]string{"1", "a"}, []string{"1", "2"})
+		Expect(err).To(MatchError(`not a numeric string: "a" at [1]`))
+	})
+})
+
+var _ = Describe("json.Number", func() {
+	c := make(Comparisons)
+
+	decode := func(s string) interface{} {
+		d := json.NewDecoder(strings.NewReader(s))
+		d.UseNumber()
+		var v interface{}
+		Expect(d.Decode(&v)).To(Succeed())
+		return v
+	}
+
+	It("should compare numbers numerically", func() {
+		Expect(c.DeepCompare(json.Number("9"), json.Number("10"))).To(Equal(-1))
+		Expect(c.DeepCompare(json.Number("1.0"), json.Number("1"))).To(Equal(0))
+		Expect(c.DeepCompare(json.Number("-1e100"), json.Number("-2"))).To(Equal(-1))
+		Expect(c.DeepCompare(
+			json.Number("12345678901234567890123456789"),
+			json.Number("12345678901234567890123456788.5"),
+		)).To(Equal(1))
+	})
+
+	It("should compare decoded dynamic JSON numerically", func() {
+		Expect(c.DeepCompare(decode(`{"a": [9, 1.5]}`), decode(`{"a": [10, 1.5]}`))).To(Equal(-1))
+		Expect(c.DeepCompare(decode(`{"a": [1e1, 1.50]}`), decode(`{"a": [10, 1.5]}`))).To(Equal(0))
+	})
+
+	It("should hash and key equal numbers alike", func() {
+		h1, err := c.DeepHash(json.Number("1.50"))
+		Expect(err).NotTo(HaveOccurred())
+		h2, err := c.DeepHash(json.Number("15e-1"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(h1).To(Equal(h2))
+		key := func(n json.Number) Key {
+			k, err := c.Key(n)
+			Expect(err).NotTo(HaveOccurred())
+			return k
+		}
+		Expect(key("100")).To(Equal(key("1e2")))
+		Expect(key("100")).NotTo(Equal(key("1e3")))
+	})
+
+	It("should order empty numbers before all numbers", func() {
+		type Amount struct {
+			N json.Number
+		}
+		Expect(c.DeepCompare(Amount{}, Amount{N: "0"})).To(Equal(-1))
+		Expect(c.DeepCompare(Amount{N: "-1e9"}, Amount{})).To(Equal(1))
+		Expect(c.DeepCompare(Amount{}, Amount{})).To(Equal(0))
+		h1, err := c.DeepHash(Amount{})
+		Expect(err).NotTo(HaveOccurred())
+		h2, err := c.DeepHash(Amount{N: "0"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(h1).NotTo(Equal(h2))
+		k1, err := c.Key(Amount{})
+		Expect(err).NotTo(HaveOccurred())
+		k2, err := c.Key(Amount{N: "0"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(k1).NotTo(Equal(k2))
+	})
+
+	It("should error on invalid numbers", func() {
+		_, err := c.TryDeepCompare(json.Number("1"), json.Number("one"))
+		Expect(err).To(MatchError(ErrNotNumeric))
+	})
+})
@@ -0,0 +1,127 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Opaque compares values of the types of the given samples by identity, i.e. by the
+// addresses they hold, without looking at what they point to. This is useful for handles
+// whose internals must not be touched, e.g. wrappers carrying finalizers.
+// Values that hold the same addresses are equal, others are ordered by their addresses,
+// which is only stable for as long as the values are alive.
+//
+// Values of weak.Pointer and runtime.Pinner, as well as of standard library types holding
+// handles of resources or runtime state, e.g. os.File, exec.Cmd, rand.Rand and sync.Mutex,
+// are always compared by identity. Structs embedding such types, e.g. configurations, can
+// thus be compared by their other fields without failing on unexported fields.
+//
+// Opaque panics if any sample is an untyped nil.
+func Opaque(samples ...interface{}) Option {
+	types := make([]reflect.Type, len(samples))
+	for i, sample := range samples {
+		if sample == nil {
+			panic("expected opaque sample, got: nil")
+		}
+		types[i] = reflect.TypeOf(sample)
+	}
+	return func(o *options) {
+		if o.opaque == nil {
+			o.opaque = make(map[reflect.Type]bool)
+		}
+		for _, t := range types {
+			o.opaque[t] = true
+		}
+	}
+}
+
+// handleTypes are the standard library types holding handles of resources or runtime state,
+// by package path and name, see isOpaqueType.
+var handleTypes = map[string]bool{
+	"bufio.Reader":      true,
+	"bufio.Writer":      true,
+	"log.Logger":        true,
+	"math/rand.Rand":    true,
+	"math/rand/v2.Rand": true,
+	"net.TCPConn":       true,
+	"net.TCPListener":   true,
+	"net.UDPConn":       true,
+	"net.UnixConn":      true,
+	"net.UnixListener":  true,
+	"os.File":           true,
+	"os.Process":        true,
+	"os.ProcessState":   true,
+	"os/exec.Cmd":       true,
+	"sync.Cond":         true,
+	"sync.Map":          true,
+	"sync.Mutex":        true,
+	"sync.Once":         true,
+	"sync.Pool":         true,
+	"sync.RWMutex":      true,
+	"sync.WaitGroup":    true,
+	"time.Ticker":       true,
+	"time.Timer":        true,
+}
+
+// isOpaqueType reports whether t is a standard library type that is always compared by identity.
+func isOpaqueType(t reflect.Type) bool {
+	if t.Name() != "" && handleTypes[t.PkgPath()+"."+t.Name()] {
+		return true
+	}
+	switch t.PkgPath() {
+	case "weak":
+		return strings.HasPrefix(t.Name(), "Pointer[")
+	case "runtime":
+		return t.Name() == "Pinner"
+	}
+	return false
+}
+
+// appendAddrs appends the addresses held by v to addrs, without following them.
+// Addresses are read even from unexported fields, as they are never dereferenced.
+func appendAddrs(addrs []uintptr, v reflect.Value) []uintptr {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.UnsafePointer, reflect.Map, reflect.Chan, reflect.Func, reflect.Slice:
+		return append(addrs, v.Pointer())
+	case reflect.Interface:
+		if v.IsNil() {
+			return append(addrs, 0)
+		}
+		return appendAddrs(addrs, v.Elem())
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			addrs = appendAddrs(addrs, v.Index(i))
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			addrs = appendAddrs(addrs, v.Field(i))
+		}
+	}
+	return addrs
+}
+
+// compareIdentities compares v1 and v2 by the addresses they hold.
+func compareIdentities(v1, v2 reflect.Value) int {
+	addrs1 := appendAddrs(nil, v1)
+	addrs2 := appendAddrs(nil, v2)
+	for i := 0; i < len(addrs1) && i < len(addrs2); i++ {
+		if res := compareUInt64(uint64(addrs1[i]), uint64(addrs2[i])); res != 0 {
+			return res
+		}
+	}
+	return compareInt64(int64(len(addrs1)), int64(len(addrs2)))
+}
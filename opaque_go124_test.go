@@ -0,0 +1,48 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.24
+
+package reflcompare_test
+
+import (
+	"runtime"
+	"weak"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Opaque standard library types", func() {
+	c := make(Comparisons)
+
+	It("should compare weak pointers by identity", func() {
+		a, b := new(int), new(int)
+		Expect(c.DeepCompare(weak.Make(a), weak.Make(a))).To(Equal(0))
+		Expect(c.DeepCompare(weak.Make(a), weak.Make(b))).NotTo(Equal(0))
+		Expect(c.DeepCompare(weak.Pointer[int]{}, weak.Make(a))).To(Equal(-1))
+		runtime.KeepAlive(a)
+		runtime.KeepAlive(b)
+	})
+
+	It("should compare pinners by identity", func() {
+		type Buffer struct {
+			Data   []byte
+			Pinner runtime.Pinner
+		}
+		Expect(c.DeepCompare(Buffer{Data: []byte("a")}, Buffer{Data: []byte("a")})).To(Equal(0))
+		Expect(c.ScanTypes(Buffer{})).To(Succeed())
+	})
+})
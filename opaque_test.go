@@ -0,0 +1,84 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"math/rand"
+	"os"
+	"os/exec"
+	"sync"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// Launcher is a configuration holding handles of resources.
+type Launcher struct {
+	Name   string
+	Output *os.File
+	Cmd    *exec.Cmd
+	Rand   *rand.Rand
+	mu     sync.Mutex
+}
+
+// Handle is a wrapper whose internals must not be compared.
+type Handle struct {
+	resource *int
+	closed   bool
+}
+
+var _ = Describe("Opaque", func() {
+	cmp := make(Comparisons).NewComparer(Opaque(Handle{}))
+
+	It("should compare values by identity", func() {
+		a, b := 1, 1
+		Expect(cmp.Compare(Handle{resource: &a}, Handle{resource: &a, closed: true})).To(Equal(0))
+		Expect(cmp.Compare(Handle{resource: &a}, Handle{resource: &b})).NotTo(Equal(0))
+		Expect(cmp.Compare(Handle{resource: &a}, Handle{resource: &b})).To(Equal(-cmp.Compare(Handle{resource: &b}, Handle{resource: &a})))
+	})
+
+	It("should not panic on unexported fields of opaque values", func() {
+		a := 1
+		Expect(func() { cmp.Compare([]Handle{{resource: &a}}, []Handle{{}}) }).NotTo(Panic())
+		Expect(cmp.Compare([]Handle{{resource: &a}}, []Handle{{}})).To(Equal(1))
+	})
+
+	It("should panic on nil samples", func() {
+		Expect(func() { Opaque(nil) }).To(Panic())
+	})
+})
+
+var _ = Describe("Standard library handle types", func() {
+	c := make(Comparisons)
+
+	It("should compare structs holding handles by their other fields", func() {
+		cmd := exec.Command("true")
+		r := rand.New(rand.NewSource(1))
+		l1 := &Launcher{Name: "a", Output: os.Stdout, Cmd: cmd, Rand: r}
+		l2 := &Launcher{Name: "a", Output: os.Stdout, Cmd: cmd, Rand: r}
+		l2.mu.Lock()
+		defer l2.mu.Unlock()
+		Expect(c.DeepCompare(l1, l2)).To(Equal(0))
+		Expect(c.DeepCompare(&Launcher{Name: "a"}, &Launcher{Name: "b"})).To(Equal(-1))
+	})
+
+	It("should compare handles by identity", func() {
+		Expect(func() { c.DeepCompare(exec.Command("true"), exec.Command("true")) }).NotTo(Panic())
+		Expect(c.DeepCompare(os.Stdout, os.Stderr)).NotTo(Equal(0))
+		Expect(c.DeepCompare(Launcher{Output: os.Stdout}, Launcher{Output: os.Stdout})).To(Equal(0))
+		Expect(c.DeepCompare(rand.New(rand.NewSource(1)), rand.New(rand.NewSource(1)))).NotTo(Equal(0))
+	})
+})
@@ -0,0 +1,306 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Option configures how a Comparer compares values.
+type Option func(*options)
+
+// options are the settings of a traversal.
+type options struct {
+	// strict disallows comparing an untyped nil to a typed value.
+	strict bool
+	// collapsePointers treats a nil at any level of a pointer chain alike.
+	collapsePointers bool
+	// dynamicTypeOrder are the interface types whose values are ordered by their dynamic types first.
+	dynamicTypeOrder map[reflect.Type]bool
+	// markers are the types whose values are always considered equal.
+	markers map[reflect.Type]bool
+	// versions are the version extractors by the types they extract versions of.
+	versions map[reflect.Type]reflect.Value
+	// opaque are the types whose values are compared by the addresses they hold.
+	opaque map[reflect.Type]bool
+	// compareAsSets compares maps used as sets by their members.
+	compareAsSets bool
+	// fieldWeights are the weights of struct fields by name per struct type, overriding tags.
+	fieldWeights map[reflect.Type]map[string]int
+	// defaults are the defaults of unset values by type, see Defaults.
+	defaults map[reflect.Type]reflect.Value
+	// fieldDefaults are the defaults of unset struct fields by name per struct type, see FieldDefaults.
+	fieldDefaults map[reflect.Type]map[string]reflect.Value
+	// significantFields, if positive, limits the weighted fields to compare per struct.
+	significantFields int
+	// strictMaps compares maps by their sorted keys before their values.
+	strictMaps bool
+	// missingAsZero compares the value of a key missing from a map as the zero value.
+	missingAsZero bool
+	// maxDepth, if positive, is the maximum nesting depth of compared values.
+	maxDepth int
+	// asOfVersion, if positive, is the version of struct types to compare, see AsOfVersion.
+	asOfVersion int
+	// maxDifferences, if positive, is the maximum number of differences reported by Diff.
+	maxDifferences int
+	// reuseReports reuses the memory of paths reported by DiffFunc.
+	reuseReports bool
+	// fieldTags are the compare tags of struct fields by name per struct type, see FieldTags.
+	fieldTags map[reflect.Type]map[string]string
+	// tagComparisons are the comparison functions by the names fields are tagged with.
+	tagComparisons map[string]reflect.Value
+	// mergeKeys are the indices of the merge key fields of list elements by element type.
+	mergeKeys map[reflect.Type][]int
+	// sortKeyedLists compares keyed lists as if sorted by their keys.
+	sortKeyedLists bool
+	// redact masks values in reports, see Redact.
+	redact bool
+	// redactor masks redacted values, if set.
+	redactor Redactor
+	// formatters are the formatters rendering values in reports by the types they render.
+	formatters map[reflect.Type]reflect.Value
+	// maxBytes, if positive, is the maximum length of strings in reports, see Truncate.
+	maxBytes int
+	// maxElements, if positive, is the maximum number of elements of lists in reports, see Truncate.
+	maxElements int
+	// numericStrings compares strings as decimal numbers, see NumericStrings.
+	numericStrings bool
+	// funcsByNil compares funcs by whether they are nil only.
+	funcsByNil bool
+	// debugTotalOrder orders all values instead of failing, see DebugTotalOrder.
+	debugTotalOrder bool
+	// lenient considers values that cannot be compared because of unexported fields equal.
+	lenient bool
+	// scoped are the options applying to values of a type and the values nested in them.
+	scoped map[reflect.Type][]Option
+	// typeScopes are the options applying to values of types matching patterns, see ForTypesMatching.
+	typeScopes []typeScope
+	// atPaths are the options applying to values at matching paths and the values nested in them.
+	atPaths []pathScope
+}
+
+// Strict makes comparing an untyped nil to a typed value a type mismatch
+// (even if the typed value is nil itself) instead of ordering the untyped nil first.
+func Strict() Option {
+	return func(o *options) {
+		o.strict = true
+	}
+}
+
+// Lenient considers values that cannot be compared because they are only reachable via
+// unexported fields equal instead of panicking. This affects values of unexported fields
+// with a comparison function and values of kinds that are compared via ==, e.g. channels.
+func Lenient() Option {
+	return func(o *options) {
+		o.lenient = true
+	}
+}
+
+// FuncsByNil compares funcs by whether they are nil only, considering any two non-nil funcs
+// equal instead of failing with ErrFuncCompare. This is useful for comparing option structs
+// holding callbacks, whose identities are irrelevant to the configuration they describe.
+// A nil func is still less than a non-nil one.
+func FuncsByNil() Option {
+	return func(o *options) {
+		o.funcsByNil = true
+	}
+}
+
+// MaxDepth limits comparing values to values nested at most n levels deep, e.g. to guard
+// against deeply nested or cyclic values. Comparing values nested deeper fails with an
+// error matching ErrDepthExceeded.
+func MaxDepth(n int) Option {
+	return func(o *options) {
+		o.maxDepth = n
+	}
+}
+
+// AsOfVersion compares structs as of version n of their types, ignoring fields added in later
+// versions as declared by the struct tag `compare:"since=N"`. This allows comparing values to
+// values known to originate from an older version of a program, e.g. during a rolling upgrade,
+// where fields unknown to the older version are always unset.
+//
+// Fields without a since directive are considered part of every version.
+func AsOfVersion(n int) Option {
+	return func(o *options) {
+		o.asOfVersion = n
+	}
+}
+
+// MaxDifferences stops Diff after finding n differences instead of traversing the
+// values entirely. It has no effect on comparisons.
+func MaxDifferences(n int) Option {
+	return func(o *options) {
+		o.maxDifferences = n
+	}
+}
+
+// ReuseReports lets DiffFunc reuse the memory of the paths of the differences it reports,
+// so diffing large values allocates less. The path of a difference passed to the callback
+// of DiffFunc is then only valid until the callback returns; callers processing differences
+// synchronously have to copy paths to retain them. ReuseReports has no effect on Diff.
+func ReuseReports() Option {
+	return func(o *options) {
+		o.reuseReports = true
+	}
+}
+
+// Markers considers all values of the types of the given samples equal, regardless of
+// their contents. This is useful for marker types like the struct{} values of sets
+// implemented as map[K]struct{}, so such maps compare by their keys only, even if
+// the marker type gains fields later on.
+//
+// Markers panics if any sample is an untyped nil.
+func Markers(samples ...interface{}) Option {
+	types := make([]reflect.Type, len(samples))
+	for i, sample := range samples {
+		if sample == nil {
+			panic("expected marker sample, got: nil")
+		}
+		types[i] = reflect.TypeOf(sample)
+	}
+	return func(o *options) {
+		if o.markers == nil {
+			o.markers = make(map[reflect.Type]bool)
+		}
+		for _, t := range types {
+			o.markers[t] = true
+		}
+	}
+}
+
+// CompareAsSets compares maps used as sets, i.e. map[K]struct{} and map[K]bool, by
+// their members instead of by their entries. Keys of a map[K]bool are only members
+// if they map to true.
+//
+// Sets are ordered by their sorted members, lexicographically: {a} < {a, b} < {b}.
+//
+// Diff reports the members present in only one of the sets as "member removed" and
+// "member added", instead of positional differences.
+func CompareAsSets() Option {
+	return func(o *options) {
+		o.compareAsSets = true
+	}
+}
+
+// FieldWeights assigns weights to the fields of the struct type of sample by field name.
+// Fields with higher weights are compared first, fields with equal weights in order of
+// their declaration. Fields without weight have weight 0. Weights can also be assigned
+// via the struct tag `compare:"weight=N"`; FieldWeights take precedence over tags.
+//
+// FieldWeights panics if sample is not a struct or if any name does not denote a field
+// declared by it. Fields promoted from embedded structs are weighted via the embedded type.
+func FieldWeights(sample interface{}, weights map[string]int) Option {
+	t := reflect.TypeOf(sample)
+	if t == nil || t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("expected struct, got: %T", sample))
+	}
+	for name := range weights {
+		f, ok := t.FieldByName(name)
+		if !ok {
+			panic(fmt.Sprintf("type %v has no field %s", t, name))
+		}
+		if len(f.Index) > 1 {
+			panic(fmt.Sprintf("field %s of type %v is promoted from embedded type %v", name, t, t.Field(f.Index[0]).Type))
+		}
+	}
+	return func(o *options) {
+		if o.fieldWeights == nil {
+			o.fieldWeights = make(map[reflect.Type]map[string]int)
+		}
+		if o.fieldWeights[t] == nil {
+			o.fieldWeights[t] = make(map[string]int)
+		}
+		for name, weight := range weights {
+			o.fieldWeights[t][name] = weight
+		}
+	}
+}
+
+// SignificantFields limits comparing structs with weighted fields (see FieldWeights)
+// to their k fields with the highest weights. The remaining fields are ignored.
+// Structs without weighted fields are not affected.
+func SignificantFields(k int) Option {
+	return func(o *options) {
+		o.significantFields = k
+	}
+}
+
+// StrictMaps compares maps deterministically, independent of their iteration order:
+// Maps are ordered by their lengths first. Maps of equal length are ordered by their
+// sorted keys, so the map holding the smallest key not present in the other map is less.
+// Maps with the same keys are ordered by their values in key order.
+//
+// By default, maps of equal length with different keys are ordered by the first differing
+// entry in iteration order, which is random. Also, an empty map is less than a non-empty one
+// with StrictMaps, whereas it is considered equal by default.
+func StrictMaps() Option {
+	return func(o *options) {
+		o.strictMaps = true
+	}
+}
+
+// MissingAsZero compares the value of a key that is missing from one of two maps of equal
+// length as if it was present with the zero value of the map's value type.
+//
+// By default, the map holding a key missing from the other map is greater, regardless of
+// the value of the key.
+// StrictMaps takes precedence over MissingAsZero: as StrictMaps orders maps by their keys
+// before their values, a missing key decides the order before any value is compared, and
+// Diff reports missing keys as such. Config.Options rejects configuring both.
+func MissingAsZero() Option {
+	return func(o *options) {
+		o.missingAsZero = true
+	}
+}
+
+// CollapsePointers compares chains of pointers (e.g. **T) by their final values only.
+//
+// By default, pointer chains are compared level by level, so a nil at an outer level
+// is less than a nil at an inner level. With CollapsePointers, a nil at any level of
+// the chain is the same as any other nil, and less than any non-nil final value.
+func CollapsePointers() Option {
+	return func(o *options) {
+		o.collapsePointers = true
+	}
+}
+
+// OrderByDynamicType orders values of the given interface types by their dynamic types
+// first if their dynamic types differ, instead of panicking. This allows e.g. sorting
+// a []error holding different error implementations.
+//
+// Interface types are specified via nil pointers to them, e.g. (*error)(nil).
+// The order of dynamic types is deterministic, but otherwise unspecified.
+//
+// OrderByDynamicType panics if any argument is not a pointer to an interface type.
+func OrderByDynamicType(ifacePtrs ...interface{}) Option {
+	types := make([]reflect.Type, len(ifacePtrs))
+	for i, ifacePtr := range ifacePtrs {
+		t := reflect.TypeOf(ifacePtr)
+		if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Interface {
+			panic(fmt.Sprintf("expected pointer to interface, got: %T", ifacePtr))
+		}
+		types[i] = t.Elem()
+	}
+	return func(o *options) {
+		if o.dynamicTypeOrder == nil {
+			o.dynamicTypeOrder = make(map[reflect.Type]bool)
+		}
+		for _, t := range types {
+			o.dynamicTypeOrder[t] = true
+		}
+	}
+}
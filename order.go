@@ -0,0 +1,66 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// AddOrder adds a comparison function ranking values of an enum-like type by the
+// given values, which have to be given in ascending order, e.g.
+//
+//	c.AddOrder(Low, High, Critical)
+//
+// makes Critical greater than High, regardless of the underlying values.
+// Values not given are greater than all given ones and are compared by their
+// underlying values among each other, with the options of the comparison.
+//
+// All values have to be of the same, comparable type. No value may be given twice.
+func (c Comparisons) AddOrder(values ...interface{}) error {
+	if len(values) == 0 {
+		return fmt.Errorf("expected at least one value")
+	}
+	t := reflect.TypeOf(values[0])
+	if t == nil || !t.Comparable() {
+		return fmt.Errorf("expected values of comparable type, got: %T", values[0])
+	}
+	ranks := make(map[interface{}]int, len(values))
+	for i, value := range values {
+		if vt := reflect.TypeOf(value); vt != t {
+			return fmt.Errorf("expected values of type %v, got: %v", t, vt)
+		}
+		if _, ok := ranks[value]; ok {
+			return fmt.Errorf("duplicate value %v", value)
+		}
+		ranks[value] = i
+	}
+
+	rank := func(v reflect.Value) int {
+		if r, ok := ranks[v.Interface()]; ok {
+			return r
+		}
+		return len(values)
+	}
+	c.addTraversalFunc(t, "AddOrder", func(tr *traversal, v1, v2 reflect.Value, depth int) (int, error) {
+		r1, r2 := rank(v1), rank(v2)
+		if res := compareInt64(int64(r1), int64(r2)); res != 0 || r1 < len(values) {
+			return res, nil
+		}
+		// Neither value is ranked; compare them by the default rules.
+		return tr.compareBypassing(t, v1, v2, depth)
+	})
+	return nil
+}
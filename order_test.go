@@ -0,0 +1,66 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type Severity string
+
+const (
+	Low      Severity = "low"
+	High     Severity = "high"
+	Critical Severity = "critical"
+)
+
+var _ = Describe("AddOrder", func() {
+	var c Comparisons
+	BeforeEach(func() {
+		c = make(Comparisons)
+	})
+
+	It("should rank the values in the given order", func() {
+		Expect(c.AddOrder(Low, High, Critical)).To(Succeed())
+		Expect(c.DeepCompare(Critical, High)).To(Equal(1))
+		Expect(c.DeepCompare(Low, High)).To(Equal(-1))
+		Expect(c.DeepCompare(High, High)).To(Equal(0))
+		Expect(c.DeepCompare([]Severity{Low, Critical}, []Severity{Low, High})).To(Equal(1))
+	})
+
+	It("should order values that are not given last", func() {
+		Expect(c.AddOrder(Low, High)).To(Succeed())
+		Expect(c.DeepCompare(Critical, High)).To(Equal(1))
+		Expect(c.DeepCompare(Severity("a"), Severity("b"))).To(Equal(-1))
+	})
+
+	It("should compare values that are not given with the options of the comparison", func() {
+		Expect(c.AddOrder(Severity("none"))).To(Succeed())
+		Expect(c.DeepCompare(Severity("10"), Severity("9"))).To(Equal(-1))
+		Expect(c.NewComparer(NumericStrings()).Compare(Severity("10"), Severity("9"))).To(Equal(1))
+		Expect(c.NewComparer(NumericStrings()).Compare(Severity("none"), Severity("9"))).To(Equal(-1))
+	})
+
+	It("should error on invalid values", func() {
+		Expect(c.AddOrder()).NotTo(Succeed())
+		Expect(c.AddOrder(Low, "high")).NotTo(Succeed())
+		Expect(c.AddOrder(Low, Low)).NotTo(Succeed())
+		Expect(c.AddOrder([]int{1})).NotTo(Succeed())
+		Expect(c.AddOrder(nil)).NotTo(Succeed())
+		Expect(c).To(BeEmpty())
+	})
+})
@@ -0,0 +1,104 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ParseOrdering parses an ordering expression like 'name asc, created_at desc, spec.replicas'
+// into sort columns for the struct type of sample. The result can be used with
+// Comparisons.SortTable and Comparisons.CompareColumns.
+//
+// An ordering expression is a comma-separated list of columns, each optionally followed
+// by 'asc' (the default) or 'desc'. A column is a dot-separated path of field names, where
+// each name is matched against the json name of a field first and against its Go name
+// (case-insensitively) second. All columns are validated against the type of sample.
+func ParseOrdering(expr string, sample interface{}) ([]SortColumn, error) {
+	t := reflect.TypeOf(sample)
+	if t == nil {
+		return nil, fmt.Errorf("expected sample value, got: nil")
+	}
+	var columns []SortColumn
+	for _, part := range strings.Split(expr, ",") {
+		fields := strings.Fields(part)
+		if len(fields) == 0 || len(fields) > 2 {
+			return nil, fmt.Errorf("invalid ordering %q: expected '<column> [asc|desc]', got %q", expr, strings.TrimSpace(part))
+		}
+		var descending bool
+		if len(fields) == 2 {
+			switch strings.ToLower(fields[1]) {
+			case "asc":
+			case "desc":
+				descending = true
+			default:
+				return nil, fmt.Errorf("invalid ordering %q: unknown direction %q", expr, fields[1])
+			}
+		}
+		path, err := resolveOrderingColumn(t, fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid ordering %q: %w", expr, err)
+		}
+		columns = append(columns, SortColumn{Path: path, Descending: descending})
+	}
+	return columns, nil
+}
+
+// resolveOrderingColumn translates the given column to a path of Go field names.
+func resolveOrderingColumn(t reflect.Type, column string) (string, error) {
+	var names []string
+	for _, name := range strings.Split(column, ".") {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return "", fmt.Errorf("column %q: cannot select field %q of non-struct type %v", column, name, t)
+		}
+		f, ok := orderingField(t, name)
+		if !ok {
+			return "", fmt.Errorf("column %q: type %v has no field %q", column, t, name)
+		}
+		names = append(names, f.Name)
+		t = f.Type
+	}
+	return strings.Join(names, "."), nil
+}
+
+// orderingField looks up the exported field of t with the given json name or,
+// if there is none, with the given case-insensitive Go name.
+func orderingField(t reflect.Type, name string) (reflect.StructField, bool) {
+	var (
+		byName reflect.StructField
+		found  bool
+	)
+	for _, f := range reflect.VisibleFields(t) {
+		if f.PkgPath != "" || f.Anonymous {
+			continue
+		}
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		if jsonName := strings.Split(tag, ",")[0]; jsonName == name {
+			return f, true
+		}
+		if !found && strings.EqualFold(f.Name, name) {
+			byName, found = f, true
+		}
+	}
+	return byName, found
+}
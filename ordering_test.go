@@ -0,0 +1,64 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+type Resource struct {
+	Name      string `json:"name"`
+	CreatedAt int64  `json:"created_at"`
+	Internal  string `json:"-"`
+	Spec      *Spec  `json:"spec,omitempty"`
+}
+
+var _ = Describe("Ordering", func() {
+	DescribeTable("ParseOrdering",
+		func(expr string, expect []SortColumn) {
+			Expect(ParseOrdering(expr, Resource{})).To(Equal(expect))
+		},
+		Entry("single column", "name", []SortColumn{{Path: "Name"}}),
+		Entry("directions", "name asc, created_at DESC", []SortColumn{{Path: "Name"}, {Path: "CreatedAt", Descending: true}}),
+		Entry("nested columns", "spec.replicas desc", []SortColumn{{Path: "Spec.Replicas", Descending: true}}),
+		Entry("go field names", "CreatedAt", []SortColumn{{Path: "CreatedAt"}}),
+	)
+
+	DescribeTable("ParseOrdering errors",
+		func(expr string) {
+			_, err := ParseOrdering(expr, Resource{})
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("empty", ""),
+		Entry("empty column", "name,"),
+		Entry("unknown column", "unknown"),
+		Entry("json ignored column", "internal"),
+		Entry("unknown direction", "name up"),
+		Entry("too many tokens", "name asc desc"),
+		Entry("non-struct selection", "name.length"),
+	)
+
+	It("should produce columns usable for sorting", func() {
+		columns, err := ParseOrdering("created_at desc", &Resource{})
+		Expect(err).NotTo(HaveOccurred())
+
+		rows := []Resource{{Name: "a", CreatedAt: 1}, {Name: "b", CreatedAt: 2}}
+		make(Comparisons).SortTable(rows, columns)
+		Expect(rows[0].Name).To(Equal("b"))
+	})
+})
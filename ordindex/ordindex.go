@@ -0,0 +1,302 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ordindex provides a continuously sorted collection whose order is
+// determined by reflcompare.Comparisons.
+package ordindex
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/adracus/reflcompare"
+)
+
+// EventType is the type of an Event.
+type EventType int
+
+const (
+	// Added indicates that an item was added at Event.NewIndex.
+	Added EventType = iota
+	// Removed indicates that an item was removed from Event.OldIndex.
+	Removed
+	// Updated indicates that an item was updated and its position did not change.
+	Updated
+	// Moved indicates that an item was updated and moved from Event.OldIndex to Event.NewIndex.
+	Moved
+)
+
+// String implements fmt.Stringer.
+func (t EventType) String() string {
+	switch t {
+	case Added:
+		return "Added"
+	case Removed:
+		return "Removed"
+	case Updated:
+		return "Updated"
+	case Moved:
+		return "Moved"
+	default:
+		return fmt.Sprintf("EventType(%d)", int(t))
+	}
+}
+
+// Event describes a change of the position of an item in an Index.
+//
+// Only the position of the changed item is reported. Positions of the other items
+// shift implicitly, as they would when inserting into or removing from a list.
+type Event struct {
+	Type EventType
+	Key  interface{}
+	Item interface{}
+	// OldIndex is the position of the item before the change or -1 if the item was added.
+	OldIndex int
+	// NewIndex is the position of the item after the change or -1 if the item was removed.
+	NewIndex int
+}
+
+type node struct {
+	key, item interface{}
+	// seq orders nodes whose items and keys are equal by the order they were added in.
+	seq         uint64
+	priority    uint32
+	size        int
+	left, right *node
+}
+
+func size(n *node) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *node) update() {
+	n.size = 1 + size(n.left) + size(n.right)
+}
+
+// Index is a collection of keyed items that is continuously sorted by the items.
+// Items that are equal according to the comparisons are ordered by their keys and
+// items with equal keys, e.g. for comparison functions ignoring case, by the order
+// they were added in.
+//
+// Insertions, updates, removals and positional lookups take O(log n) comparisons.
+// An Index is not safe for concurrent use.
+type Index struct {
+	comparisons reflcompare.Comparisons
+	handlers    []func(Event)
+	root        *node
+	nodes       map[interface{}]*node
+	// seq is the sequence number of the next added node.
+	seq uint64
+}
+
+// New creates a new, empty Index ordering its items by the given Comparisons.
+func New(c reflcompare.Comparisons) *Index {
+	return &Index{
+		comparisons: c,
+		nodes:       make(map[interface{}]*node),
+	}
+}
+
+// AddHandler adds a handler that is called for every change of the Index.
+func (x *Index) AddHandler(handler func(Event)) {
+	x.handlers = append(x.handlers, handler)
+}
+
+func (x *Index) emit(event Event) {
+	for _, handler := range x.handlers {
+		handler(event)
+	}
+}
+
+// Len returns the number of items in the Index.
+func (x *Index) Len() int {
+	return size(x.root)
+}
+
+// Get returns the item with the given key, if any.
+func (x *Index) Get(key interface{}) (interface{}, bool) {
+	n, ok := x.nodes[key]
+	if !ok {
+		return nil, false
+	}
+	return n.item, true
+}
+
+// Position returns the position of the item with the given key or -1 if there is none.
+func (x *Index) Position(key interface{}) int {
+	n, ok := x.nodes[key]
+	if !ok {
+		return -1
+	}
+	return x.rank(n)
+}
+
+// At returns the key and item at position i. It panics if i is out of range.
+func (x *Index) At(i int) (key, item interface{}) {
+	if i < 0 || i >= x.Len() {
+		panic(fmt.Sprintf("ordindex: index %d out of range [0:%d]", i, x.Len()))
+	}
+	t := x.root
+	for {
+		switch l := size(t.left); {
+		case i < l:
+			t = t.left
+		case i > l:
+			i -= l + 1
+			t = t.right
+		default:
+			return t.key, t.item
+		}
+	}
+}
+
+// Range calls fn for every key and item in order. If fn returns false, Range stops.
+func (x *Index) Range(fn func(key, item interface{}) bool) {
+	rangeNode(x.root, fn)
+}
+
+func rangeNode(n *node, fn func(key, item interface{}) bool) bool {
+	if n == nil {
+		return true
+	}
+	return rangeNode(n.left, fn) && fn(n.key, n.item) && rangeNode(n.right, fn)
+}
+
+// Set adds the item with the given key or updates the item if the key is already present.
+// It emits an Added, Updated or Moved event.
+func (x *Index) Set(key, item interface{}) {
+	n, ok := x.nodes[key]
+	if !ok {
+		n = &node{key: key, item: item, seq: x.seq, priority: rand.Uint32(), size: 1}
+		x.seq++
+		x.nodes[key] = n
+		x.root = x.insert(x.root, n)
+		x.emit(Event{Type: Added, Key: key, Item: item, OldIndex: -1, NewIndex: x.rank(n)})
+		return
+	}
+
+	oldIndex := x.rank(n)
+	x.root = x.remove(x.root, n)
+	n.item, n.left, n.right, n.size = item, nil, nil, 1
+	x.root = x.insert(x.root, n)
+	newIndex := x.rank(n)
+
+	typ := Updated
+	if oldIndex != newIndex {
+		typ = Moved
+	}
+	x.emit(Event{Type: typ, Key: key, Item: item, OldIndex: oldIndex, NewIndex: newIndex})
+}
+
+// Delete removes the item with the given key and reports whether it was present.
+// If it was, a Removed event is emitted.
+func (x *Index) Delete(key interface{}) bool {
+	n, ok := x.nodes[key]
+	if !ok {
+		return false
+	}
+	oldIndex := x.rank(n)
+	x.root = x.remove(x.root, n)
+	delete(x.nodes, key)
+	x.emit(Event{Type: Removed, Key: key, Item: n.item, OldIndex: oldIndex, NewIndex: -1})
+	return true
+}
+
+// compare compares n1 against n2 by their items, falling back to their keys and sequence numbers.
+// As sequence numbers are unique, only the same nodes compare equal.
+func (x *Index) compare(n1, n2 *node) int {
+	if res := x.comparisons.DeepCompare(n1.item, n2.item); res != 0 {
+		return res
+	}
+	if res := x.comparisons.DeepCompare(n1.key, n2.key); res != 0 {
+		return res
+	}
+	switch {
+	case n1.seq < n2.seq:
+		return -1
+	case n1.seq > n2.seq:
+		return 1
+	}
+	return 0
+}
+
+func (x *Index) rank(n *node) int {
+	var r int
+	for t := x.root; t != nil; {
+		switch res := x.compare(n, t); {
+		case res < 0:
+			t = t.left
+		case res > 0:
+			r += size(t.left) + 1
+			t = t.right
+		default:
+			return r + size(t.left)
+		}
+	}
+	return -1
+}
+
+// split splits t into the nodes less than n and the nodes greater than or equal to n.
+func (x *Index) split(t, n *node) (l, r *node) {
+	if t == nil {
+		return nil, nil
+	}
+	if x.compare(t, n) < 0 {
+		t.right, r = x.split(t.right, n)
+		t.update()
+		return t, r
+	}
+	l, t.left = x.split(t.left, n)
+	t.update()
+	return l, t
+}
+
+// merge merges l and r, assuming all nodes of l are less than all nodes of r.
+func merge(l, r *node) *node {
+	switch {
+	case l == nil:
+		return r
+	case r == nil:
+		return l
+	case l.priority > r.priority:
+		l.right = merge(l.right, r)
+		l.update()
+		return l
+	default:
+		r.left = merge(l, r.left)
+		r.update()
+		return r
+	}
+}
+
+func (x *Index) insert(t, n *node) *node {
+	l, r := x.split(t, n)
+	return merge(merge(l, n), r)
+}
+
+func (x *Index) remove(t, n *node) *node {
+	if t == n {
+		return merge(t.left, t.right)
+	}
+	if x.compare(n, t) < 0 {
+		t.left = x.remove(t.left, n)
+	} else {
+		t.right = x.remove(t.right, n)
+	}
+	t.update()
+	return t
+}
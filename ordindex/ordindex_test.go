@@ -0,0 +1,190 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ordindex_test
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/adracus/reflcompare"
+	. "github.com/adracus/reflcompare/ordindex"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func keys(x *Index) []interface{} {
+	var res []interface{}
+	x.Range(func(key, _ interface{}) bool {
+		res = append(res, key)
+		return true
+	})
+	return res
+}
+
+var _ = Describe("Index", func() {
+	var (
+		x      *Index
+		events []Event
+	)
+	BeforeEach(func() {
+		x = New(make(reflcompare.Comparisons))
+		events = nil
+		x.AddHandler(func(event Event) {
+			events = append(events, event)
+		})
+	})
+
+	It("should keep the items sorted", func() {
+		x.Set("c", 3)
+		x.Set("a", 1)
+		x.Set("b", 2)
+		Expect(x.Len()).To(Equal(3))
+		Expect(keys(x)).To(Equal([]interface{}{"a", "b", "c"}))
+		Expect(events).To(Equal([]Event{
+			{Type: Added, Key: "c", Item: 3, OldIndex: -1, NewIndex: 0},
+			{Type: Added, Key: "a", Item: 1, OldIndex: -1, NewIndex: 0},
+			{Type: Added, Key: "b", Item: 2, OldIndex: -1, NewIndex: 1},
+		}))
+	})
+
+	It("should order equal items by their keys", func() {
+		x.Set("b", 1)
+		x.Set("a", 1)
+		Expect(keys(x)).To(Equal([]interface{}{"a", "b"}))
+	})
+
+	It("should order items with equal keys by the order they were added in", func() {
+		x = New(reflcompare.NewComparisonsOrDie(func(a, b string) int {
+			return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+		}))
+		var added []interface{}
+		for i := 0; i < 20; i++ {
+			// Keys differing in case only, e.g. 'aaAaa'.
+			key := []byte("aaaaa")
+			for j := range key {
+				if i&(1<<j) != 0 {
+					key[j] = 'A'
+				}
+			}
+			added = append(added, string(key))
+			x.Set(string(key), 1)
+		}
+		Expect(keys(x)).To(Equal(added))
+		for i, key := range added {
+			Expect(x.Position(key)).To(Equal(i))
+		}
+
+		x.Set(added[0], 1)
+		Expect(keys(x)).To(Equal(added))
+		for _, i := range rand.Perm(len(added)) {
+			Expect(x.Delete(added[i])).To(BeTrue())
+		}
+		Expect(x.Len()).To(Equal(0))
+	})
+
+	It("should emit updated and moved events", func() {
+		x.Set("a", 1)
+		x.Set("b", 2)
+		events = nil
+
+		x.Set("a", 0)
+		x.Set("a", 3)
+		Expect(keys(x)).To(Equal([]interface{}{"b", "a"}))
+		Expect(events).To(Equal([]Event{
+			{Type: Updated, Key: "a", Item: 0, OldIndex: 0, NewIndex: 0},
+			{Type: Moved, Key: "a", Item: 3, OldIndex: 0, NewIndex: 1},
+		}))
+	})
+
+	It("should delete items", func() {
+		x.Set("a", 1)
+		x.Set("b", 2)
+		events = nil
+
+		Expect(x.Delete("a")).To(BeTrue())
+		Expect(x.Delete("a")).To(BeFalse())
+		Expect(keys(x)).To(Equal([]interface{}{"b"}))
+		Expect(events).To(Equal([]Event{
+			{Type: Removed, Key: "a", Item: 1, OldIndex: 0, NewIndex: -1},
+		}))
+	})
+
+	It("should look up items by key and position", func() {
+		x.Set("a", 2)
+		x.Set("b", 1)
+
+		item, ok := x.Get("a")
+		Expect(ok).To(BeTrue())
+		Expect(item).To(Equal(2))
+		_, ok = x.Get("c")
+		Expect(ok).To(BeFalse())
+
+		Expect(x.Position("a")).To(Equal(1))
+		Expect(x.Position("c")).To(Equal(-1))
+
+		key, item := x.At(0)
+		Expect(key).To(Equal("b"))
+		Expect(item).To(Equal(1))
+		Expect(func() { x.At(2) }).To(Panic())
+	})
+
+	It("should stop ranging if the function returns false", func() {
+		x.Set("a", 1)
+		x.Set("b", 2)
+		var visited int
+		x.Range(func(_, _ interface{}) bool {
+			visited++
+			return false
+		})
+		Expect(visited).To(Equal(1))
+	})
+
+	It("should stay consistent under random operations", func() {
+		r := rand.New(rand.NewSource(0))
+		items := make(map[int]int)
+		for i := 0; i < 1000; i++ {
+			key := r.Intn(100)
+			if r.Intn(3) == 0 {
+				Expect(x.Delete(key)).To(Equal(items[key] != 0))
+				delete(items, key)
+				continue
+			}
+			item := r.Intn(50) + 1
+			x.Set(key, item)
+			items[key] = item
+		}
+
+		var expected []int
+		for key := range items {
+			expected = append(expected, key)
+		}
+		sort.Slice(expected, func(i, j int) bool {
+			ki, kj := expected[i], expected[j]
+			if items[ki] != items[kj] {
+				return items[ki] < items[kj]
+			}
+			return ki < kj
+		})
+
+		Expect(x.Len()).To(Equal(len(expected)))
+		for i, key := range expected {
+			k, item := x.At(i)
+			Expect(k).To(Equal(key))
+			Expect(item).To(Equal(items[key]))
+			Expect(x.Position(key)).To(Equal(i))
+		}
+	})
+})
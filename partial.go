@@ -0,0 +1,109 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// Ordering is the result of a partial comparison.
+type Ordering int
+
+const (
+	// LessThan indicates that the first value is less than the second one.
+	LessThan Ordering = -1
+	// EqualTo indicates that both values are equal.
+	EqualTo Ordering = 0
+	// GreaterThan indicates that the first value is greater than the second one.
+	GreaterThan Ordering = 1
+	// Incomparable indicates that there is no order between both values.
+	Incomparable Ordering = 2
+)
+
+// String implements fmt.Stringer.
+func (o Ordering) String() string {
+	switch o {
+	case LessThan:
+		return "LessThan"
+	case EqualTo:
+		return "EqualTo"
+	case GreaterThan:
+		return "GreaterThan"
+	case Incomparable:
+		return "Incomparable"
+	default:
+		return fmt.Sprintf("Ordering(%d)", int(o))
+	}
+}
+
+var orderingType = reflect.TypeOf(EqualTo)
+
+// incomparable is the internal comparison result for incomparable values.
+// As -incomparable == incomparable, it survives reversing comparison results.
+const incomparable = math.MinInt
+
+// toOrdering converts an internal comparison result to an Ordering.
+func toOrdering(res int) Ordering {
+	switch {
+	case res == incomparable:
+		return Incomparable
+	case res < 0:
+		return LessThan
+	case res > 0:
+		return GreaterThan
+	default:
+		return EqualTo
+	}
+}
+
+// fromOrdering converts an Ordering to an internal comparison result.
+func fromOrdering(o Ordering) int {
+	if o == Incomparable {
+		return incomparable
+	}
+	return int(o)
+}
+
+// totalOrder errors if res indicates that the values of type t are incomparable.
+func totalOrder(res int, t reflect.Type) (int, error) {
+	if res == incomparable {
+		return 0, fmt.Errorf("cannot totally order values of type %v: values are incomparable", t)
+	}
+	return res, nil
+}
+
+// checkTotal panics if res indicates that the values of type t are incomparable.
+func checkTotal(res int, t reflect.Type) int {
+	res, err := totalOrder(res, t)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// PartialCompare compares two values like DeepCompare, but allows for partial orders.
+//
+// Comparison functions of the signature func(A, A) Ordering may return Incomparable
+// for values without order between them. Composite values are compared lexicographically,
+// so they are Incomparable if the first unequal elements / fields are Incomparable.
+//
+// DeepCompare and all other APIs requiring a total order panic on Incomparable values.
+func (c Comparisons) PartialCompare(a1, a2 interface{}) Ordering {
+	t := c.newTraversal()
+	t.partial = true
+	return toOrdering(t.compare(a1, a2))
+}
@@ -0,0 +1,76 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+// Span is partially ordered: A span is less than another one if it ends before the other one starts.
+type Span struct {
+	Lo, Hi int
+}
+
+func compareSpans(s1, s2 Span) Ordering {
+	switch {
+	case s1 == s2:
+		return EqualTo
+	case s1.Hi < s2.Lo:
+		return LessThan
+	case s2.Hi < s1.Lo:
+		return GreaterThan
+	default:
+		return Incomparable
+	}
+}
+
+var _ = Describe("Partial", func() {
+	c := NewComparisonsOrDie(compareSpans)
+
+	DescribeTable("PartialCompare",
+		func(v1, v2 interface{}, expect Ordering) {
+			Expect(c.PartialCompare(v1, v2)).To(Equal(expect))
+			if expect != Incomparable {
+				Expect(c.PartialCompare(v2, v1)).To(Equal(-expect))
+			} else {
+				Expect(c.PartialCompare(v2, v1)).To(Equal(Incomparable))
+			}
+		},
+		Entry("less", Span{0, 1}, Span{2, 3}, LessThan),
+		Entry("equal", Span{0, 1}, Span{0, 1}, EqualTo),
+		Entry("incomparable", Span{0, 2}, Span{1, 3}, Incomparable),
+		Entry("lexicographic less", []Span{{0, 1}, {0, 2}}, []Span{{2, 3}, {1, 3}}, LessThan),
+		Entry("lexicographic incomparable", []Span{{0, 1}, {0, 2}}, []Span{{0, 1}, {1, 3}}, Incomparable),
+		Entry("incomparable via pointers", &[]Span{{0, 2}}, &[]Span{{1, 3}}, Incomparable),
+		Entry("totally ordered types", 1, 2, LessThan),
+		Entry("length", []int{1, 2, 3}, []int{1}, GreaterThan),
+	)
+
+	It("should panic on incomparable values when requiring a total order", func() {
+		Expect(c.DeepCompare(Span{0, 1}, Span{2, 3})).To(Equal(-1))
+		Expect(func() { c.DeepCompare(Span{0, 2}, Span{1, 3}) }).To(Panic())
+		Expect(func() {
+			c.SortTable([]Span{{0, 2}, {1, 3}}, []SortColumn{{Path: "Lo"}, {Path: "Hi"}})
+		}).NotTo(Panic())
+	})
+
+	It("should stringify orderings", func() {
+		Expect(Incomparable.String()).To(Equal("Incomparable"))
+		Expect(Ordering(5).String()).To(Equal("Ordering(5)"))
+	})
+})
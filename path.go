@@ -0,0 +1,236 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// fieldPath is a path of (possibly nested) struct fields, resolved against a type.
+// Each element is the index sequence of a field as used by reflect.Value.FieldByIndex.
+type fieldPath [][]int
+
+// resolveFieldPath resolves the given dot-separated path of field names, e.g.
+// 'Spec.Replicas', against t. Pointers to structs are dereferenced while walking the path.
+func resolveFieldPath(t reflect.Type, path string) (fieldPath, error) {
+	var res fieldPath
+	for _, name := range strings.Split(path, ".") {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("path %q: cannot select field %q of non-struct type %v", path, name, t)
+		}
+		f, ok := t.FieldByName(name)
+		if !ok {
+			return nil, fmt.Errorf("path %q: type %v has no field %q", path, t, name)
+		}
+		if f.PkgPath != "" {
+			return nil, fmt.Errorf("path %q: field %q of type %v is unexported", path, name, t)
+		}
+		res = append(res, f.Index)
+		t = f.Type
+	}
+	return res, nil
+}
+
+// get returns the field denoted by p of v. If a nil pointer (including nil embedded
+// struct pointers) is encountered while walking the path, the zero reflect.Value is returned.
+func (p fieldPath) get(v reflect.Value) reflect.Value {
+	for _, index := range p {
+		for _, i := range index {
+			for v.Kind() == reflect.Ptr {
+				if v.IsNil() {
+					return reflect.Value{}
+				}
+				v = v.Elem()
+			}
+			v = v.Field(i)
+		}
+	}
+	return v
+}
+
+// pathStep is a step from a value to a value nested in it: a struct field, an element
+// of an array or slice, or a map entry.
+type pathStep struct {
+	// field is the name of a struct field, if set.
+	field string
+	// key is the key of a map entry, if valid.
+	key reflect.Value
+	// index is the index of an element otherwise.
+	index int
+	// redacted masks key in reports.
+	redacted bool
+}
+
+func fieldElem(name string) pathStep {
+	return pathStep{field: name}
+}
+
+func indexElem(i int) pathStep {
+	return pathStep{index: i}
+}
+
+func keyElem(k reflect.Value) pathStep {
+	return pathStep{key: k}
+}
+
+func (s pathStep) String() string {
+	switch {
+	case s.field != "":
+		return fieldStep(s.field)
+	case s.key.IsValid():
+		return keyStep(s.key)
+	default:
+		return indexStep(s.index)
+	}
+}
+
+// segmentKind is the kind of a segment of a path pattern.
+type segmentKind int
+
+const (
+	// segmentField matches the struct field with the segment's name.
+	segmentField segmentKind = iota
+	// segmentAnyField matches any struct field ('*').
+	segmentAnyField
+	// segmentIndex matches the element with the segment's index ('[N]').
+	segmentIndex
+	// segmentAnyElem matches any element or map entry ('[*]').
+	segmentAnyElem
+	// segmentDeep matches any number of steps, including none ('**').
+	segmentDeep
+)
+
+type pathSegment struct {
+	kind  segmentKind
+	name  string
+	index int
+}
+
+func (s pathSegment) matches(step pathStep) bool {
+	switch s.kind {
+	case segmentField:
+		return step.field == s.name
+	case segmentAnyField:
+		return step.field != ""
+	case segmentIndex:
+		return step.field == "" && !step.key.IsValid() && step.index == s.index
+	default:
+		return step.field == ""
+	}
+}
+
+// maxPatternSegments is the maximum number of segments of a path pattern, so the states of
+// its matcher fit into a bit set.
+const maxPatternSegments = 63
+
+// pathPattern is a compiled path pattern, see AtPath. It is matched step by step, as a
+// nondeterministic automaton whose states are the numbers of segments matched so far.
+type pathPattern struct {
+	source   string
+	segments []pathSegment
+}
+
+// compilePathPattern compiles the given path pattern.
+func compilePathPattern(pattern string) (*pathPattern, error) {
+	p := &pathPattern{source: pattern}
+	rest := pattern
+	for first := true; rest != "" || first; first = false {
+		switch {
+		case strings.HasPrefix(rest, "["):
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("pattern %q: unterminated %q", pattern, rest)
+			}
+			seg := pathSegment{kind: segmentAnyElem}
+			if arg := rest[1:end]; arg != "*" {
+				index, err := strconv.Atoi(arg)
+				if err != nil || index < 0 {
+					return nil, fmt.Errorf("pattern %q: invalid index %q", pattern, arg)
+				}
+				seg = pathSegment{kind: segmentIndex, index: index}
+			}
+			p.segments = append(p.segments, seg)
+			rest = rest[end+1:]
+			continue
+		case !first:
+			if !strings.HasPrefix(rest, ".") {
+				return nil, fmt.Errorf("pattern %q: expected '.' or '[' at %q", pattern, rest)
+			}
+			rest = rest[1:]
+		}
+		end := strings.IndexAny(rest, ".[")
+		if end < 0 {
+			end = len(rest)
+		}
+		name := rest[:end]
+		switch name {
+		case "":
+			return nil, fmt.Errorf("pattern %q: empty field name", pattern)
+		case "*":
+			p.segments = append(p.segments, pathSegment{kind: segmentAnyField})
+		case "**":
+			p.segments = append(p.segments, pathSegment{kind: segmentDeep})
+		default:
+			p.segments = append(p.segments, pathSegment{kind: segmentField, name: name})
+		}
+		rest = rest[end:]
+	}
+	if len(p.segments) > maxPatternSegments {
+		return nil, fmt.Errorf("pattern %q: more than %d segments", pattern, maxPatternSegments)
+	}
+	return p, nil
+}
+
+// start returns the states of the matcher before taking any step.
+func (p *pathPattern) start() uint64 {
+	return p.closure(1)
+}
+
+// closure adds the states reachable without taking a step to states.
+func (p *pathPattern) closure(states uint64) uint64 {
+	for i, seg := range p.segments {
+		if seg.kind == segmentDeep && states&(1<<uint(i)) != 0 {
+			states |= 1 << uint(i+1)
+		}
+	}
+	return states
+}
+
+// step returns the states of the matcher after taking the step s from states.
+func (p *pathPattern) step(states uint64, s pathStep) uint64 {
+	var next uint64
+	for i, seg := range p.segments {
+		if states&(1<<uint(i)) == 0 {
+			continue
+		}
+		if seg.kind == segmentDeep {
+			next |= 1 << uint(i)
+		} else if seg.matches(s) {
+			next |= 1 << uint(i+1)
+		}
+	}
+	return p.closure(next)
+}
+
+// accepts reports whether states include the state of having matched all segments.
+func (p *pathPattern) accepts(states uint64) bool {
+	return states&(1<<uint(len(p.segments))) != 0
+}
@@ -0,0 +1,48 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"reflect"
+)
+
+// Project extracts the given fields of v into a tuple that can be compared via
+// DeepCompare. Fields are specified as dot-separated paths of field names, e.g.
+// 'Spec.Replicas'. If a nil pointer is encountered on a path, the tuple holds nil
+// for that field, which is less than any non-nil value.
+//
+// Project panics if any path does not denote an exported field of v.
+func (c Comparisons) Project(v interface{}, fields ...string) interface{} {
+	rv := reflect.ValueOf(v)
+	tuple := make([]interface{}, len(fields))
+	for i, field := range fields {
+		path, err := resolveFieldPath(rv.Type(), field)
+		if err != nil {
+			panic(err)
+		}
+		if fv := path.get(rv); fv.IsValid() {
+			tuple[i] = fv.Interface()
+		}
+	}
+	return tuple
+}
+
+// CompareProjected compares the given fields of a1 and a2 in order. It is
+// equivalent to comparing the projections of a1 and a2 via DeepCompare.
+//
+// See Project for the format of fields.
+func (c Comparisons) CompareProjected(a1, a2 interface{}, fields ...string) int {
+	return c.DeepCompare(c.Project(a1, fields...), c.Project(a2, fields...))
+}
@@ -0,0 +1,77 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type Spec struct {
+	Replicas *int
+	Image    string
+}
+
+type Object struct {
+	Name   string
+	Labels map[string]string
+	Spec   *Spec
+	hidden int
+}
+
+var _ = Describe("Project", func() {
+	var c Comparisons
+	BeforeEach(func() {
+		c = make(Comparisons)
+	})
+
+	Describe("Project", func() {
+		It("should extract the given fields", func() {
+			obj := Object{Name: "foo", Spec: &Spec{Replicas: intPtr(2)}}
+			Expect(c.Project(obj, "Name", "Spec.Replicas")).To(Equal([]interface{}{"foo", intPtr(2)}))
+		})
+
+		It("should extract nil for nil pointers on the path", func() {
+			Expect(c.Project(&Object{}, "Spec.Image")).To(Equal([]interface{}{nil}))
+		})
+
+		It("should panic on unknown fields", func() {
+			Expect(func() { c.Project(Object{}, "Spec.Unknown") }).To(Panic())
+		})
+
+		It("should panic on unexported fields", func() {
+			Expect(func() { c.Project(Object{}, "hidden") }).To(Panic())
+		})
+
+		It("should panic on selecting fields of non-structs", func() {
+			Expect(func() { c.Project(Object{}, "Name.Length") }).To(Panic())
+		})
+	})
+
+	Describe("CompareProjected", func() {
+		It("should only compare the given fields", func() {
+			o1 := Object{Name: "a", Labels: map[string]string{"foo": "bar"}, Spec: &Spec{Replicas: intPtr(2)}}
+			o2 := Object{Name: "a", Spec: &Spec{Replicas: intPtr(3)}}
+			Expect(c.CompareProjected(o1, o2, "Name")).To(Equal(0))
+			Expect(c.CompareProjected(o1, o2, "Name", "Spec.Replicas")).To(Equal(-1))
+			Expect(c.CompareProjected(o2, o1, "Name", "Spec.Replicas")).To(Equal(1))
+		})
+
+		It("should order nil pointers on the path first", func() {
+			Expect(c.CompareProjected(Object{}, Object{Spec: &Spec{}}, "Spec.Image")).To(Equal(-1))
+		})
+	})
+})
@@ -0,0 +1,51 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+// Range is a range of values. By default, both bounds are inclusive.
+type Range struct {
+	// Lo is the lower bound of the range. If nil, the range is unbounded below.
+	Lo interface{}
+	// Hi is the upper bound of the range. If nil, the range is unbounded above.
+	Hi interface{}
+	// ExcludeLo excludes Lo from the range.
+	ExcludeLo bool
+	// ExcludeHi excludes Hi from the range.
+	ExcludeHi bool
+}
+
+// InRange reports whether v is within the range r, comparing v to the bounds of r via DeepCompare.
+func (c Comparisons) InRange(v interface{}, r Range) bool {
+	t := c.newTraversal()
+	if r.Lo != nil {
+		res := t.compare(v, r.Lo)
+		if res < 0 || (res == 0 && r.ExcludeLo) {
+			return false
+		}
+	}
+	if r.Hi != nil {
+		res := t.compare(v, r.Hi)
+		if res > 0 || (res == 0 && r.ExcludeHi) {
+			return false
+		}
+	}
+	return true
+}
+
+// Between reports whether v is between lo and hi. If inclusive is true, lo and hi
+// are part of the range. See InRange for more details.
+func (c Comparisons) Between(v, lo, hi interface{}, inclusive bool) bool {
+	return c.InRange(v, Range{Lo: lo, Hi: hi, ExcludeLo: !inclusive, ExcludeHi: !inclusive})
+}
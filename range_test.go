@@ -0,0 +1,64 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"time"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Range", func() {
+	c := make(Comparisons)
+
+	DescribeTable("InRange",
+		func(v interface{}, r Range, expect bool) {
+			Expect(c.InRange(v, r)).To(Equal(expect))
+		},
+		Entry("within", 2, Range{Lo: 1, Hi: 3}, true),
+		Entry("inclusive lower bound", 1, Range{Lo: 1, Hi: 3}, true),
+		Entry("inclusive upper bound", 3, Range{Lo: 1, Hi: 3}, true),
+		Entry("exclusive lower bound", 1, Range{Lo: 1, Hi: 3, ExcludeLo: true}, false),
+		Entry("exclusive upper bound", 3, Range{Lo: 1, Hi: 3, ExcludeHi: true}, false),
+		Entry("below", 0, Range{Lo: 1, Hi: 3}, false),
+		Entry("above", 4, Range{Lo: 1, Hi: 3}, false),
+		Entry("unbounded below", -100, Range{Hi: 3}, true),
+		Entry("unbounded above", 100, Range{Lo: 1}, true),
+		Entry("unbounded", "foo", Range{}, true),
+		Entry("composite values", []int{1, 2}, Range{Lo: []int{1, 1}, Hi: []int{1, 3}}, true),
+	)
+
+	DescribeTable("Between",
+		func(v, lo, hi interface{}, inclusive, expect bool) {
+			Expect(c.Between(v, lo, hi, inclusive)).To(Equal(expect))
+		},
+		Entry("inclusive", "b", "a", "b", true, true),
+		Entry("exclusive", "b", "a", "b", false, false),
+		Entry("exclusive within", "b", "a", "c", false, true),
+	)
+
+	It("should use comparison functions", func() {
+		c := NewComparisonsOrDie(CompareTime)
+		now := time.Now()
+		Expect(c.Between(now, now.Add(-time.Hour), now.Add(time.Hour), false)).To(BeTrue())
+	})
+
+	It("should panic on values of different types", func() {
+		Expect(func() { c.Between(1, "a", "b", true) }).To(Panic())
+	})
+})
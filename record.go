@@ -0,0 +1,71 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Record is implemented by adapters of generic records whose fields are described by a
+// schema instead of a Go struct type, e.g. Avro generic records or Arrow rows.
+// Values implementing Record are compared field by field in schema order, like structs.
+type Record interface {
+	// NumFields returns the number of fields of the record's schema.
+	NumFields() int
+	// FieldName returns the name of the i-th field of the record's schema.
+	FieldName(i int) string
+	// Field returns the value of the i-th field, or nil if it is null.
+	Field(i int) interface{}
+}
+
+var recordType = reflect.TypeOf((*Record)(nil)).Elem()
+
+// isRecord reports whether v is a non-nil value implementing Record.
+func isRecord(v reflect.Value) bool {
+	switch {
+	case v.Kind() == reflect.Interface || !v.CanInterface() || !v.Type().Implements(recordType):
+		return false
+	case v.Kind() == reflect.Ptr:
+		// Nil pointers are ordered first, without calling any method.
+		return !v.IsNil()
+	}
+	return true
+}
+
+// compareRecords compares the records r1 and r2 field by field. It errors with ErrTypeMismatch
+// if their schemas have different fields.
+func (t *traversal) compareRecords(r1, r2 Record, depth int) (int, error) {
+	if r1.NumFields() != r2.NumFields() {
+		return 0, fmt.Errorf("%w: records with different numbers of fields: %d - %d", ErrTypeMismatch, r1.NumFields(), r2.NumFields())
+	}
+	for i := 0; i < r1.NumFields(); i++ {
+		name := r1.FieldName(i)
+		if name2 := r2.FieldName(i); name != name2 {
+			return 0, fmt.Errorf("%w: records with different fields at %d: %s - %s", ErrTypeMismatch, i, name, name2)
+		}
+		f1, f2 := r1.Field(i), r2.Field(i)
+		// Compare the fields as interface values, so null fields are ordered first.
+		res, err := t.deepValueCompareAt(fieldElem(name), reflect.ValueOf(&f1).Elem(), reflect.ValueOf(&f2).Elem(), depth+1)
+		if err != nil {
+			return 0, prependStep(err, fieldStep(name))
+		}
+		if res != 0 {
+			t.stepField(name)
+			return res, nil
+		}
+	}
+	return 0, nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"errors"
+	"sort"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// GenericRecord is a record whose fields are described by a schema of field names.
+type GenericRecord struct {
+	schema []string
+	values []interface{}
+}
+
+func (r *GenericRecord) NumFields() int          { return len(r.schema) }
+func (r *GenericRecord) FieldName(i int) string  { return r.schema[i] }
+func (r *GenericRecord) Field(i int) interface{} { return r.values[i] }
+
+var _ = Describe("Record", func() {
+	schema := []string{"id", "name"}
+	record := func(values ...interface{}) *GenericRecord {
+		return &GenericRecord{schema: schema, values: values}
+	}
+	c := make(Comparisons)
+
+	It("should compare records field by field", func() {
+		Expect(c.DeepCompare(record(1, "b"), record(1, "b"))).To(Equal(0))
+		Expect(c.DeepCompare(record(1, "b"), record(2, "a"))).To(Equal(-1))
+		Expect(c.DeepCompare(record(1, "b"), record(1, "a"))).To(Equal(1))
+	})
+
+	It("should order null fields first", func() {
+		Expect(c.DeepCompare(record(1, nil), record(1, "a"))).To(Equal(-1))
+	})
+
+	It("should sort records", func() {
+		records := []Record{record(2, "a"), record(1, "b"), record(1, "a")}
+		sort.Slice(records, func(i, j int) bool { return c.DeepCompare(records[i], records[j]) < 0 })
+		Expect(records).To(Equal([]Record{record(1, "a"), record(1, "b"), record(2, "a")}))
+	})
+
+	It("should explain which field decided", func() {
+		Expect(c.Explain(record(1, "b"), record(1, "a")).Path).To(Equal(".name"))
+	})
+
+	It("should error on records of different schemas", func() {
+		_, err := c.TryDeepCompare(record(1, "a"), &GenericRecord{schema: []string{"id", "title"}, values: []interface{}{1, "a"}})
+		Expect(errors.Is(err, ErrTypeMismatch)).To(BeTrue())
+		_, err = c.TryDeepCompare(record(1, "a"), &GenericRecord{schema: []string{"id"}, values: []interface{}{1}})
+		Expect(errors.Is(err, ErrTypeMismatch)).To(BeTrue())
+	})
+})
@@ -0,0 +1,127 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Redacted replaces redacted values in reports unless a Redactor is set via WithRedactor.
+const Redacted = "<redacted>"
+
+// Redactor masks a redacted value in reports, e.g. by replacing it with a fingerprint.
+type Redactor func(v interface{}) interface{}
+
+// Redact masks the values the option applies to in reports, i.e. in the values and paths of
+// differences found by Diff, in the paths reported by Explain and in the paths of errors.
+// Use it with AtPath or ForType to select the values to mask, e.g. AtPath("**.Password", Redact()).
+// The values of fields tagged with `compare:"redact"` are always masked.
+//
+// Values reported as a whole, e.g. by Diff for values with comparison functions, are masked
+// as a whole if they contain values of fields tagged with `compare:"redact"`. Values selected
+// via AtPath or ForType below them are not detected, though.
+func Redact() Option {
+	return func(o *options) {
+		o.redact = true
+	}
+}
+
+// WithRedactor sets the Redactor masking redacted values in reports. By default, redacted
+// values are replaced by Redacted.
+func WithRedactor(r Redactor) Option {
+	return func(o *options) {
+		o.redactor = r
+	}
+}
+
+// redactedChild returns the scope for values of redacted fields nested in s.
+func (s *scope) redactedChild() *scope {
+	if s.redacted == nil {
+		opts := s.options.clone()
+		opts.redact = true
+		s.redacted = s.newChild(opts)
+	}
+	return s.redacted
+}
+
+// mask returns the masked value of v.
+func (t *traversal) mask(v interface{}) interface{} {
+	if t.redactor == nil {
+		return Redacted
+	}
+	return t.redactor(v)
+}
+
+// keyStep returns the path step to the map entry with key k, masking k if redacted.
+func (t *traversal) keyStep(k reflect.Value) string {
+	if t.redact {
+		return fmt.Sprintf("[%#v]", t.mask(interfaceOf(k)))
+	}
+	return t.renderKeyStep(k)
+}
+
+// renderKeyStep returns the path step to the map entry with key k, rendering k by its
+// formatter or truncating it if it exceeds the limits set via Truncate.
+func (t *traversal) renderKeyStep(k reflect.Value) string {
+	if f, ok := t.formatted(k); ok {
+		return fmt.Sprintf("[%#v]", f)
+	}
+	if tr, ok := t.truncated(k); ok {
+		return fmt.Sprintf("[%#v]", tr)
+	}
+	return keyStep(k)
+}
+
+// reportValue returns v as an interface{} to report, masked if redacted or if it contains
+// values of redacted fields, rendered by its formatter or truncated if it exceeds the limits
+// set via Truncate.
+func (t *traversal) reportValue(v reflect.Value) interface{} {
+	res := interfaceOf(v)
+	if res != nil && (t.redact || t.containsRedacted(v.Type())) {
+		return t.mask(res)
+	}
+	if f, ok := t.formatted(v); ok {
+		return f
+	}
+	if tr, ok := t.truncated(v); ok {
+		return tr
+	}
+	return res
+}
+
+// containsRedacted reports whether values of typ can contain values of fields tagged with
+// `compare:"redact"`.
+func (t *traversal) containsRedacted(typ reflect.Type) bool {
+	if res, ok := t.redactedTypes[typ]; ok {
+		return res
+	}
+	if t.redactedTypes == nil {
+		t.redactedTypes = make(map[reflect.Type]bool)
+	}
+	res := false
+	nested, _ := nestedTypes(typ)
+	for n := range nested {
+		if n.Kind() != reflect.Struct {
+			continue
+		}
+		for i := 0; i < n.NumField() && !res; i++ {
+			tag, err := parseTagOf(t.fieldTags, n, n.Field(i))
+			res = err == nil && tag.redact
+		}
+	}
+	t.redactedTypes[typ] = res
+	return res
+}
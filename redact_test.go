@@ -0,0 +1,119 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"fmt"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type Credentials struct {
+	User     string
+	Password string            `compare:"redact"`
+	Tokens   map[string]int    `compare:"redact"`
+	Hooks    map[string]func() `compare:"redact"`
+}
+
+type Account struct {
+	Name        string
+	Credentials Credentials
+}
+
+var _ = Describe("Redact", func() {
+	c := make(Comparisons)
+
+	It("should mask values of fields tagged with redact", func() {
+		diffs, err := c.Diff(
+			Credentials{User: "a", Password: "hunter2", Tokens: map[string]int{"ci": 1}},
+			Credentials{User: "b", Password: "swordfish", Tokens: map[string]int{"ci": 2}},
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diffs).To(Equal([]Difference{
+			{Path: ".User", A: "a", B: "b", Result: -1, Comparator: "string"},
+			{Path: ".Password", A: Redacted, B: Redacted, Result: -1, Comparator: "string"},
+			{Path: `.Tokens["<redacted>"]`, A: Redacted, B: Redacted, Result: -1, Comparator: "int"},
+		}))
+	})
+
+	It("should mask values containing redacted fields reported as a whole", func() {
+		diffs, err := c.Diff([]Credentials{{User: "a"}}, []Credentials{{User: "a"}, {User: "b"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diffs).To(Equal([]Difference{
+			{Path: "[1]", B: Redacted, Result: -1, Comparator: "length"},
+		}))
+	})
+
+	It("should mask values selected via AtPath", func() {
+		diffs, err := c.Diff(
+			Account{Name: "a", Credentials: Credentials{User: "a"}},
+			Account{Name: "b", Credentials: Credentials{User: "b"}},
+			AtPath("**.User", Redact()),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diffs).To(Equal([]Difference{
+			{Path: ".Name", A: "a", B: "b", Result: -1, Comparator: "string"},
+			{Path: ".Credentials.User", A: Redacted, B: Redacted, Result: -1, Comparator: "string"},
+		}))
+	})
+
+	It("should mask values using the redactor", func() {
+		fingerprint := func(v interface{}) interface{} { return fmt.Sprintf("len=%d", len(v.(string))) }
+		diffs, err := c.Diff(
+			Account{Credentials: Credentials{Password: "hunter2"}},
+			Account{Credentials: Credentials{Password: "swordfish"}},
+			WithRedactor(fingerprint),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diffs).To(Equal([]Difference{
+			{Path: ".Credentials.Password", A: "len=7", B: "len=9", Result: -1, Comparator: "string"},
+		}))
+	})
+
+	It("should mask keys in explanations", func() {
+		res := c.Explain(Credentials{Tokens: map[string]int{"ci": 1}}, Credentials{Tokens: map[string]int{"ci": 2}})
+		Expect(res).To(Equal(Result{Value: -1, Path: `.Tokens["<redacted>"]`, Comparator: "int"}))
+	})
+
+	It("should mask keys in errors", func() {
+		c1 := Credentials{Hooks: map[string]func(){"deploy": func() {}}}
+		c2 := Credentials{Hooks: map[string]func(){"deploy": func() {}}}
+		_, err := c.Diff(c1, c2)
+		Expect(err).To(MatchError(ContainSubstring(`.Hooks["<redacted>"]`)))
+		Expect(err.Error()).NotTo(ContainSubstring("deploy"))
+
+		cmp := c.NewComparer()
+		var msg string
+		func() {
+			defer func() { msg = fmt.Sprint(recover()) }()
+			cmp.Compare(c1, c2)
+		}()
+		Expect(msg).To(ContainSubstring(`.Hooks["<redacted>"]`))
+		Expect(msg).NotTo(ContainSubstring("deploy"))
+	})
+
+	It("should not mask values in JSON patches", func() {
+		ops, err := c.JSONPatch(Credentials{Password: "hunter2"}, Credentials{Password: "swordfish"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ops).To(HaveLen(1))
+		Expect(ops[0].Value).To(Equal("swordfish"))
+	})
+
+	It("should panic when registering a comparison named redact", func() {
+		Expect(func() { TagComparison("redact", func(a, b string) int { return 0 }) }).To(Panic())
+	})
+})
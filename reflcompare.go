@@ -16,6 +16,8 @@
 package reflcompare
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"reflect"
 	"strings"
@@ -41,9 +43,22 @@ func (c Comparisons) AddFuncs(funcs ...interface{}) error {
 
 // AddFunc adds the given function as a comparison function.
 // The function has to have a signature of func(A, A) int where A can be any type.
+// For partial orders, the signature func(A, A) Ordering is accepted as well, see PartialCompare.
 // If the function does not match that signature, an error is returned.
 func (c Comparisons) AddFunc(compFunc interface{}) error {
 	fv := reflect.ValueOf(compFunc)
+	if err := checkFunc(fv); err != nil {
+		return err
+	}
+	c[fv.Type().In(0)] = fv
+	return nil
+}
+
+// checkFunc checks that fv is a comparison function of the signature func(A, A) int.
+func checkFunc(fv reflect.Value) error {
+	if !fv.IsValid() {
+		return fmt.Errorf("expected func, got: nil")
+	}
 	ft := fv.Type()
 	if ft.Kind() != reflect.Func {
 		return fmt.Errorf("expected func, got: %v", ft)
@@ -57,15 +72,50 @@ func (c Comparisons) AddFunc(compFunc interface{}) error {
 	if ft.In(0) != ft.In(1) {
 		return fmt.Errorf("expected arg 1 and 2 to have same type, but got %v", ft)
 	}
-	var forReturnType int
-	intType := reflect.TypeOf(forReturnType)
-	if ft.Out(0) != intType {
-		return fmt.Errorf("expected bool return, got: %v", ft)
+	if ft.Out(0) != intType && ft.Out(0) != orderingType {
+		return fmt.Errorf("expected int or Ordering return, got: %v", ft)
 	}
-	c[ft.In(0)] = fv
 	return nil
 }
 
+// AdaptFunc adapts the given comparison function of signature func(A, A) int to
+// a comparison function of signature func(B, B) int where B is the type of sample.
+// B has to be convertible to A, which is e.g. the case if B is a named type with A
+// as underlying type. The result can be added via Comparisons.AddFunc.
+//
+// This allows reusing comparison functions like CompareUUID for custom types:
+//
+//	type UUID [16]byte
+//	f, err := AdaptFunc(CompareUUID, UUID{})
+func AdaptFunc(compFunc interface{}, sample interface{}) (interface{}, error) {
+	fv := reflect.ValueOf(compFunc)
+	if err := checkFunc(fv); err != nil {
+		return nil, err
+	}
+	if sample == nil {
+		return nil, fmt.Errorf("expected sample value, got: nil")
+	}
+	from := fv.Type().In(0)
+	to := reflect.TypeOf(sample)
+	if !to.ConvertibleTo(from) {
+		return nil, fmt.Errorf("cannot convert %v to %v", to, from)
+	}
+	ft := reflect.FuncOf([]reflect.Type{to, to}, []reflect.Type{fv.Type().Out(0)}, false)
+	return reflect.MakeFunc(ft, func(args []reflect.Value) []reflect.Value {
+		return fv.Call([]reflect.Value{args[0].Convert(from), args[1].Convert(from)})
+	}).Interface(), nil
+}
+
+// AdaptFuncOrDie adapts the given comparison function to the type of sample.
+// If the function cannot be adapted, it panics. See AdaptFunc for more details.
+func AdaptFuncOrDie(compFunc interface{}, sample interface{}) interface{} {
+	f, err := AdaptFunc(compFunc, sample)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
 // Below here is forked from go's reflect/deepequal.go
 
 // During deepValueEqual, must keep track of checks that are
@@ -78,30 +128,6 @@ type visit struct {
 	typ reflect.Type
 }
 
-// unexportedTypePanic is thrown when you use this DeepEqual on something that has an
-// unexported type. It indicates a programmer error, so should not occur at runtime,
-// which is why it's not public and thus impossible to catch.
-type unexportedTypePanic []reflect.Type
-
-func (u unexportedTypePanic) Error() string { return u.String() }
-func (u unexportedTypePanic) String() string {
-	strs := make([]string, len(u))
-	for i, t := range u {
-		strs[i] = fmt.Sprintf("%v", t)
-	}
-	return "an unexported field was encountered, nested like this: " + strings.Join(strs, " -> ")
-}
-
-func makeUsefulPanic(v reflect.Value) {
-	if x := recover(); x != nil {
-		if u, ok := x.(unexportedTypePanic); ok {
-			u = append(unexportedTypePanic{v.Type()}, u...)
-			x = u
-		}
-		panic(x)
-	}
-}
-
 func compareBool(b1, b2 bool) int {
 	if b1 {
 		if !b2 {
@@ -115,20 +141,152 @@ func compareBool(b1, b2 bool) int {
 	return 0
 }
 
-// deep compare values using reflected types. The map argument tracks
-// comparisons that have already been seen, which allows short circuiting on
+// traversal holds the state for deep comparing values.
+type traversal struct {
+	options
+	comparisons Comparisons
+	// parents are the Comparisons to fall back to, see NewComparisonsWithParent.
+	parents []Comparisons
+	// visited tracks comparisons that have already been seen.
+	visited map[visit]int
+	// seen, if set, tracks the comparisons that have been entered instead of visited,
+	// assuming them equal when encountered again. It is only used to check for equality.
+	seen map[visit]struct{}
+	// structs caches the fields to compare per struct type, for the current scope.
+	structs map[reflect.Type][]structField
+	// incomparable caches the struct types whose values cannot be compared, for the current scope.
+	incomparable map[reflect.Type]*UnexportedFieldError
+	// scope is the current scope, see ForType.
+	scope *scope
+	// field is the struct field holding the next compared value, if any.
+	field structField
+	// derefs is the number of pointers of a pointer chain dereferenced to reach the next compared values.
+	derefs int
+	// redactedTypes caches whether types contain values of redacted fields.
+	redactedTypes map[reflect.Type]bool
+	// paths, if set, tracks the path of the compared values for path scopes, see AtPath.
+	paths *pathTracker
+	// bypass, if set, is the type whose comparison function is bypassed, see compareBypassing.
+	bypass reflect.Type
+	// partial allows incomparable results.
+	partial bool
+	// ctx, if set, stops the traversal when done, see CompareContext.
+	ctx context.Context
+	// done is the done channel of ctx.
+	done <-chan struct{}
+	// provenance, if set, records what decided the comparison.
+	provenance *provenance
+	// stats accumulates statistics about the traversal.
+	stats Stats
+}
+
+func (c Comparisons) newTraversal(opts ...Option) *traversal {
+	t := &traversal{
+		comparisons: c,
+		visited:     make(map[visit]int),
+	}
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	t.setScope(newScope(o))
+	if len(o.atPaths) > 0 {
+		t.paths = newPathTracker(o.atPaths)
+		// Path scopes may match the compared values themselves.
+		for i, ps := range o.atPaths {
+			if ps.pattern.accepts(ps.pattern.start()) {
+				t.setScope(t.scope.pathChild(i, ps.opts))
+			}
+		}
+	}
+	return t
+}
+
+// reset clears the visited comparisons of the traversal while retaining the map's capacity.
+// This has to be done whenever the compared values may have been modified.
+func (t *traversal) reset() {
+	for v := range t.visited {
+		delete(t.visited, v)
+	}
+}
+
+// deep compare values using reflected types. The visited map of the traversal
+// tracks comparisons that have already been seen, which allows short circuiting on
 // recursive types.
-func (c Comparisons) deepValueCompare(v1, v2 reflect.Value, visited map[visit]int, depth int) (res int) {
-	defer makeUsefulPanic(v1)
+//
+// Instead of panicking, deepValueCompare returns an error if the values cannot be compared.
+// Comparison functions may still panic, though.
+func (t *traversal) deepValueCompare(v1, v2 reflect.Value, depth int) (res int, err error) {
+	derefs := t.derefs
+	t.derefs = 0
+	if t.provenance != nil {
+		defer func() { t.settle(res, v1, v2, derefs) }()
+	}
+	t.stats.enter(depth)
+	field := t.field
+	t.field = structField{}
+	if field.redact && !t.redact {
+		prev := t.scope
+		t.setScope(prev.redactedChild())
+		defer t.setScope(prev)
+	}
+	if t.maxDepth > 0 && depth > t.maxDepth {
+		return 0, &pathError{err: fmt.Errorf("%w (%d)", ErrDepthExceeded, t.maxDepth)}
+	}
+	if t.done != nil {
+		if err := t.interrupted(); err != nil {
+			return 0, err
+		}
+	}
 
 	if !v1.IsValid() || !v2.IsValid() {
-		return compareBool(v1.IsValid(), v2.IsValid())
+		return compareBool(v1.IsValid(), v2.IsValid()), nil
 	}
 	if v1.Type() != v2.Type() {
-		panic(fmt.Sprintf("cannot compare different types: %s - %s", v1.Type(), v2.Type()))
+		if t.debugTotalOrder {
+			return compareTypes(v1.Type(), v2.Type()), nil
+		}
+		return 0, &typeMismatchError{t1: v1.Type(), t2: v2.Type()}
+	}
+	v1, v2 = t.applyDefaults(field, v1, v2)
+	v1, v2 = t.expose(v1), t.expose(v2)
+	if field.compare.IsValid() {
+		// Values of fields with comparison functions, e.g. named by tags, are compared
+		// like when comparing the struct holding them.
+		return t.callFieldFunc(field.compare, v1, v2, depth)
+	}
+	t.resolveTypeScopes(v1.Type())
+	if _, ok := t.scoped[v1.Type()]; ok && !t.scope.entered[v1.Type()] {
+		prev := t.scope
+		t.setScope(prev.child(v1.Type()))
+		defer t.setScope(prev)
+	}
+	if t.markers[v1.Type()] {
+		return 0, nil
+	}
+	if fv, ok := t.versions[v1.Type()]; ok && sameVersion(fv, v1, v2) {
+		return 0, nil
 	}
-	if fv, ok := c[v1.Type()]; ok {
-		return int(fv.Call([]reflect.Value{v1, v2})[0].Int())
+	defer func() {
+		if u, ok := err.(*UnexportedFieldError); ok {
+			u.Types = append([]reflect.Type{v1.Type()}, u.Types...)
+		}
+	}()
+	if fv, ok := t.lookup(v1.Type()); ok && v1.Type() != t.bypass {
+		if ok, err := t.canCall(v1, v2); !ok {
+			return 0, err
+		}
+		t.stats.OverrideHits++
+		return t.callCompare(fv, v1, v2, depth)
+	}
+	if res, ok := t.compareCustom(v1, v2); ok {
+		return res, nil
+	}
+	if isRecord(v1) {
+		return t.compareRecords(v1.Interface().(Record), v2.Interface().(Record), depth)
+	}
+	if t.opaque[v1.Type()] || v1.Kind() == reflect.Struct && isOpaqueType(v1.Type()) {
+		return compareIdentities(v1, v2), nil
 	}
 
 	hard := func(k reflect.Kind) bool {
@@ -151,24 +309,36 @@ func (c Comparisons) deepValueCompare(v1, v2 reflect.Value, visited map[visit]in
 
 		// Short circuit if references are identical ...
 		if addr1 == addr2 {
-			return 0
+			return 0, nil
 		}
 
 		// ... or already seen
 		typ := v1.Type()
 		v := visit{addr1, addr2, typ}
-		if res, ok := visited[v]; ok {
-			return res
-		}
-
-		defer func() {
-			// Remember for later.
-			cache := res
-			if swapped {
-				cache = -cache
+		if t.seen != nil {
+			// Checking for equality stops at the first difference, so comparisons encountered
+			// again are either in progress or found equal. Either way, they can be assumed equal.
+			if _, ok := t.seen[v]; ok {
+				return 0, nil
+			}
+			t.seen[v] = struct{}{}
+		} else {
+			if res, ok := t.visited[v]; ok {
+				return res, nil
 			}
-			visited[v] = cache
-		}()
+
+			defer func() {
+				if err != nil {
+					return
+				}
+				// Remember for later.
+				cache := res
+				if swapped {
+					cache = -cache
+				}
+				t.visited[v] = cache
+			}()
+		}
 	}
 
 	switch v1.Kind() {
@@ -176,87 +346,242 @@ func (c Comparisons) deepValueCompare(v1, v2 reflect.Value, visited map[visit]in
 		// We don't need to check length here because length is part of
 		// an array's type, which has already been filtered for.
 		for i := 0; i < v1.Len(); i++ {
-			if res := c.deepValueCompare(v1.Index(i), v2.Index(i), visited, depth+1); res != 0 {
-				return res
+			res, err := t.deepValueCompareAt(indexElem(i), v1.Index(i), v2.Index(i), depth+1)
+			if err != nil {
+				return 0, prependStep(err, indexStep(i))
+			}
+			if res != 0 {
+				t.stepIndex(i)
+				return res, nil
 			}
 		}
-		return 0
+		return 0, nil
 	case reflect.Slice:
 		if (v1.IsNil() || v1.Len() == 0) != (v2.IsNil() || v2.Len() == 0) {
-			return 0
+			return 0, nil
 		}
 		if res := v1.Len() - v2.Len(); res != 0 {
-			return res
+			return res, nil
 		}
 		if v1.Pointer() == v2.Pointer() {
-			return 0
+			return 0, nil
+		}
+		if key := t.mergeKey(v1.Type(), field.mergeKey); key != nil {
+			return t.compareKeyedLists(v1, v2, key, depth)
+		}
+		if elem := v1.Type().Elem(); elem.Kind() == reflect.Uint8 && t.plain(elem) && !t.defaults[elem].IsValid() {
+			// Fast path: Compare byte slices without reflecting on each element.
+			return bytes.Compare(v1.Bytes(), v2.Bytes()), nil
 		}
 		for i := 0; i < v1.Len(); i++ {
-			if res := c.deepValueCompare(v1.Index(i), v2.Index(i), visited, depth+1); res != 0 {
-				return res
+			res, err := t.deepValueCompareAt(indexElem(i), v1.Index(i), v2.Index(i), depth+1)
+			if err != nil {
+				return 0, prependStep(err, indexStep(i))
+			}
+			if res != 0 {
+				t.stepIndex(i)
+				return res, nil
 			}
 		}
-		return 0
+		return 0, nil
 	case reflect.Interface:
 		if res := compareBool(!v1.IsNil(), !v2.IsNil()); res != 0 {
-			return res
+			return res, nil
+		}
+		if (t.dynamicTypeOrder[v1.Type()] || t.debugTotalOrder) && !v1.IsNil() {
+			if res := compareTypes(v1.Elem().Type(), v2.Elem().Type()); res != 0 {
+				return res, nil
+			}
 		}
-		return c.deepValueCompare(v1.Elem(), v2.Elem(), visited, depth+1)
+		return t.deepValueCompare(v1.Elem(), v2.Elem(), depth+1)
 	case reflect.Ptr:
-		return c.deepValueCompare(v1.Elem(), v2.Elem(), visited, depth+1)
+		if t.collapsePointers {
+			return t.deepValueCompare(t.collapsePointer(v1), t.collapsePointer(v2), depth+1)
+		}
+		if v1.IsNil() || v2.IsNil() {
+			return compareBool(!v1.IsNil(), !v2.IsNil()), nil
+		}
+		t.derefs = derefs + 1
+		return t.deepValueCompare(v1.Elem(), v2.Elem(), depth+1)
 	case reflect.Struct:
-		for i, n := 0, v1.NumField(); i < n; i++ {
-			if res := c.deepValueCompare(v1.Field(i), v2.Field(i), visited, depth+1); res != 0 {
-				return res
+		fields, err := t.structFields(v1.Type())
+		if err != nil {
+			return 0, err
+		}
+		if u := t.incomparableStruct(v1.Type(), fields); u != nil {
+			return 0, u
+		}
+		for _, f := range fields {
+			f1, f2 := t.expose(v1.Field(f.index)), t.expose(v2.Field(f.index))
+			if f.compare.IsValid() {
+				res, err := t.compareFieldFunc(f, f1, f2, depth+1)
+				if err != nil {
+					return 0, prependStep(err, fieldStep(f.name))
+				}
+				if res != 0 {
+					t.decideBy(f.compare)
+					t.stepField(f.name)
+					return res, nil
+				}
+				continue
+			}
+			t.field = f
+			res, err := t.deepValueCompareAt(fieldElem(f.name), f1, f2, depth+1)
+			if err != nil {
+				return 0, prependStep(err, fieldStep(f.name))
+			}
+			if res != 0 {
+				t.stepField(f.name)
+				return res, nil
 			}
 		}
-		return 0
+		return 0, nil
 	case reflect.Map:
+		if t.compareAsSets && isSetType(v1.Type()) {
+			return t.compareSets(v1, v2, depth)
+		}
+		if t.strictMaps {
+			return t.compareMapsStrict(v1, v2, depth)
+		}
 		if (v1.IsNil() || v1.Len() == 0) != (v2.IsNil() || v2.Len() == 0) {
-			return 0
+			return 0, nil
 		}
 		if res := v1.Len() - v2.Len(); res != 0 {
-			return res
+			return res, nil
 		}
 		if v1.Pointer() == v2.Pointer() {
-			return 0
+			return 0, nil
 		}
-		for _, k := range v1.MapKeys() {
-			if res := c.deepValueCompare(v1.MapIndex(k), v2.MapIndex(k), visited, depth+1); res != 0 {
-				return res
+		// Iterate instead of using MapKeys to avoid allocating a slice of all keys.
+		for iter := v1.MapRange(); iter.Next(); {
+			e2 := v2.MapIndex(iter.Key())
+			if !e2.IsValid() {
+				if !t.missingAsZero {
+					t.missKey(iter.Key())
+					return 1, nil
+				}
+				e2 = reflect.Zero(v2.Type().Elem())
+			}
+			res, err := t.deepValueCompareAt(keyElem(iter.Key()), iter.Value(), e2, depth+1)
+			if err != nil {
+				return 0, prependStep(err, t.keyStep(iter.Key()))
+			}
+			if res != 0 {
+				t.stepKey(iter.Key())
+				return res, nil
 			}
 		}
-		return 0
+		return 0, nil
 	case reflect.Func:
 		if !v1.IsNil() && !v2.IsNil() {
-			panic("cannot compare two non-nil functions")
+			if t.funcsByNil {
+				return 0, nil
+			}
+			if t.debugTotalOrder {
+				return compareDebug(v1, v2), nil
+			}
+			return 0, &pathError{err: ErrFuncCompare}
 		}
-		return compareBool(!v1.IsNil(), !v2.IsNil())
+		return compareBool(!v1.IsNil(), !v2.IsNil()), nil
 
 	case reflect.Bool:
-		return compareBool(v1.Bool(), v2.Bool())
+		return compareBool(v1.Bool(), v2.Bool()), nil
 
 	case reflect.Uint, reflect.Uintptr, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		return compareUInt64(v1.Uint(), v2.Uint())
+		return compareUInt64(v1.Uint(), v2.Uint()), nil
 
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		return compareInt64(v1.Int(), v2.Int())
+		return compareInt64(v1.Int(), v2.Int()), nil
 
 	case reflect.Float32, reflect.Float64:
-		return compareFloat64(v1.Float(), v2.Float())
+		return compareFloat64(v1.Float(), v2.Float()), nil
 
 	case reflect.String:
-		return strings.Compare(v1.String(), v2.String())
+		if v1.Type() == jsonNumberType {
+			return compareJSONNumbers(v1.String(), v2.String())
+		}
+		if t.numericStrings {
+			return compareNumericStrings(v1.String(), v2.String())
+		}
+		return strings.Compare(v1.String(), v2.String()), nil
 
 	default:
+		if t.debugTotalOrder {
+			return compareDebug(v1, v2), nil
+		}
 		// Normal equality suffices
 		if !v1.CanInterface() || !v2.CanInterface() {
-			panic(unexportedTypePanic{})
+			if t.lenient {
+				return 0, nil
+			}
+			return 0, &UnexportedFieldError{}
 		}
 		return compareInterface(v1.Interface(), v2.Interface())
 	}
 }
 
+// compareFieldFunc compares the values f1 and f2 of the struct field f with its comparison function.
+func (t *traversal) compareFieldFunc(f structField, f1, f2 reflect.Value, depth int) (int, error) {
+	if t.paths != nil {
+		t.enterStep(fieldElem(f.name))
+		defer t.leaveStep()
+	}
+	return t.callFieldFunc(f.compare, defaulted(f1, f.def), defaulted(f2, f.def), depth)
+}
+
+// callFieldFunc compares the values v1 and v2 of a struct field with its comparison function fv.
+func (t *traversal) callFieldFunc(fv, v1, v2 reflect.Value, depth int) (int, error) {
+	if ok, err := t.canCall(v1, v2); !ok {
+		if u, ok := err.(*UnexportedFieldError); ok {
+			u.Types = []reflect.Type{v1.Type()}
+		}
+		return 0, err
+	}
+	t.stats.OverrideHits++
+	return t.callCompare(fv, v1, v2, depth)
+}
+
+// canCall reports whether a comparison function can be called with v1 and v2.
+// If they are obtained via unexported fields, it errors unless the traversal is lenient.
+func (t *traversal) canCall(v1, v2 reflect.Value) (bool, error) {
+	if v1.CanInterface() && v2.CanInterface() {
+		return true, nil
+	}
+	if t.lenient || t.debugTotalOrder {
+		return false, nil
+	}
+	return false, &UnexportedFieldError{}
+}
+
+// collapsePointer dereferences the pointer v until reaching a non-pointer value,
+// a pointer with a comparison function, or nil, in which case it returns the zero
+// reflect.Value.
+func (t *traversal) collapsePointer(v reflect.Value) reflect.Value {
+	for {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+		if v.Kind() != reflect.Ptr {
+			return v
+		}
+		if _, ok := t.lookup(v.Type()); ok {
+			return v
+		}
+	}
+}
+
+// compareTypes orders types by their string representation and package path.
+func compareTypes(t1, t2 reflect.Type) int {
+	if t1 == t2 {
+		return 0
+	}
+	if res := strings.Compare(t1.String(), t2.String()); res != 0 {
+		return res
+	}
+	return strings.Compare(t1.PkgPath(), t2.PkgPath())
+}
+
 // compareInt64 compares two int64 values. We compare 'manually' to avoid any overflow.
 func compareInt64(i1, i2 int64) int {
 	if i1 < i2 {
@@ -288,33 +613,143 @@ func compareUInt64(u1, u2 uint64) int {
 	return 0
 }
 
-func compareInterface(v1, v2 interface{}) int {
+func compareInterface(v1, v2 interface{}) (int, error) {
 	// utmost fallback: regular equality
 	if v1 == v2 {
-		return 0
+		return 0, nil
 	}
-	panic(fmt.Sprintf("cannot compare values of type %T", v1))
+	return 0, &pathError{err: fmt.Errorf("%w %T", ErrTypeNotComparable, v1)}
 }
 
+// DeepComparer deep compares values. It is implemented by Comparisons and FrozenComparisons,
+// so functions can accept either of them, or any other implementation.
+type DeepComparer interface {
+	// DeepCompare compares a1 and a2, returning a negative number if a1 is less than a2,
+	// 0 if they are equal and a positive number if a1 is greater than a2.
+	DeepCompare(a1, a2 interface{}) int
+}
+
+var (
+	_ DeepComparer = Comparisons(nil)
+	_ DeepComparer = FrozenComparisons{}
+)
+
 // DeepCompare compares two values, traversing through them if they
 // are complex data types.
 //
-// It will use c's comparison functions if it finds types that match.
+// It will use c's comparison functions if it finds types that match. Otherwise,
+// values implementing CustomComparer are compared via their CompareTo method.
 //
 // An empty slice *is* equal to a nil slice for our purposes; same for maps.
 //
+// An untyped nil is equal to another untyped nil and to values that are nil
+// (pointers, funcs, channels) or nil / empty (slices, maps). It is less than any
+// other value. Use a Comparer with the Strict option to treat comparing an untyped
+// nil with a typed value as a type mismatch instead.
+//
 // Unexported field members cannot be compared and will cause an informative panic; you must add an Equality
 // function for these types.
 func (c Comparisons) DeepCompare(a1, a2 interface{}) int {
-	if res := compareBool(a1 == nil, a2 == nil); res != 0 {
-		return res
+	return c.newTraversal().compare(a1, a2)
+}
+
+// compare compares a1 and a2, panicking if they cannot be compared.
+func (t *traversal) compare(a1, a2 interface{}) int {
+	res, err := t.tryCompare(a1, a2)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// tryCompare compares a1 and a2, returning an error if they cannot be compared.
+func (t *traversal) tryCompare(a1, a2 interface{}) (int, error) {
+	if a1 == nil || a2 == nil {
+		return t.compareUntypedNil(a1, a2)
 	}
 	v1 := reflect.ValueOf(a1)
 	v2 := reflect.ValueOf(a2)
 	if v1.Type() != v2.Type() {
-		panic(fmt.Sprintf("cannot compare different types: %T - %T", a1, a2))
+		if t.debugTotalOrder {
+			return compareTypes(v1.Type(), v2.Type()), nil
+		}
+		return 0, &typeMismatchError{t1: reflect.TypeOf(a1), t2: reflect.TypeOf(a2)}
+	}
+	if t.debugTotalOrder {
+		v1, v2 = addressable(v1), addressable(v2)
+	}
+	res, err := t.deepValueCompare(v1, v2, 0)
+	if err != nil || t.partial {
+		return res, err
+	}
+	if t.debugTotalOrder && res == incomparable {
+		return 0, nil
+	}
+	return totalOrder(res, v1.Type())
+}
+
+// compareUntypedNil compares a1 and a2 of which at least one is an untyped nil.
+func (t *traversal) compareUntypedNil(a1, a2 interface{}) (int, error) {
+	if a1 == nil && a2 == nil {
+		return 0, nil
+	}
+	if t.strict && !t.debugTotalOrder {
+		return 0, &typeMismatchError{t1: reflect.TypeOf(a1), t2: reflect.TypeOf(a2)}
+	}
+	if a1 == nil {
+		res, err := t.compareUntypedNil(a2, a1)
+		return -res, err
+	}
+	if isNilOrEmpty(reflect.ValueOf(a1)) {
+		return 0, nil
+	}
+	return 1, nil
+}
+
+// isNilOrEmpty reports whether v is a nil pointer, func, channel or unsafe pointer,
+// or a nil or empty slice or map.
+func isNilOrEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Func, reflect.Chan, reflect.UnsafePointer, reflect.Interface:
+		return v.IsNil()
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return false
+}
+
+// TryDeepCompare compares two values like DeepCompare, but returns an error
+// instead of panicking if the values cannot be compared.
+func (c Comparisons) TryDeepCompare(a1, a2 interface{}) (res int, err error) {
+	// Comparison functions may still panic.
+	defer func() {
+		if x := recover(); x != nil {
+			err = recoveredError(x)
+		}
+	}()
+	return c.newTraversal().tryCompare(a1, a2)
+}
+
+// DeepCompareStrict compares two values like DeepCompare, panicking if they cannot be compared.
+// Unlike DeepCompare, it also panics when comparing an untyped nil to a typed value (see Strict).
+// It is meant for tests, where an incomplete set of comparison functions should fail loudly.
+func (c Comparisons) DeepCompareStrict(a1, a2 interface{}) int {
+	return c.newTraversal(Strict()).compare(a1, a2)
+}
+
+// DeepCompareLenient compares two values like DeepCompare, but considers values that cannot
+// be compared because of unexported fields equal instead of panicking (see Lenient).
+// It still panics on programmer errors like comparing values of different types.
+func (c Comparisons) DeepCompareLenient(a1, a2 interface{}) int {
+	return c.newTraversal(Lenient()).compare(a1, a2)
+}
+
+// recoveredError converts a value recovered from a panic while comparing to an error.
+func recoveredError(x interface{}) error {
+	if err, ok := x.(error); ok {
+		return err
 	}
-	return c.deepValueCompare(v1, v2, make(map[visit]int), 0)
+	return fmt.Errorf("%v", x)
 }
 
 // NewComparisons creates new Comparisons with the given functions added.
@@ -327,6 +762,38 @@ func NewComparisons(funcs ...interface{}) (Comparisons, error) {
 	return c, nil
 }
 
+// WithOverride derives new Comparisons from c with the given comparison functions added.
+// c itself is not modified, which makes WithOverride suitable for tweaking the ordering
+// of single types, e.g. in tests, without polluting shared Comparisons.
+//
+// The derived Comparisons are a copy of c, so comparison functions added to c later on
+// are not visible in them.
+func (c Comparisons) WithOverride(funcs ...interface{}) (Comparisons, error) {
+	res := c.copy()
+	if err := res.AddFuncs(funcs...); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// WithOverrideOrDie derives new Comparisons from c with the given comparison functions added.
+// If any of the given functions is *not* a comparison function, it panics.
+func (c Comparisons) WithOverrideOrDie(funcs ...interface{}) Comparisons {
+	res, err := c.WithOverride(funcs...)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// lookup looks up the comparison function for typ in the Comparisons of t and their parents.
+func (t *traversal) lookup(typ reflect.Type) (reflect.Value, bool) {
+	if fv, ok := t.comparisons[typ]; ok || len(t.parents) == 0 {
+		return fv, ok
+	}
+	return lookupLayers(t.parents, typ)
+}
+
 // NewComparisonsOrDie creates new Comparisons with the given functions added.
 // If any of the given functions is *not* a comparison function, it panics.
 func NewComparisonsOrDie(funcs ...interface{}) Comparisons {
@@ -48,3 +48,25 @@ func ExampleComparisons_DeepCompare() {
 	// cat vs dog: -1
 	// cat vs dog (adorability considered): 1
 }
+
+func ExampleComparisons_AddOrder() {
+	type Severity string
+	const (
+		Low      Severity = "low"
+		High     Severity = "high"
+		Critical Severity = "critical"
+	)
+
+	// Lexicographically, critical is below high
+	fmt.Println("critical vs high:", strings.Compare(string(Critical), string(High)))
+
+	c := make(reflcompare.Comparisons)
+	if err := c.AddOrder(Low, High, Critical); err != nil {
+		panic(err)
+	}
+
+	fmt.Println("critical vs high (order considered):", c.DeepCompare(Critical, High))
+	// Output:
+	// critical vs high: -1
+	// critical vs high (order considered): 1
+}
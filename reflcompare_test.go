@@ -24,10 +24,27 @@ import (
 	. "github.com/onsi/gomega"
 )
 
+var reflectTypeOfInt = reflect.TypeOf(0)
+
 func intPtr(i int) *int {
 	return &i
 }
 
+func intPtrPtr(p *int) **int {
+	return &p
+}
+
+func intPtrPtrPtr(p **int) ***int {
+	return &p
+}
+
+// descByte is a byte ordered descendingly via CompareTo.
+type descByte byte
+
+func (b descByte) CompareTo(other interface{}) (int, bool) {
+	return int(other.(descByte)) - int(b), true
+}
+
 type Struct struct {
 	A int
 	B *int
@@ -66,6 +83,14 @@ var _ = Describe("Reflcompare", func() {
 				Expect(c.DeepCompare(v1, v2)).To(Equal(expect))
 				Expect(c.DeepCompare(v2, v1)).To(Equal(-expect))
 			},
+			Entry("untyped nil == untyped nil", c, nil, nil, 0),
+			Entry("untyped nil == nil pointer", c, nil, (*int)(nil), 0),
+			Entry("untyped nil == nil func", c, nil, (func())(nil), 0),
+			Entry("untyped nil == empty slice", c, nil, []int{}, 0),
+			Entry("untyped nil == nil map", c, nil, (map[int]int)(nil), 0),
+			Entry("untyped nil < pointer", c, nil, intPtr(0), -1),
+			Entry("untyped nil < zero value", c, nil, 0, -1),
+			Entry("untyped nil < slice", c, nil, []int{0}, -1),
 			Entry("nil - not nil", c, (*int)(nil), intPtr(1), -1),
 			Entry("not nil - nil", c, intPtr(1), (*int)(nil), 1),
 			Entry("custom struct{A: 0, B: *1} == struct{A: 0, B: *2}", NewComparisonsOrDie(func(a, b Struct) int { return a.A - b.A }), Struct{B: intPtr(1)}, Struct{B: intPtr(2)}, 0),
@@ -79,6 +104,11 @@ var _ = Describe("Reflcompare", func() {
 			Entry("slice1 == slice2", c, []int{1, 2}, []int{1, 2}, 0),
 			Entry("slice1[1] < slice2[1]", c, []int{1, 1}, []int{1, 2}, -1),
 			Entry("slice1[1] > slice2[1]", c, []int{1, 2}, []int{1, 1}, 1),
+			Entry("bytes1 == bytes2", c, []byte{1, 2}, []byte{1, 2}, 0),
+			Entry("bytes1[1] < bytes2[1]", c, []byte{1, 1}, []byte{1, 2}, -1),
+			Entry("custom bytes1 == bytes2", NewComparisonsOrDie(func(a, b uint8) int { return 0 }), []byte{1, 1}, []byte{1, 2}, 0),
+			Entry("custom byte slices1 == byte slices2", NewComparisonsOrDie(func(a, b []byte) int { return 0 }), []byte{1, 1}, []byte{1, 2}, 0),
+			Entry("custom comparer bytes1[1] > bytes2[1]", c, []descByte{1, 1}, []descByte{1, 2}, 1),
 			Entry("iface1(nil) == iface2(nil) via ptr", c, &nilErr, &nilErr, 0),
 			Entry("iface1(v) == iface2(v) via ptr", c, errPtr, errPtr, 0),
 			Entry("*int == *int", c, intPtr(1), intPtr(1), 0),
@@ -139,6 +169,53 @@ var _ = Describe("Reflcompare", func() {
 		)
 	})
 
+	Describe("TryDeepCompare", func() {
+		It("should compare values", func() {
+			Expect(make(Comparisons).TryDeepCompare(1, 2)).To(Equal(-1))
+		})
+
+		It("should return an error instead of panicking", func() {
+			_, err := make(Comparisons).TryDeepCompare(1, "foo")
+			Expect(err).To(MatchError("cannot compare different types: int - string"))
+		})
+
+		It("should report the path to unexported fields", func() {
+			type Hidden struct{ c chan int }
+			_, err := make(Comparisons).TryDeepCompare([]Hidden{{make(chan int)}}, []Hidden{{make(chan int)}})
+			Expect(err).To(MatchError(HaveSuffix("[]reflcompare_test.Hidden -> reflcompare_test.Hidden -> chan int")))
+		})
+	})
+
+	Describe("DeepCompareStrict / DeepCompareLenient", func() {
+		type hidden struct{ a int }
+		type Outer struct {
+			Name string
+			h    hidden
+			ch   chan int
+		}
+		c := NewComparisonsOrDie(func(h1, h2 hidden) int { return h1.a - h2.a })
+
+		It("should panic in strict mode on unexported fields with comparison functions", func() {
+			o1, o2 := Outer{h: hidden{1}}, Outer{h: hidden{2}}
+			Expect(func() { c.DeepCompareStrict(o1, o2) }).To(PanicWith(MatchError(ContainSubstring("unexported field"))))
+		})
+
+		It("should panic in strict mode on untyped nils compared to typed values", func() {
+			Expect(c.DeepCompare(nil, (*Outer)(nil))).To(Equal(0))
+			Expect(func() { c.DeepCompareStrict(nil, (*Outer)(nil)) }).To(PanicWith(MatchError(ErrTypeMismatch)))
+			Expect(c.DeepCompareStrict(nil, nil)).To(Equal(0))
+		})
+
+		It("should consider values of unexported fields equal in lenient mode", func() {
+			Expect(c.DeepCompareLenient(Outer{Name: "a", h: hidden{2}}, Outer{Name: "b", h: hidden{1}})).To(Equal(-1))
+			Expect(c.DeepCompareLenient(Outer{ch: make(chan int)}, Outer{ch: make(chan int)})).To(Equal(0))
+		})
+
+		It("should still panic on programmer errors in lenient mode", func() {
+			Expect(func() { c.DeepCompareLenient(1, "foo") }).To(Panic())
+		})
+	})
+
 	Describe("AddFunc", func() {
 		It("should add the function", func() {
 			c := make(Comparisons)
@@ -169,6 +246,34 @@ var _ = Describe("Reflcompare", func() {
 		})
 	})
 
+	Describe("AdaptFunc", func() {
+		type MyInt int
+
+		It("should adapt the function to the type of the sample", func() {
+			f, err := AdaptFunc(func(a, b int) int { return b - a }, MyInt(0))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(f.(func(a, b MyInt) int)(1, 2)).To(Equal(1))
+		})
+
+		It("should error if the given argument is no function", func() {
+			_, err := AdaptFunc(1, MyInt(0))
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should error if the sample is not convertible", func() {
+			_, err := AdaptFunc(func(a, b int) int { return a - b }, "foo")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("AdaptFuncOrDie", func() {
+		It("should panic if the function cannot be adapted", func() {
+			Expect(func() {
+				AdaptFuncOrDie(func(a, b int) int { return a - b }, "foo")
+			}).To(Panic())
+		})
+	})
+
 	Describe("NewComparisons", func() {
 		It("should create a new conversion with the given functions", func() {
 			c, err := NewComparisons(func(a, b int) int {
@@ -184,6 +289,37 @@ var _ = Describe("Reflcompare", func() {
 		})
 	})
 
+	Describe("WithOverride", func() {
+		It("should override comparison functions without modifying the original", func() {
+			c := make(Comparisons)
+			o, err := c.WithOverride(func(a, b int) int { return b - a })
+			Expect(err).NotTo(HaveOccurred())
+			Expect(o.DeepCompare(1, 2)).To(Equal(1))
+			Expect(o.DeepCompare("a", "b")).To(Equal(-1))
+			Expect(c.DeepCompare(1, 2)).To(Equal(-1))
+			Expect(c).To(BeEmpty())
+		})
+
+		It("should copy the original", func() {
+			c := make(Comparisons)
+			o := c.WithOverrideOrDie(func(a, b int) int { return b - a })
+			Expect(c.AddFunc(func(a, b string) int { return 0 })).To(Succeed())
+			Expect(o.DeepCompare("a", "b")).To(Equal(-1))
+			Expect(o).To(HaveLen(1))
+		})
+
+		It("should error if the given argument is no function", func() {
+			_, err := make(Comparisons).WithOverride(1)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("WithOverrideOrDie", func() {
+		It("should panic if the given argument is no function", func() {
+			Expect(func() { make(Comparisons).WithOverrideOrDie(1) }).To(Panic())
+		})
+	})
+
 	Describe("NewComparisonsOrDie", func() {
 		It("should create a new conversion with the given functions", func() {
 			c := NewComparisonsOrDie(func(a, b int) int {
@@ -0,0 +1,105 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.18
+
+// Package reflcomparefuzz provides helpers to fuzz-check that reflcompare.DeepComparer
+// implementations order values consistently, i.e. that custom comparison functions are reflexive,
+// antisymmetric and transitive.
+package reflcomparefuzz
+
+import (
+	"testing"
+
+	"github.com/adracus/reflcompare"
+)
+
+// Generator generates a value from fuzzer-provided data.
+// It has to be deterministic and always return values of the same type.
+type Generator func(data []byte) interface{}
+
+// Fuzz runs a fuzz target on f that checks, via Check, that c orders the values
+// generated by gen consistently. Each seed is added to the corpus; when running as
+// a regular test, all combinations of three seeds are checked.
+//
+// A typical fuzz target looks like this:
+//
+//	func FuzzCompareVersions(f *testing.F) {
+//		reflcomparefuzz.Fuzz(f, comparisons, func(data []byte) interface{} {
+//			return ParseVersion(string(data))
+//		}, []byte("1.0.0"), []byte("1.10.0"))
+//	}
+func Fuzz(f *testing.F, c reflcompare.DeepComparer, gen Generator, seeds ...[]byte) {
+	f.Helper()
+	for _, x := range seeds {
+		for _, y := range seeds {
+			for _, z := range seeds {
+				f.Add(x, y, z)
+			}
+		}
+	}
+	f.Fuzz(func(t *testing.T, x, y, z []byte) {
+		Check(t, c, gen(x), gen(y), gen(z))
+	})
+}
+
+// Check checks that c orders x, y and z consistently and reports any violation via t.Errorf.
+// It checks that
+//
+//   - every value is equal to itself (reflexivity),
+//   - reversing the arguments reverses the result (antisymmetry) and
+//   - x <= y and y <= z implies x <= z for all orderings of x, y and z (transitivity).
+//
+// Check uses c.DeepCompare, so it fails on panics as well.
+func Check(t testing.TB, c reflcompare.DeepComparer, x, y, z interface{}) {
+	t.Helper()
+	values := []interface{}{x, y, z}
+	res := make([][]int, len(values))
+	for i, a := range values {
+		res[i] = make([]int, len(values))
+		for j, b := range values {
+			res[i][j] = sign(c.DeepCompare(a, b))
+		}
+	}
+
+	for i, a := range values {
+		if res[i][i] != 0 {
+			t.Errorf("not reflexive: compare(%#v, %#v) = %d", a, a, res[i][i])
+		}
+		for j, b := range values {
+			if res[i][j] != -res[j][i] {
+				t.Errorf("not antisymmetric: compare(%#v, %#v) = %d, but compare(%#v, %#v) = %d", a, b, res[i][j], b, a, res[j][i])
+			}
+			for k, c := range values {
+				if res[i][j] <= 0 && res[j][k] <= 0 && res[i][k] > 0 {
+					t.Errorf("not transitive: %#v <= %#v and %#v <= %#v, but %#v > %#v", a, b, b, c, a, c)
+				}
+				if res[i][j] == 0 && res[j][k] == 0 && res[i][k] != 0 {
+					t.Errorf("not transitive: %#v == %#v and %#v == %#v, but %#v != %#v", a, b, b, c, a, c)
+				}
+			}
+		}
+	}
+}
+
+func sign(res int) int {
+	switch {
+	case res < 0:
+		return -1
+	case res > 0:
+		return 1
+	default:
+		return 0
+	}
+}
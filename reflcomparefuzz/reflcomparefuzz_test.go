@@ -0,0 +1,91 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.18
+
+package reflcomparefuzz_test
+
+import (
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/adracus/reflcompare"
+	. "github.com/adracus/reflcompare/reflcomparefuzz"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// recordingTB records errors instead of failing.
+type recordingTB struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func genInt(data []byte) interface{} {
+	var buf [8]byte
+	copy(buf[:], data)
+	return int(binary.LittleEndian.Uint64(buf[:]))
+}
+
+func FuzzDeepCompareInts(f *testing.F) {
+	Fuzz(f, make(reflcompare.Comparisons), genInt, []byte{0}, []byte{1}, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+}
+
+var _ = Describe("Check", func() {
+	It("should accept consistent comparisons", func() {
+		tb := &recordingTB{}
+		Check(tb, make(reflcompare.Comparisons), 1, 2, 3)
+		Check(tb, make(reflcompare.Comparisons), []int{1}, []int{1}, nil)
+		Check(tb, make(reflcompare.Comparisons).Freeze(), "a", "b", "a")
+		Expect(tb.errors).To(BeEmpty())
+	})
+
+	It("should report asymmetric comparison functions", func() {
+		tb := &recordingTB{}
+		Check(tb, reflcompare.NewComparisonsOrDie(func(a, b int) int { return 1 }), 1, 2, 3)
+		Expect(tb.errors).To(ContainElement(ContainSubstring("not reflexive")))
+		Expect(tb.errors).To(ContainElement(ContainSubstring("not antisymmetric")))
+	})
+
+	It("should report intransitive comparison functions", func() {
+		// Rock-paper-scissors: 0 < 1 < 2 < 0.
+		rps := func(a, b int) int {
+			switch {
+			case a == b:
+				return 0
+			case (a+1)%3 == b:
+				return -1
+			default:
+				return 1
+			}
+		}
+		tb := &recordingTB{}
+		Check(tb, reflcompare.NewComparisonsOrDie(rps), 0, 1, 2)
+		Expect(tb.errors).To(ContainElement(ContainSubstring("not transitive")))
+	})
+
+	It("should report comparison functions overflowing", func() {
+		tb := &recordingTB{}
+		c := reflcompare.NewComparisonsOrDie(func(a, b int) int { return a - b })
+		Check(tb, c, genInt([]byte{0}), genInt([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f}), -2)
+		Expect(tb.errors).NotTo(BeEmpty())
+	})
+})
@@ -0,0 +1,139 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package reflcomparetest provides helpers for testing code relying on reflcompare.
+package reflcomparetest
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/adracus/reflcompare"
+)
+
+// UpdateGoldenEnv is the environment variable that, if set to a non-empty value,
+// causes GoldenOrder to (re-)write golden files instead of checking them.
+const UpdateGoldenEnv = "REFLCOMPARE_UPDATE_GOLDEN"
+
+// GoldenOrder sorts values (a slice or array) with c and checks that the resulting order
+// matches the one recorded in the golden file filename. If the orders differ, the test
+// fails with a line diff of both orders.
+//
+// To record or update golden files, run the tests with the environment variable
+// UpdateGoldenEnv set.
+func GoldenOrder(t testing.TB, c reflcompare.Comparisons, filename string, values interface{}) {
+	t.Helper()
+	if err := CheckGoldenOrder(c, filename, values, os.Getenv(UpdateGoldenEnv) != ""); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// CheckGoldenOrder is like GoldenOrder but returns an error instead of failing a test.
+// If update is true, the golden file is written instead of checked.
+func CheckGoldenOrder(c reflcompare.Comparisons, filename string, values interface{}, update bool) error {
+	actual, err := renderOrder(c, values)
+	if err != nil {
+		return err
+	}
+
+	if update {
+		return ioutil.WriteFile(filename, []byte(actual), 0644)
+	}
+
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("golden file %s does not exist, run with %s=1 to create it", filename, UpdateGoldenEnv)
+		}
+		return err
+	}
+	if expected := string(data); expected != actual {
+		return fmt.Errorf("order differs from golden file %s (-golden +actual):\n%s", filename, diffLines(expected, actual))
+	}
+	return nil
+}
+
+// renderOrder sorts values and renders them as one canonical encoding per line.
+func renderOrder(c reflcompare.Comparisons, values interface{}) (string, error) {
+	v := reflect.ValueOf(values)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return "", fmt.Errorf("expected slice or array but got %T", values)
+	}
+
+	items := make([]interface{}, v.Len())
+	for i := range items {
+		items[i] = v.Index(i).Interface()
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return c.DeepCompare(items[i], items[j]) < 0
+	})
+
+	var sb strings.Builder
+	for _, item := range items {
+		data, err := c.MarshalCanonical(item)
+		if err != nil {
+			return "", fmt.Errorf("error encoding %v: %w", item, err)
+		}
+		sb.Write(data)
+		sb.WriteByte('\n')
+	}
+	return sb.String(), nil
+}
+
+// diffLines computes a line diff of the given texts based on their longest common subsequence.
+// Removed lines are prefixed with '-', added lines with '+' and common lines with ' '.
+func diffLines(a, b string) string {
+	x := strings.Split(strings.TrimSuffix(a, "\n"), "\n")
+	y := strings.Split(strings.TrimSuffix(b, "\n"), "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of x[i:] and y[j:].
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var sb strings.Builder
+	i, j := 0, 0
+	for i < len(x) || j < len(y) {
+		switch {
+		case i < len(x) && j < len(y) && x[i] == y[j]:
+			fmt.Fprintf(&sb, " %s\n", x[i])
+			i, j = i+1, j+1
+		case j == len(y) || (i < len(x) && lcs[i+1][j] >= lcs[i][j+1]):
+			fmt.Fprintf(&sb, "-%s\n", x[i])
+			i++
+		default:
+			fmt.Fprintf(&sb, "+%s\n", y[j])
+			j++
+		}
+	}
+	return sb.String()
+}
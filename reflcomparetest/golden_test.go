@@ -0,0 +1,74 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcomparetest_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/adracus/reflcompare"
+	. "github.com/adracus/reflcompare/reflcomparetest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Golden", func() {
+	var (
+		dir      string
+		filename string
+	)
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "golden")
+		Expect(err).NotTo(HaveOccurred())
+		filename = filepath.Join(dir, "order.golden")
+	})
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	Describe("CheckGoldenOrder", func() {
+		It("should record and check the sorted order", func() {
+			c := make(reflcompare.Comparisons)
+			Expect(CheckGoldenOrder(c, filename, []string{"b", "c", "a"}, true)).To(Succeed())
+
+			data, err := ioutil.ReadFile(filename)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("\"a\"\n\"b\"\n\"c\"\n"))
+
+			Expect(CheckGoldenOrder(c, filename, []string{"c", "a", "b"}, false)).To(Succeed())
+		})
+
+		It("should report a diff if the order changed", func() {
+			Expect(CheckGoldenOrder(make(reflcompare.Comparisons), filename, []string{"a", "b", "c"}, true)).To(Succeed())
+
+			reverse := reflcompare.NewComparisonsOrDie(func(s1, s2 string) int { return strings.Compare(s2, s1) })
+			err := CheckGoldenOrder(reverse, filename, []string{"a", "b", "c"}, false)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(HaveSuffix("(-golden +actual):\n-\"a\"\n-\"b\"\n \"c\"\n+\"b\"\n+\"a\"\n"))
+		})
+
+		It("should error if the golden file does not exist", func() {
+			err := CheckGoldenOrder(make(reflcompare.Comparisons), filename, []int{1}, false)
+			Expect(err).To(MatchError(ContainSubstring(UpdateGoldenEnv)))
+		})
+
+		It("should error on non-slice values", func() {
+			Expect(CheckGoldenOrder(make(reflcompare.Comparisons), filename, 1, true)).To(MatchError("expected slice or array but got int"))
+		})
+	})
+})
@@ -0,0 +1,112 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// ReloadableComparer compares values like a Comparer configured by a Config, which can be
+// swapped at runtime, e.g. to adjust which fields to ignore when diffing without redeploying.
+// Configs are validated before they are swapped, so a ReloadableComparer never compares with
+// an invalid Config. Comparisons in progress complete with the Config they started with.
+//
+// Unlike a Comparer, a ReloadableComparer is safe for concurrent use.
+type ReloadableComparer struct {
+	comparisons Comparisons
+	// samples are the samples the types named by configs are resolved among, see Config.Options.
+	samples []interface{}
+	// opts are the options applied before the options of configs.
+	opts []Option
+	// active holds the active *reloadableState.
+	active atomic.Value
+}
+
+// reloadableState is a Config in effect and the Comparers configured by it.
+type reloadableState struct {
+	cfg  Config
+	opts []Option
+	// comparers pools Comparers, which are not safe for concurrent use.
+	comparers sync.Pool
+}
+
+// NewReloadableComparer creates a ReloadableComparer using c, configured by the given options
+// followed by the options of cfg. Options that cannot be configured via a Config, e.g.
+// TagComparison, are thus passed as opts. The types named by configs are resolved among the
+// types of the given samples, see Config.Options.
+//
+// NewReloadableComparer returns an error if cfg is invalid, see Reload.
+func (c Comparisons) NewReloadableComparer(cfg Config, samples []interface{}, opts ...Option) (*ReloadableComparer, error) {
+	r := &ReloadableComparer{
+		comparisons: c,
+		samples:     samples,
+		opts:        opts,
+	}
+	if err := r.Reload(cfg); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload validates cfg and swaps it in atomically, so subsequent comparisons use it. Config
+// validation resolves its options like Config.Options and scans the types of the samples of r
+// like Comparer.ScanTypes. If cfg is invalid, Reload returns an error and keeps the active Config.
+func (r *ReloadableComparer) Reload(cfg Config) error {
+	cfgOpts, err := cfg.Options(r.samples...)
+	if err != nil {
+		return err
+	}
+	opts := append(append([]Option(nil), r.opts...), cfgOpts...)
+	cmp := r.comparisons.NewComparer(opts...)
+	if err := cmp.ScanTypes(r.samples...); err != nil {
+		return err
+	}
+	s := &reloadableState{cfg: cfg, opts: opts}
+	s.comparers.New = func() interface{} { return r.comparisons.NewComparer(opts...) }
+	s.comparers.Put(cmp)
+	r.active.Store(s)
+	return nil
+}
+
+// Config returns the active Config.
+func (r *ReloadableComparer) Config() Config {
+	return r.state().cfg
+}
+
+func (r *ReloadableComparer) state() *reloadableState {
+	return r.active.Load().(*reloadableState)
+}
+
+// Compare compares a1 and a2 like Comparer.Compare, using the active Config.
+func (r *ReloadableComparer) Compare(a1, a2 interface{}) int {
+	s := r.state()
+	cmp := s.comparers.Get().(*Comparer)
+	defer s.comparers.Put(cmp)
+	return cmp.Compare(a1, a2)
+}
+
+// TryCompare compares a1 and a2 like Comparer.TryCompare, using the active Config.
+func (r *ReloadableComparer) TryCompare(a1, a2 interface{}) (int, error) {
+	s := r.state()
+	cmp := s.comparers.Get().(*Comparer)
+	defer s.comparers.Put(cmp)
+	return cmp.TryCompare(a1, a2)
+}
+
+// Diff diffs a1 and a2 like Comparisons.Diff, using the active Config.
+func (r *ReloadableComparer) Diff(a1, a2 interface{}) ([]Difference, error) {
+	return r.comparisons.Diff(a1, a2, r.state().opts...)
+}
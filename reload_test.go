@@ -0,0 +1,94 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"strings"
+	"sync"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ReloadableComparer", func() {
+	ignoreStatus := Config{Tags: map[string]map[string]string{
+		"reflcompare_test.Workload": {"Status": "ignore"},
+	}}
+	w1 := Workload{Name: "a", Status: "up"}
+	w2 := Workload{Name: "a", Status: "down"}
+
+	var r *ReloadableComparer
+	BeforeEach(func() {
+		var err error
+		r, err = make(Comparisons).NewReloadableComparer(Config{}, []interface{}{Workload{}})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should compare using the active config", func() {
+		Expect(r.Compare(w1, w2)).To(Equal(1))
+		Expect(r.Reload(ignoreStatus)).To(Succeed())
+		Expect(r.Config()).To(Equal(ignoreStatus))
+		Expect(r.Compare(w1, w2)).To(Equal(0))
+		Expect(r.TryCompare(w1, w2)).To(Equal(0))
+		Expect(r.Diff(w1, w2)).To(BeEmpty())
+		Expect(r.Reload(Config{})).To(Succeed())
+		Expect(r.Diff(w1, w2)).To(HaveLen(1))
+	})
+
+	It("should keep the active config if a config is invalid", func() {
+		Expect(r.Reload(ignoreStatus)).To(Succeed())
+		Expect(r.Reload(Config{Flags: []string{"Loose"}})).NotTo(Succeed())
+		Expect(r.Reload(Config{Tags: map[string]map[string]string{
+			"reflcompare_test.Workload": {"Name": "caseless"},
+		}})).To(MatchError(ContainSubstring(`unknown directive "caseless"`)))
+		Expect(r.Config()).To(Equal(ignoreStatus))
+		Expect(r.Compare(w1, w2)).To(Equal(0))
+	})
+
+	It("should apply its options before the options of configs", func() {
+		r, err := make(Comparisons).NewReloadableComparer(Config{Tags: map[string]map[string]string{
+			"reflcompare_test.Workload": {"Name": "caseless"},
+		}}, []interface{}{Workload{}}, TagComparison("caseless", func(s1, s2 string) int {
+			return strings.Compare(strings.ToLower(s1), strings.ToLower(s2))
+		}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Compare(Workload{Name: "A"}, Workload{Name: "a"})).To(Equal(0))
+	})
+
+	It("should fail to create with an invalid config", func() {
+		_, err := make(Comparisons).NewReloadableComparer(ignoreStatus, nil)
+		Expect(err).To(MatchError("unknown type reflcompare_test.Workload"))
+	})
+
+	It("should be safe for concurrent use", func() {
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer GinkgoRecover()
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					if i == 0 {
+						Expect(r.Reload([]Config{{}, ignoreStatus}[j%2])).To(Succeed())
+						continue
+					}
+					Expect(r.Compare(w1, w2)).To(BeNumerically(">=", 0))
+				}
+			}(i)
+		}
+		wg.Wait()
+	})
+})
@@ -0,0 +1,152 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ANSI escape sequences used to colorize rendered differences.
+const (
+	ansiReset = "\x1b[0m"
+	ansiRed   = "\x1b[31m"
+	ansiGreen = "\x1b[32m"
+	ansiCyan  = "\x1b[36m"
+)
+
+// RenderOption configures how Render renders differences.
+type RenderOption func(*renderOptions)
+
+type renderOptions struct {
+	color bool
+}
+
+// Colorize colorizes rendered differences with ANSI escape sequences for terminal display:
+// headers are cyan, values of A red and values of B green.
+func Colorize() RenderOption {
+	return func(o *renderOptions) {
+		o.color = true
+	}
+}
+
+// Render renders the differences found by Diff like a unified diff, e.g.
+//
+//	@@ .Spec.Image @@ string
+//	- "nginx"
+//	+ "alpine"
+//
+// Each difference is rendered as a header holding its path and comparator, followed by a line
+// holding A prefixed by '-' and a line holding B prefixed by '+'. The line of a value missing
+// from a map or slice is omitted. Values are rendered in Go syntax, see Formatters and Truncate
+// to customize how values are rendered.
+func Render(diffs []Difference, opts ...RenderOption) string {
+	var o renderOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	var sb strings.Builder
+	for _, diff := range diffs {
+		path := diff.Path
+		if path == "" {
+			path = "(root)"
+		}
+		o.writeLine(&sb, ansiCyan, fmt.Sprintf("@@ %s @@ %s", path, diff.Comparator))
+		missing := diff.Comparator == "key missing" || diff.Comparator == "length"
+		if diff.A != nil || !missing {
+			o.writeLine(&sb, ansiRed, "- "+renderValue(diff.A))
+		}
+		if diff.B != nil || !missing {
+			o.writeLine(&sb, ansiGreen, "+ "+renderValue(diff.B))
+		}
+	}
+	return sb.String()
+}
+
+// writeLine writes line to sb, colorized with the given color if enabled.
+func (o renderOptions) writeLine(sb *strings.Builder, color, line string) {
+	if o.color {
+		sb.WriteString(color)
+		sb.WriteString(line)
+		sb.WriteString(ansiReset)
+	} else {
+		sb.WriteString(line)
+	}
+	sb.WriteByte('\n')
+}
+
+// renderValue renders the reported value v in Go syntax.
+func renderValue(v interface{}) string {
+	return fmt.Sprintf("%#v", v)
+}
+
+// jsonDifference is the JSON encoding of a Difference.
+type jsonDifference struct {
+	Path       string     `json:"path"`
+	A          *jsonValue `json:"a,omitempty"`
+	B          *jsonValue `json:"b,omitempty"`
+	Result     int        `json:"result"`
+	Comparator string     `json:"comparator"`
+}
+
+// jsonValue is the JSON encoding of a reported value.
+type jsonValue struct {
+	// Type is the name of the type of the value, e.g. 'time.Time'.
+	Type string `json:"type"`
+	// Text is the rendered value, see Render.
+	Text string `json:"text"`
+	// Value is the JSON encoding of the value, if it can be encoded and is not truncated.
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// MarshalJSON encodes d as a JSON object with the members 'path', 'result', 'comparator'
+// and 'a' and 'b', which are omitted if the respective value is nil. Values are encoded as
+// objects with the members 'type' holding the name of their type, 'text' holding their
+// rendering (see Render) and 'value' holding their JSON encoding, e.g.
+//
+//	{"path":".Spec.Image","a":{"type":"string","text":"\"nginx\"","value":"nginx"},...}
+//
+// Formatted values are encoded by the types and JSON encodings of the formatted values.
+// The member 'value' is omitted for truncated values and for values that cannot be encoded.
+func (d Difference) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonDifference{
+		Path:       d.Path,
+		A:          newJSONValue(d.A),
+		B:          newJSONValue(d.B),
+		Result:     d.Result,
+		Comparator: d.Comparator,
+	})
+}
+
+func newJSONValue(v interface{}) *jsonValue {
+	if v == nil {
+		return nil
+	}
+	res := &jsonValue{Text: renderValue(v)}
+	switch v := v.(type) {
+	case Truncated:
+		res.Type = reflect.TypeOf(v.Value).String()
+		return res
+	case Formatted:
+		res.Type = reflect.TypeOf(v.Value).String()
+		res.Value, _ = json.Marshal(v.Value)
+		return res
+	}
+	res.Type = reflect.TypeOf(v).String()
+	res.Value, _ = json.Marshal(v)
+	return res
+}
@@ -0,0 +1,121 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"encoding/json"
+	"time"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Render", func() {
+	diffs := []Difference{
+		{Path: ".Name", A: "a", B: "b", Result: -1, Comparator: "string"},
+		{Path: `.Labels["env"]`, B: "prod", Result: -1, Comparator: "key missing"},
+		{Path: "[2]", A: 3, Result: 1, Comparator: "length"},
+	}
+
+	It("should render differences like a unified diff", func() {
+		Expect(Render(diffs)).To(Equal(`@@ .Name @@ string
+- "a"
++ "b"
+@@ .Labels["env"] @@ key missing
++ "prod"
+@@ [2] @@ length
+- 3
+`))
+	})
+
+	It("should colorize differences", func() {
+		Expect(Render(diffs[:1], Colorize())).To(Equal(
+			"\x1b[36m@@ .Name @@ string\x1b[0m\n" +
+				"\x1b[31m- \"a\"\x1b[0m\n" +
+				"\x1b[32m+ \"b\"\x1b[0m\n",
+		))
+	})
+
+	It("should render differences at the root and nil values", func() {
+		Expect(Render([]Difference{{A: nil, B: 1, Result: -1, Comparator: "nil"}})).To(Equal(
+			"@@ (root) @@ nil\n- <nil>\n+ 1\n",
+		))
+	})
+
+	It("should render formatted and truncated values", func() {
+		Expect(Render([]Difference{{
+			Path:       ".Data",
+			A:          Truncated{Value: []byte{1}, Len: 3},
+			B:          Formatted{Value: 2, Text: "two"},
+			Comparator: "custom",
+		}})).To(Equal("@@ .Data @@ custom\n- []byte{0x1}...(2 more elements)\n+ two\n"))
+	})
+
+	It("should render nothing for no differences", func() {
+		Expect(Render(nil)).To(BeEmpty())
+	})
+
+	Describe("MarshalJSON", func() {
+		marshal := func(d Difference) string {
+			data, err := json.Marshal(d)
+			Expect(err).NotTo(HaveOccurred())
+			return string(data)
+		}
+
+		It("should encode differences with their values", func() {
+			Expect(marshal(diffs[0])).To(MatchJSON(`{
+				"path": ".Name",
+				"a": {"type": "string", "text": "\"a\"", "value": "a"},
+				"b": {"type": "string", "text": "\"b\"", "value": "b"},
+				"result": -1,
+				"comparator": "string"
+			}`))
+		})
+
+		It("should omit missing values", func() {
+			Expect(marshal(diffs[1])).To(MatchJSON(`{
+				"path": ".Labels[\"env\"]",
+				"b": {"type": "string", "text": "\"prod\"", "value": "prod"},
+				"result": -1,
+				"comparator": "key missing"
+			}`))
+		})
+
+		It("should encode formatted values by the formatted values", func() {
+			t := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+			Expect(marshal(Difference{A: Formatted{Value: t, Text: "2021"}, Result: 1, Comparator: "nil"})).To(MatchJSON(`{
+				"path": "",
+				"a": {"type": "time.Time", "text": "2021", "value": "2021-01-01T00:00:00Z"},
+				"result": 1,
+				"comparator": "nil"
+			}`))
+		})
+
+		It("should omit the encodings of truncated values and of values that cannot be encoded", func() {
+			Expect(marshal(Difference{
+				A:          Truncated{Value: "ab", Len: 3},
+				B:          map[[1]int]int{{1}: 2},
+				Comparator: "custom",
+			})).To(MatchJSON(`{
+				"path": "",
+				"a": {"type": "string", "text": "\"ab\"...(1 more bytes)"},
+				"b": {"type": "map[[1]int]int", "text": "map[[1]int]int{[1]int{1}:2}"},
+				"result": 0,
+				"comparator": "custom"
+			}`))
+		})
+	})
+})
@@ -0,0 +1,85 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+// Router routes comparisons to different Comparisons depending on the compared values, e.g.
+// by the API versions of stored objects, so a single entry point can compare heterogeneous
+// values during migrations.
+//
+// A Router must not be modified while it is used.
+type Router struct {
+	routes   []route
+	fallback Comparisons
+}
+
+// route routes the values matching a predicate to Comparisons.
+type route struct {
+	match       func(v interface{}) bool
+	comparisons Comparisons
+}
+
+// NewRouter creates a Router routing comparisons to fallback unless routed otherwise via Route.
+func NewRouter(fallback Comparisons) *Router {
+	return &Router{fallback: fallback}
+}
+
+// Route routes comparisons of values that both match the predicate match to c, unless they are
+// routed by a route added before. It returns r to allow chaining.
+func (r *Router) Route(match func(v interface{}) bool, c Comparisons) *Router {
+	r.routes = append(r.routes, route{match: match, comparisons: c})
+	return r
+}
+
+// For returns the Comparisons to compare a1 and a2 with: Those of the first route matching both
+// values, or the fallback if there is none.
+func (r *Router) For(a1, a2 interface{}) Comparisons {
+	for _, rt := range r.routes {
+		if rt.match(a1) && rt.match(a2) {
+			return rt.comparisons
+		}
+	}
+	return r.fallback
+}
+
+// DeepCompare compares a1 and a2 via Comparisons.DeepCompare of the Comparisons they are routed to.
+func (r *Router) DeepCompare(a1, a2 interface{}) int {
+	return r.For(a1, a2).DeepCompare(a1, a2)
+}
+
+// TryDeepCompare compares a1 and a2 via Comparisons.TryDeepCompare of the Comparisons they are
+// routed to.
+func (r *Router) TryDeepCompare(a1, a2 interface{}) (int, error) {
+	return r.For(a1, a2).TryDeepCompare(a1, a2)
+}
+
+// Diff diffs a1 and a2 via Comparisons.Diff of the Comparisons they are routed to.
+func (r *Router) Diff(a1, a2 interface{}, opts ...Option) ([]Difference, error) {
+	return r.For(a1, a2).Diff(a1, a2, opts...)
+}
+
+// PathEquals returns a predicate for Router.Route matching values whose value at path (see
+// Comparisons.Lookup) is equal to want according to DeepCompare, e.g. PathEquals(".APIVersion", "v1").
+// Values without a value at path or with one of a type other than want's do not match.
+func PathEquals(path string, want interface{}) func(v interface{}) bool {
+	var c Comparisons
+	return func(v interface{}) bool {
+		got, err := c.Lookup(v, path)
+		if err != nil {
+			return false
+		}
+		res, err := c.TryDeepCompare(got, want)
+		return err == nil && res == 0
+	}
+}
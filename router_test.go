@@ -0,0 +1,77 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"strings"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type Manifest struct {
+	APIVersion string
+	Name       string
+}
+
+var _ = Describe("Router", func() {
+	// Names were case-insensitive in v1.
+	v1 := NewComparisonsOrDie(func(m1, m2 Manifest) int {
+		return strings.Compare(strings.ToLower(m1.Name), strings.ToLower(m2.Name))
+	})
+	r := NewRouter(make(Comparisons)).Route(PathEquals(".APIVersion", "v1"), v1)
+
+	It("should route values matching a route", func() {
+		Expect(r.DeepCompare(Manifest{APIVersion: "v1", Name: "A"}, Manifest{APIVersion: "v1", Name: "a"})).To(Equal(0))
+		Expect(r.For(Manifest{APIVersion: "v1"}, Manifest{APIVersion: "v1"})).To(Equal(v1))
+	})
+
+	It("should route other values to the fallback", func() {
+		Expect(r.DeepCompare(Manifest{APIVersion: "v2", Name: "A"}, Manifest{APIVersion: "v2", Name: "a"})).To(Equal(-1))
+		Expect(r.DeepCompare(Manifest{APIVersion: "v1", Name: "A"}, Manifest{APIVersion: "v2", Name: "a"})).To(Equal(-1))
+		Expect(r.TryDeepCompare(1, 2)).To(Equal(-1))
+	})
+
+	It("should route to the first matching route", func() {
+		r := NewRouter(make(Comparisons)).
+			Route(func(v interface{}) bool { _, ok := v.(Manifest); return ok }, v1).
+			Route(PathEquals(".APIVersion", "v1"), make(Comparisons))
+		Expect(r.DeepCompare(Manifest{APIVersion: "v1", Name: "A"}, Manifest{APIVersion: "v1", Name: "a"})).To(Equal(0))
+	})
+
+	It("should diff routed values", func() {
+		diffs, err := r.Diff(Manifest{APIVersion: "v1", Name: "A"}, Manifest{APIVersion: "v1", Name: "a"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diffs).To(BeEmpty())
+		diffs, err = r.Diff(Manifest{APIVersion: "v2", Name: "A"}, Manifest{APIVersion: "v2", Name: "a"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diffs).To(HaveLen(1))
+	})
+
+	Describe("PathEquals", func() {
+		It("should match values with the value at the path", func() {
+			Expect(PathEquals(".APIVersion", "v1")(Manifest{APIVersion: "v1"})).To(BeTrue())
+			Expect(PathEquals(".APIVersion", "v1")(&Manifest{APIVersion: "v1"})).To(BeTrue())
+			Expect(PathEquals(".APIVersion", "v1")(Manifest{APIVersion: "v2"})).To(BeFalse())
+		})
+
+		It("should not match values without the value at the path", func() {
+			Expect(PathEquals(".APIVersion", "v1")((*Manifest)(nil))).To(BeFalse())
+			Expect(PathEquals(".APIVersion", "v1")(1)).To(BeFalse())
+			Expect(PathEquals(".APIVersion", 1)(Manifest{APIVersion: "v1"})).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,133 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ScanTypes checks upfront that values of the types of the given samples can be compared
+// using c, so configuration errors surface at startup instead of on first use.
+//
+// It walks the types recursively, parsing all compare tags and erroring
+// on invalid tags and on fields whose values cannot be compared, e.g. funcs or unexported
+// fields with comparison functions. Fields tagged with `compare:"ignore"` are skipped.
+// Values of interface types are not checked, as their dynamic types are unknown.
+func (c Comparisons) ScanTypes(samples ...interface{}) error {
+	s := &typeScanner{comparisons: c, seen: make(map[reflect.Type]bool)}
+	return s.scanSamples(samples)
+}
+
+// ScanTypes checks upfront that values of the types of the given samples can be compared
+// using c, like Comparisons.ScanTypes. Fields tagged with the names of comparison functions
+// registered via TagComparison are checked as well.
+func (c *Comparer) ScanTypes(samples ...interface{}) error {
+	t := c.traversal
+	s := &typeScanner{comparisons: t.comparisons, parents: t.parents, tagComparisons: t.tagComparisons, fieldTags: t.fieldTags, seen: make(map[reflect.Type]bool)}
+	return s.scanSamples(samples)
+}
+
+func (s *typeScanner) scanSamples(samples []interface{}) error {
+	for _, sample := range samples {
+		t := reflect.TypeOf(sample)
+		if t == nil {
+			return fmt.Errorf("expected sample value, got: nil")
+		}
+		if err := s.scan(t, t.String(), true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type typeScanner struct {
+	comparisons Comparisons
+	// parents are the Comparisons to fall back to, see NewComparisonsWithParent.
+	parents []Comparisons
+	// tagComparisons are the comparison functions by the names fields are tagged with.
+	tagComparisons map[string]reflect.Value
+	// fieldTags are the compare tags of fields set via FieldTags.
+	fieldTags map[reflect.Type]map[string]string
+	// seen are the types already scanned with exported access.
+	seen map[reflect.Type]bool
+}
+
+// scan checks that values of type t can be compared. path describes where t was reached,
+// exported whether t was reached via exported fields only.
+func (s *typeScanner) scan(t reflect.Type, path string, exported bool) error {
+	if exported {
+		if s.seen[t] {
+			return nil
+		}
+		s.seen[t] = true
+	}
+	if _, ok := s.comparisons[t]; ok || hasLayer(s.parents, t) {
+		if !exported {
+			return fmt.Errorf("%s: cannot call comparison function for %v of unexported field", path, t)
+		}
+		return nil
+	}
+	if isOpaqueType(t) {
+		return nil
+	}
+	if exported && (t.Implements(customComparerType) || reflect.PtrTo(t).Implements(customComparerType) || t.Implements(recordType)) {
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return s.scan(t.Elem(), path, exported)
+	case reflect.Map:
+		if err := s.scan(t.Key(), path, exported); err != nil {
+			return err
+		}
+		return s.scan(t.Elem(), path, exported)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			tag, err := parseTagOf(s.fieldTags, t, f)
+			if err != nil {
+				return fmt.Errorf("%s: invalid %s tag: %w", path, tagKey, err)
+			}
+			if tag.ignore {
+				continue
+			}
+			fv, err := tagComparison(s.tagComparisons, f, tag)
+			if err != nil {
+				return fmt.Errorf("%s: invalid %s tag: %w", path, tagKey, err)
+			}
+			if fv.IsValid() {
+				if !exported || f.PkgPath != "" {
+					return fmt.Errorf("%s.%s: cannot call comparison function for %v of unexported field", path, f.Name, f.Type)
+				}
+				continue
+			}
+			if err := s.scan(f.Type, path+"."+f.Name, exported && f.PkgPath == ""); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Func:
+		return fmt.Errorf("%s: cannot compare non-nil funcs of type %v", path, t)
+	case reflect.Complex64, reflect.Complex128:
+		return fmt.Errorf("%s: cannot order values of type %v", path, t)
+	case reflect.Chan, reflect.UnsafePointer:
+		if !exported {
+			return fmt.Errorf("%s: cannot compare %v of unexported field", path, t)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"time"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ScanTypes", func() {
+	It("should accept comparable types", func() {
+		type Tree struct {
+			Name     string
+			Children []*Tree
+			Labels   map[string]string
+			Created  time.Time
+			Hook     func() `compare:"ignore"`
+			Err      error
+			count    int
+		}
+		Expect(NewComparisonsOrDie(CompareTime).ScanTypes(Tree{}, &Release{}, Version{})).To(Succeed())
+	})
+
+	It("should error on invalid tags", func() {
+		type Invalid struct {
+			Inner struct {
+				A int `compare:"unknown"`
+			}
+		}
+		Expect(make(Comparisons).ScanTypes(Invalid{})).To(MatchError(ContainSubstring(`.Inner: invalid compare tag: field A: unknown directive "unknown"`)))
+	})
+
+	It("should error on fields that cannot be compared", func() {
+		type Funcs struct {
+			Handlers map[string]func()
+		}
+		type Hidden struct {
+			created time.Time
+		}
+		type Channel struct {
+			done chan struct{}
+		}
+		type Complex struct {
+			C complex128
+		}
+		c := NewComparisonsOrDie(CompareTime)
+		Expect(c.ScanTypes(Funcs{})).To(MatchError(ContainSubstring("Funcs.Handlers: cannot compare non-nil funcs")))
+		Expect(c.ScanTypes(Hidden{})).To(MatchError(ContainSubstring("Hidden.created: cannot call comparison function")))
+		Expect(c.ScanTypes(Channel{})).To(MatchError(ContainSubstring("Channel.done: cannot compare")))
+		Expect(c.ScanTypes(Complex{})).To(MatchError(ContainSubstring("Complex.C: cannot order")))
+	})
+
+	It("should error on nil samples", func() {
+		Expect(make(Comparisons).ScanTypes(nil)).NotTo(Succeed())
+	})
+})
@@ -0,0 +1,172 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/adracus/reflcompare"
+)
+
+// Comparator compares dynamic JSON values according to a schema.
+type Comparator struct {
+	comparisons reflcompare.Comparisons
+	schema      *Schema
+}
+
+// New creates a new Comparator for values of schema s. Values without a schema, e.g.
+// undeclared properties, and values of other formats are compared using c.
+func New(c reflcompare.Comparisons, s *Schema) *Comparator {
+	return &Comparator{comparisons: c, schema: s}
+}
+
+// Compare compares the JSON values a and b, as decoded by encoding/json into interface{}.
+// Missing and null values are less than any other value.
+//
+// Compare returns an error if the values cannot be compared, e.g. because they are of
+// different types or because strings do not match their declared format.
+func (c *Comparator) Compare(a, b interface{}) (int, error) {
+	return c.compare(c.schema, a, b)
+}
+
+func (c *Comparator) compare(s *Schema, a, b interface{}) (int, error) {
+	if s != nil && s.Ref != "" {
+		s = c.schema.Defs[s.Ref]
+	}
+	if s != nil && s.Ignore {
+		return 0, nil
+	}
+	if a == nil || b == nil {
+		return compareBool(a != nil, b != nil), nil
+	}
+	if s == nil {
+		return c.comparisons.TryDeepCompare(a, b)
+	}
+
+	switch a := a.(type) {
+	case map[string]interface{}:
+		if b, ok := b.(map[string]interface{}); ok {
+			return c.compareObjects(s, a, b)
+		}
+	case []interface{}:
+		if b, ok := b.([]interface{}); ok {
+			return c.compareArrays(s, a, b)
+		}
+	case string:
+		if b, ok := b.(string); ok {
+			return c.compareStrings(s, a, b)
+		}
+	}
+	return c.comparisons.TryDeepCompare(a, b)
+}
+
+func (c *Comparator) compareObjects(s *Schema, a, b map[string]interface{}) (int, error) {
+	declared := make(map[string]bool, len(s.Properties))
+	for _, p := range s.Properties {
+		declared[p.Name] = true
+		res, err := c.compare(p.Schema, a[p.Name], b[p.Name])
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", p.Name, err)
+		}
+		if res != 0 {
+			return res, nil
+		}
+	}
+
+	var undeclared []string
+	for _, m := range []map[string]interface{}{a, b} {
+		for k := range m {
+			if !declared[k] {
+				declared[k] = true
+				undeclared = append(undeclared, k)
+			}
+		}
+	}
+	sort.Strings(undeclared)
+	for _, k := range undeclared {
+		res, err := c.compare(nil, a[k], b[k])
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", k, err)
+		}
+		if res != 0 {
+			return res, nil
+		}
+	}
+	return 0, nil
+}
+
+func (c *Comparator) compareArrays(s *Schema, a, b []interface{}) (int, error) {
+	// Like DeepCompare, order arrays by their lengths first.
+	if res := len(a) - len(b); res != 0 {
+		return res, nil
+	}
+	for i := range a {
+		res, err := c.compare(s.Items, a[i], b[i])
+		if err != nil {
+			return 0, fmt.Errorf("[%d]: %w", i, err)
+		}
+		if res != 0 {
+			return res, nil
+		}
+	}
+	return 0, nil
+}
+
+func (c *Comparator) compareStrings(s *Schema, a, b string) (int, error) {
+	switch s.Format {
+	case "date-time":
+		return compareTimes(time.RFC3339Nano, a, b)
+	case "date":
+		return compareTimes("2006-01-02", a, b)
+	case "byte":
+		b1, err := base64.StdEncoding.DecodeString(a)
+		if err != nil {
+			return 0, err
+		}
+		b2, err := base64.StdEncoding.DecodeString(b)
+		if err != nil {
+			return 0, err
+		}
+		return bytes.Compare(b1, b2), nil
+	}
+	return c.comparisons.TryDeepCompare(a, b)
+}
+
+func compareTimes(layout, a, b string) (int, error) {
+	t1, err := time.Parse(layout, a)
+	if err != nil {
+		return 0, err
+	}
+	t2, err := time.Parse(layout, b)
+	if err != nil {
+		return 0, err
+	}
+	return reflcompare.CompareTime(t1, t2), nil
+}
+
+func compareBool(b1, b2 bool) int {
+	switch {
+	case b1 == b2:
+		return 0
+	case b1:
+		return 1
+	default:
+		return -1
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package schema compares dynamic JSON values, e.g. decoded into map[string]interface{},
+// according to a JSON Schema or OpenAPI schema instead of Go struct reflection.
+//
+// Objects are compared property by property in the order the properties are declared
+// in the schema, followed by undeclared properties in key order. Properties marked with
+// the extension keyword 'x-compare-ignore' are skipped. Strings are compared according
+// to their format: 'date-time' and 'date' strings as points in time, 'byte' strings by
+// their base64-decoded bytes. All other values are compared like reflcompare.DeepCompare.
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Schema is the part of a JSON Schema relevant for comparing values.
+type Schema struct {
+	// Type is the type of the values, e.g. 'object'. For a list of types, it is the first type besides 'null'.
+	Type string
+	// Format is the format of string values, e.g. 'date-time'.
+	Format string
+	// Properties are the properties of object values, in declaration order.
+	Properties []Property
+	// Items is the schema of the items of array values.
+	Items *Schema
+	// Ignore excludes the values from comparisons. It is set via 'x-compare-ignore'.
+	Ignore bool
+	// Ref is the reference to another schema, e.g. '#/$defs/Item'.
+	Ref string
+	// Defs are the schemas that can be referenced, by reference, e.g. '#/$defs/Item'.
+	// Only the Defs of the root schema are used to resolve references.
+	Defs map[string]*Schema
+}
+
+// Property is a property of an object schema.
+type Property struct {
+	Name   string
+	Schema *Schema
+}
+
+// UnmarshalJSON implements json.Unmarshaler, retaining the declaration order of properties.
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Type        json.RawMessage    `json:"type"`
+		Format      string             `json:"format"`
+		Properties  json.RawMessage    `json:"properties"`
+		Items       *Schema            `json:"items"`
+		Ignore      bool               `json:"x-compare-ignore"`
+		Ref         string             `json:"$ref"`
+		Defs        map[string]*Schema `json:"$defs"`
+		Definitions map[string]*Schema `json:"definitions"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	typ, err := parseType(raw.Type)
+	if err != nil {
+		return err
+	}
+	properties, err := parseProperties(raw.Properties)
+	if err != nil {
+		return err
+	}
+	*s = Schema{
+		Type:       typ,
+		Format:     raw.Format,
+		Properties: properties,
+		Items:      raw.Items,
+		Ignore:     raw.Ignore,
+		Ref:        raw.Ref,
+	}
+	s.addDefs("#/$defs/", raw.Defs)
+	s.addDefs("#/definitions/", raw.Definitions)
+	return nil
+}
+
+func (s *Schema) addDefs(prefix string, defs map[string]*Schema) {
+	for name, def := range defs {
+		if s.Defs == nil {
+			s.Defs = make(map[string]*Schema)
+		}
+		s.Defs[prefix+name] = def
+	}
+}
+
+// parseType parses the type of a schema, which is either a single type or a list of types.
+func parseType(data json.RawMessage) (string, error) {
+	if len(data) == 0 {
+		return "", nil
+	}
+	var typ string
+	if err := json.Unmarshal(data, &typ); err == nil {
+		return typ, nil
+	}
+	var types []string
+	if err := json.Unmarshal(data, &types); err != nil {
+		return "", fmt.Errorf("invalid type: %s", data)
+	}
+	for _, typ := range types {
+		if typ != "null" {
+			return typ, nil
+		}
+	}
+	return "null", nil
+}
+
+// parseProperties parses the properties of a schema in declaration order.
+func parseProperties(data json.RawMessage) ([]Property, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, fmt.Errorf("invalid properties: %s", data)
+	}
+	var res []Property
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		s := &Schema{}
+		if err := dec.Decode(s); err != nil {
+			return nil, fmt.Errorf("property %s: %w", tok, err)
+		}
+		res = append(res, Property{Name: tok.(string), Schema: s})
+	}
+	return res, nil
+}
+
+// Parse parses a JSON Schema document. References are resolved against its '$defs' and 'definitions'.
+func Parse(doc []byte) (*Schema, error) {
+	s := &Schema{}
+	if err := json.Unmarshal(doc, s); err != nil {
+		return nil, err
+	}
+	if err := s.check(s.Defs, make(map[*Schema]bool)); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// ParseOpenAPI parses the schema with the given name from the 'components.schemas' of an
+// OpenAPI document. References are resolved against the other schemas of the document.
+func ParseOpenAPI(doc []byte, name string) (*Schema, error) {
+	var raw struct {
+		Components struct {
+			Schemas map[string]*Schema `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(doc, &raw); err != nil {
+		return nil, err
+	}
+	schemas := raw.Components.Schemas
+	if schemas[name] == nil {
+		return nil, fmt.Errorf("schema %q not found", name)
+	}
+	s := *schemas[name]
+	s.addDefs("#/components/schemas/", schemas)
+	if err := s.check(s.Defs, make(map[*Schema]bool)); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// check checks that all references of s can be resolved against defs.
+func (s *Schema) check(defs map[string]*Schema, seen map[*Schema]bool) error {
+	if s == nil || seen[s] {
+		return nil
+	}
+	seen[s] = true
+	if s.Ref != "" {
+		def, ok := defs[s.Ref]
+		if !ok {
+			return fmt.Errorf("unresolved reference %q", s.Ref)
+		}
+		return def.check(defs, seen)
+	}
+	for _, p := range s.Properties {
+		if err := p.Schema.check(defs, seen); err != nil {
+			return fmt.Errorf("property %s: %w", p.Name, err)
+		}
+	}
+	for _, def := range s.Defs {
+		if err := def.check(defs, seen); err != nil {
+			return err
+		}
+	}
+	return s.Items.check(defs, seen)
+}
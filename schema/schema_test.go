@@ -0,0 +1,127 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema_test
+
+import (
+	"encoding/json"
+
+	"github.com/adracus/reflcompare"
+	. "github.com/adracus/reflcompare/schema"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const doc = `{
+	"type": "object",
+	"properties": {
+		"name": {"type": "string"},
+		"created": {"type": "string", "format": "date-time"},
+		"etag": {"type": "string", "x-compare-ignore": true},
+		"items": {"type": "array", "items": {"$ref": "#/$defs/Item"}}
+	},
+	"$defs": {
+		"Item": {
+			"type": ["object", "null"],
+			"properties": {
+				"data": {"type": "string", "format": "byte"},
+				"id": {"type": "integer"}
+			}
+		}
+	}
+}`
+
+func decode(s string) interface{} {
+	var v interface{}
+	Expect(json.Unmarshal([]byte(s), &v)).To(Succeed())
+	return v
+}
+
+var _ = Describe("Schema", func() {
+	var c *Comparator
+	BeforeEach(func() {
+		s, err := Parse([]byte(doc))
+		Expect(err).NotTo(HaveOccurred())
+		c = New(make(reflcompare.Comparisons), s)
+	})
+
+	Describe("Parse", func() {
+		It("should retain the declaration order of properties", func() {
+			s, err := Parse([]byte(doc))
+			Expect(err).NotTo(HaveOccurred())
+			var names []string
+			for _, p := range s.Properties {
+				names = append(names, p.Name)
+			}
+			Expect(names).To(Equal([]string{"name", "created", "etag", "items"}))
+			Expect(s.Defs["#/$defs/Item"].Type).To(Equal("object"))
+		})
+
+		It("should error on unresolved references", func() {
+			_, err := Parse([]byte(`{"properties": {"a": {"$ref": "#/$defs/Missing"}}}`))
+			Expect(err).To(MatchError(ContainSubstring("unresolved reference")))
+		})
+	})
+
+	Describe("ParseOpenAPI", func() {
+		It("should resolve references to component schemas", func() {
+			s, err := ParseOpenAPI([]byte(`{"components": {"schemas": {
+				"Pet": {"properties": {"born": {"$ref": "#/components/schemas/Date"}}},
+				"Date": {"type": "string", "format": "date"}
+			}}}`), "Pet")
+			Expect(err).NotTo(HaveOccurred())
+			c := New(make(reflcompare.Comparisons), s)
+			Expect(c.Compare(decode(`{"born": "2021-01-02"}`), decode(`{"born": "2021-01-10"}`))).To(Equal(-1))
+		})
+
+		It("should error on missing schemas", func() {
+			_, err := ParseOpenAPI([]byte(`{}`), "Pet")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Compare", func() {
+		It("should compare properties in declaration order", func() {
+			Expect(c.Compare(decode(`{"name": "a", "created": "2021-01-02T00:00:00Z"}`), decode(`{"name": "b", "created": "2020-01-01T00:00:00Z"}`))).To(Equal(-1))
+		})
+
+		It("should compare date-times as points in time", func() {
+			Expect(c.Compare(decode(`{"created": "2021-01-02T01:00:00+01:00"}`), decode(`{"created": "2021-01-02T00:00:00Z"}`))).To(Equal(0))
+			Expect(c.Compare(decode(`{"created": "2021-01-02T00:00:00+01:00"}`), decode(`{"created": "2021-01-02T00:00:00Z"}`))).To(Equal(-1))
+		})
+
+		It("should skip ignored properties", func() {
+			Expect(c.Compare(decode(`{"etag": "x"}`), decode(`{"etag": "y"}`))).To(Equal(0))
+		})
+
+		It("should compare undeclared properties last, in key order", func() {
+			Expect(c.Compare(decode(`{"b": 1, "z": 2}`), decode(`{"b": 2, "z": 1}`))).To(Equal(-1))
+		})
+
+		It("should compare referenced item schemas", func() {
+			// "AQ==" is [1], "Ag==" is [2].
+			Expect(c.Compare(decode(`{"items": [{"data": "Ag==", "id": 1}]}`), decode(`{"items": [{"data": "AQ==", "id": 2}]}`))).To(Equal(1))
+		})
+
+		It("should order missing and null values first", func() {
+			Expect(c.Compare(decode(`{}`), decode(`{"name": "a"}`))).To(Equal(-1))
+			Expect(c.Compare(decode(`{"items": [null]}`), decode(`{"items": [{}]}`))).To(Equal(-1))
+		})
+
+		It("should error on values not matching their format", func() {
+			_, err := c.Compare(decode(`{"created": "yesterday"}`), decode(`{"created": "today"}`))
+			Expect(err).To(MatchError(ContainSubstring("created")))
+		})
+	})
+})
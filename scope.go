@@ -0,0 +1,421 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+)
+
+// ForType scopes the given options to values of the type of sample, including all
+// values nested in them. Outside of such values, the options do not apply.
+// This allows e.g. comparing maps as sets (see CompareAsSets) in one part of an
+// object only.
+//
+// Scoped options are applied on top of the options in effect when reaching a value
+// of the type, so scopes nest. Options that only affect the compared values themselves,
+// like Strict, have no effect when scoped.
+//
+// ForType panics if sample is an untyped nil or if the options refer to types that
+// cannot be nested in values of the type of sample, as they would never apply.
+func ForType(sample interface{}, opts ...Option) Option {
+	if sample == nil {
+		panic("expected sample, got: nil")
+	}
+	t := reflect.TypeOf(sample)
+	var scoped options
+	for _, opt := range opts {
+		opt(&scoped)
+	}
+	nested, dynamic := nestedTypes(t)
+	for _, r := range scoped.referencedTypes() {
+		if !nested[r] && !dynamic {
+			panic(fmt.Sprintf("options scoped to type %v refer to type %v, which is not nested in it", t, r))
+		}
+	}
+	return func(o *options) {
+		if o.scoped == nil {
+			o.scoped = make(map[reflect.Type][]Option)
+		}
+		o.scoped[t] = append(o.scoped[t], opts...)
+	}
+}
+
+// ForTypesMatching scopes the given options to values of the types whose qualified names
+// match the regular expression pattern, like ForType. Qualified names consist of the package
+// path and the name of a type, e.g. 'example.com/internal/generated.Object', so options can be
+// applied to all types of a package and the packages below it. Only named types match.
+//
+// ForTypesMatching panics if pattern is not a valid regular expression.
+func ForTypesMatching(pattern string, opts ...Option) Option {
+	re := regexp.MustCompile(pattern)
+	return func(o *options) {
+		o.typeScopes = append(o.typeScopes, typeScope{re: re, opts: opts})
+	}
+}
+
+// typeScope are options scoped to the values of types whose qualified names match re.
+type typeScope struct {
+	re   *regexp.Regexp
+	opts []Option
+}
+
+// resolveTypeScopes scopes the options of the type scopes matching typ to typ, once per
+// type and scope.
+func (t *traversal) resolveTypeScopes(typ reflect.Type) {
+	s := t.scope
+	if len(s.typeScopes) == 0 || s.resolved[typ] {
+		return
+	}
+	s.resolved[typ] = true
+	if typ.Name() == "" {
+		return
+	}
+	name := typ.PkgPath() + "." + typ.Name()
+	for _, ts := range s.typeScopes {
+		if !ts.re.MatchString(name) {
+			continue
+		}
+		if s.scoped == nil {
+			s.scoped = make(map[reflect.Type][]Option)
+		}
+		s.scoped[typ] = append(s.scoped[typ], ts.opts...)
+	}
+	t.setScope(s)
+}
+
+// AtPath scopes the given options to the values at paths matching pattern, including all
+// values nested in them. Paths are relative to the compared values. Patterns consist of
+// the following segments, separated by dots or followed by brackets, e.g. 'Spec.Containers[*].Env[*].Value':
+//
+//	Name	the struct field Name.
+//	*	any struct field.
+//	[N]	the element with index N of an array or slice.
+//	[*]	any element of an array or slice, or any entry of a map.
+//	**	any number of steps, including none, e.g. '**.Value' matches fields Value at any depth.
+//
+// Like ForType, AtPath has no effect when scoped itself.
+//
+// AtPath panics if pattern is malformed.
+func AtPath(pattern string, opts ...Option) Option {
+	p, err := compilePathPattern(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return func(o *options) {
+		o.atPaths = append(o.atPaths, pathScope{pattern: p, opts: opts})
+	}
+}
+
+// pathScope are options scoped to the values at paths matching a pattern.
+type pathScope struct {
+	pattern *pathPattern
+	opts    []Option
+}
+
+// pathTracker tracks the path of the compared values, applying path scopes to the values
+// at matching paths.
+type pathTracker struct {
+	scopes []pathScope
+	// states are the states of the matchers of scopes per entered step, flattened.
+	states []uint64
+	// prev are the scopes in effect before entering each step.
+	prev []*scope
+}
+
+func newPathTracker(scopes []pathScope) *pathTracker {
+	p := &pathTracker{scopes: scopes}
+	for _, ps := range scopes {
+		p.states = append(p.states, ps.pattern.start())
+	}
+	return p
+}
+
+// enterStep advances the path of the traversal by the step s, entering the scopes of all
+// path scopes matching the resulting path. It has to be followed by a call to leaveStep.
+func (t *traversal) enterStep(s pathStep) {
+	p := t.paths
+	n := len(p.scopes)
+	cur := p.states[len(p.states)-n:]
+	p.prev = append(p.prev, t.scope)
+	next := t.scope
+	for i, ps := range p.scopes {
+		states := ps.pattern.step(cur[i], s)
+		p.states = append(p.states, states)
+		if ps.pattern.accepts(states) && !next.enteredPaths[i] {
+			next = next.pathChild(i, ps.opts)
+		}
+	}
+	t.setScope(next)
+}
+
+// leaveStep reverts the last call to enterStep.
+func (t *traversal) leaveStep() {
+	p := t.paths
+	p.states = p.states[:len(p.states)-len(p.scopes)]
+	prev := p.prev[len(p.prev)-1]
+	p.prev = p.prev[:len(p.prev)-1]
+	t.setScope(prev)
+}
+
+// deepValueCompareAt compares v1 and v2 nested in the current values at the step s.
+func (t *traversal) deepValueCompareAt(s pathStep, v1, v2 reflect.Value, depth int) (int, error) {
+	if t.paths != nil {
+		t.enterStep(s)
+		defer t.leaveStep()
+	}
+	return t.deepValueCompare(v1, v2, depth)
+}
+
+// referencedTypes returns the types o refers to, sorted by name.
+func (o options) referencedTypes() []reflect.Type {
+	set := make(map[reflect.Type]bool)
+	for _, types := range []map[reflect.Type]bool{o.dynamicTypeOrder, o.markers, o.opaque} {
+		for t := range types {
+			set[t] = true
+		}
+	}
+	for t := range o.versions {
+		set[t] = true
+	}
+	for t := range o.formatters {
+		set[t] = true
+	}
+	for t := range o.fieldWeights {
+		set[t] = true
+	}
+	for t := range o.defaults {
+		set[t] = true
+	}
+	for t := range o.fieldDefaults {
+		set[t] = true
+	}
+	for t := range o.fieldTags {
+		set[t] = true
+	}
+	for t := range o.scoped {
+		set[t] = true
+	}
+	res := make([]reflect.Type, 0, len(set))
+	for t := range set {
+		res = append(res, t)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].String() < res[j].String()
+	})
+	return res
+}
+
+// nestedTypes returns the types of values that can be nested in values of type t,
+// including t itself. If values of any type can be nested via interfaces, dynamic is true.
+func nestedTypes(t reflect.Type) (nested map[reflect.Type]bool, dynamic bool) {
+	nested = make(map[reflect.Type]bool)
+	var walk func(t reflect.Type)
+	walk = func(t reflect.Type) {
+		if nested[t] {
+			return
+		}
+		nested[t] = true
+		switch t.Kind() {
+		case reflect.Interface:
+			dynamic = true
+		case reflect.Ptr, reflect.Slice, reflect.Array:
+			walk(t.Elem())
+		case reflect.Map:
+			walk(t.Key())
+			walk(t.Elem())
+		case reflect.Struct:
+			for i := 0; i < t.NumField(); i++ {
+				walk(t.Field(i).Type)
+			}
+		}
+	}
+	walk(t)
+	return nested, dynamic
+}
+
+// scope is the state of a traversal that depends on the options in effect.
+type scope struct {
+	options
+	// structs caches the fields to compare per struct type.
+	structs map[reflect.Type][]structField
+	// incomparable caches per struct type whether its values cannot be compared regardless
+	// of their contents, see incomparableStruct.
+	incomparable map[reflect.Type]*UnexportedFieldError
+	// entered are the types whose scoped options have been applied to the scope.
+	entered map[reflect.Type]bool
+	// resolved are the types matched against the type scopes of the scope, see ForTypesMatching.
+	resolved map[reflect.Type]bool
+	// enteredPaths are the indices of the path scopes whose options have been applied to the scope.
+	enteredPaths map[int]bool
+	// children are the scopes entered from this scope, by type.
+	children map[reflect.Type]*scope
+	// pathChildren are the scopes entered from this scope, by path scope index.
+	pathChildren map[int]*scope
+	// redacted is the scope entered from this scope for redacted fields, if any.
+	redacted *scope
+}
+
+func newScope(opts options) *scope {
+	return &scope{
+		options:      opts,
+		structs:      make(map[reflect.Type][]structField),
+		incomparable: make(map[reflect.Type]*UnexportedFieldError),
+		entered:      make(map[reflect.Type]bool),
+		resolved:     make(map[reflect.Type]bool),
+		enteredPaths: make(map[int]bool),
+		children:     make(map[reflect.Type]*scope),
+		pathChildren: make(map[int]*scope),
+	}
+}
+
+// child returns the scope for values of type typ nested in s.
+// Child scopes are computed once and cached.
+func (s *scope) child(typ reflect.Type) *scope {
+	if c, ok := s.children[typ]; ok {
+		return c
+	}
+	opts := s.options.clone()
+	for _, opt := range s.scoped[typ] {
+		opt(&opts)
+	}
+	c := s.newChild(opts)
+	c.entered[typ] = true
+	s.children[typ] = c
+	return c
+}
+
+// pathChild returns the scope for values at paths matching the i-th path scope with the
+// given options, nested in s. Child scopes are computed once and cached.
+func (s *scope) pathChild(i int, scoped []Option) *scope {
+	if c, ok := s.pathChildren[i]; ok {
+		return c
+	}
+	opts := s.options.clone()
+	for _, opt := range scoped {
+		opt(&opts)
+	}
+	c := s.newChild(opts)
+	c.enteredPaths[i] = true
+	s.pathChildren[i] = c
+	return c
+}
+
+// newChild creates a scope nested in s with the given options.
+func (s *scope) newChild(opts options) *scope {
+	c := newScope(opts)
+	for entered := range s.entered {
+		c.entered[entered] = true
+	}
+	for entered := range s.enteredPaths {
+		c.enteredPaths[entered] = true
+	}
+	return c
+}
+
+// setScope makes s the current scope of the traversal.
+func (t *traversal) setScope(s *scope) {
+	t.scope = s
+	t.options = s.options
+	t.structs = s.structs
+	t.incomparable = s.incomparable
+}
+
+// clone deeply copies o, so applying options to the copy does not modify o.
+func (o options) clone() options {
+	res := o
+	res.dynamicTypeOrder = cloneTypeSet(o.dynamicTypeOrder)
+	res.markers = cloneTypeSet(o.markers)
+	res.opaque = cloneTypeSet(o.opaque)
+	if o.versions != nil {
+		res.versions = make(map[reflect.Type]reflect.Value, len(o.versions))
+		for t, fv := range o.versions {
+			res.versions[t] = fv
+		}
+	}
+	if o.formatters != nil {
+		res.formatters = make(map[reflect.Type]reflect.Value, len(o.formatters))
+		for t, fv := range o.formatters {
+			res.formatters[t] = fv
+		}
+	}
+	if o.fieldWeights != nil {
+		res.fieldWeights = make(map[reflect.Type]map[string]int, len(o.fieldWeights))
+		for t, weights := range o.fieldWeights {
+			res.fieldWeights[t] = make(map[string]int, len(weights))
+			for name, weight := range weights {
+				res.fieldWeights[t][name] = weight
+			}
+		}
+	}
+	if o.defaults != nil {
+		res.defaults = make(map[reflect.Type]reflect.Value, len(o.defaults))
+		for t, v := range o.defaults {
+			res.defaults[t] = v
+		}
+	}
+	if o.fieldDefaults != nil {
+		res.fieldDefaults = make(map[reflect.Type]map[string]reflect.Value, len(o.fieldDefaults))
+		for t, defaults := range o.fieldDefaults {
+			res.fieldDefaults[t] = make(map[string]reflect.Value, len(defaults))
+			for name, v := range defaults {
+				res.fieldDefaults[t][name] = v
+			}
+		}
+	}
+	if o.fieldTags != nil {
+		res.fieldTags = make(map[reflect.Type]map[string]string, len(o.fieldTags))
+		for t, tags := range o.fieldTags {
+			res.fieldTags[t] = make(map[string]string, len(tags))
+			for name, value := range tags {
+				res.fieldTags[t][name] = value
+			}
+		}
+	}
+	if o.mergeKeys != nil {
+		res.mergeKeys = make(map[reflect.Type][]int, len(o.mergeKeys))
+		for t, key := range o.mergeKeys {
+			res.mergeKeys[t] = key
+		}
+	}
+	if o.tagComparisons != nil {
+		res.tagComparisons = make(map[string]reflect.Value, len(o.tagComparisons))
+		for name, fv := range o.tagComparisons {
+			res.tagComparisons[name] = fv
+		}
+	}
+	res.typeScopes = append([]typeScope(nil), o.typeScopes...)
+	res.atPaths = append([]pathScope(nil), o.atPaths...)
+	if o.scoped != nil {
+		res.scoped = make(map[reflect.Type][]Option, len(o.scoped))
+		for t, opts := range o.scoped {
+			res.scoped[t] = append([]Option(nil), opts...)
+		}
+	}
+	return res
+}
+
+func cloneTypeSet(s map[reflect.Type]bool) map[reflect.Type]bool {
+	if s == nil {
+		return nil
+	}
+	res := make(map[reflect.Type]bool, len(s))
+	for t := range s {
+		res[t] = true
+	}
+	return res
+}
@@ -0,0 +1,188 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"fmt"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type Selector struct {
+	MatchLabels map[string]bool
+}
+
+type Deployment struct {
+	Selector Selector
+	Features map[string]bool
+}
+
+type EnvVar struct {
+	Name, Value string
+}
+
+type Container struct {
+	Name string
+	Env  []EnvVar
+}
+
+type Pod struct {
+	Spec struct {
+		Containers []Container
+	}
+	Labels map[string]string
+}
+
+func newPod(values ...string) Pod {
+	var pod Pod
+	for i, value := range values {
+		pod.Spec.Containers = append(pod.Spec.Containers, Container{
+			Name: fmt.Sprintf("c%d", i),
+			Env:  []EnvVar{{Name: "A", Value: value}},
+		})
+	}
+	return pod
+}
+
+var _ = Describe("ForType", func() {
+	It("should apply options to values of the type only", func() {
+		cmp := make(Comparisons).NewComparer(ForType(Selector{}, CompareAsSets()))
+
+		Expect(cmp.Compare(
+			Deployment{Selector: Selector{MatchLabels: map[string]bool{"a": true, "b": false}}},
+			Deployment{Selector: Selector{MatchLabels: map[string]bool{"a": true}}},
+		)).To(Equal(0))
+		Expect(cmp.Compare(
+			Deployment{Features: map[string]bool{"a": true, "b": false}},
+			Deployment{Features: map[string]bool{"a": true}},
+		)).To(Equal(1))
+	})
+
+	It("should nest scopes", func() {
+		cmp := make(Comparisons).NewComparer(ForType(Deployment{},
+			CompareAsSets(),
+			ForType(Selector{}, FieldWeights(Selector{}, map[string]int{"MatchLabels": 1})),
+		))
+		Expect(cmp.Compare(
+			Deployment{Selector: Selector{MatchLabels: map[string]bool{"a": true, "b": false}}},
+			Deployment{Selector: Selector{MatchLabels: map[string]bool{"a": true}}},
+		)).To(Equal(0))
+	})
+
+	It("should not leak scoped options", func() {
+		cmp := make(Comparisons).NewComparer(
+			Markers(Release{}),
+			ForType(Deployment{}, Markers(Selector{})),
+		)
+		Expect(cmp.Compare(Deployment{Selector: Selector{MatchLabels: map[string]bool{"a": true}}}, Deployment{})).To(Equal(0))
+		Expect(cmp.Compare(Selector{MatchLabels: map[string]bool{"a": true}}, Selector{MatchLabels: map[string]bool{"b": true}})).NotTo(Equal(0))
+		Expect(cmp.Compare(Release{Major: 1}, Release{})).To(Equal(0))
+	})
+
+	It("should panic on options referring to types not nested in the type", func() {
+		Expect(func() { ForType(Selector{}, Markers(Release{})) }).To(Panic())
+		Expect(func() { ForType(Selector{}, FieldWeights(Deployment{}, map[string]int{"Features": 1})) }).To(Panic())
+		Expect(func() { ForType(Deployment{}, ForType(Release{})) }).To(Panic())
+		Expect(func() { ForType(Deployment{}, Markers(Selector{}), ForType(map[string]bool{})) }).NotTo(Panic())
+		Expect(func() { ForType([]interface{}{}, Markers(Release{})) }).NotTo(Panic())
+	})
+
+	It("should panic on an untyped nil sample", func() {
+		Expect(func() { ForType(nil) }).To(Panic())
+	})
+})
+
+var _ = Describe("ForTypesMatching", func() {
+	type Hidden struct{ c chan int }
+
+	It("should apply options to values of types with matching names", func() {
+		cmp := make(Comparisons).NewComparer(ForTypesMatching(`reflcompare_test\.(Selector|Release)$`, CompareAsSets()))
+		Expect(cmp.Compare(
+			Deployment{Selector: Selector{MatchLabels: map[string]bool{"a": true, "b": false}}},
+			Deployment{Selector: Selector{MatchLabels: map[string]bool{"a": true}}},
+		)).To(Equal(0))
+		Expect(cmp.Compare(
+			Deployment{Features: map[string]bool{"a": true, "b": false}},
+			Deployment{Features: map[string]bool{"a": true}},
+		)).To(Equal(1))
+	})
+
+	It("should match package paths", func() {
+		cmp := make(Comparisons).NewComparer(ForTypesMatching(`^github\.com/adracus/reflcompare_test\.`, Lenient()))
+		Expect(cmp.Compare([]Hidden{{make(chan int)}}, []Hidden{{make(chan int)}})).To(Equal(0))
+		Expect(func() { make(Comparisons).NewComparer().Compare(Hidden{make(chan int)}, Hidden{make(chan int)}) }).To(Panic())
+	})
+
+	It("should panic on invalid patterns", func() {
+		Expect(func() { ForTypesMatching("(") }).To(Panic())
+	})
+})
+
+var _ = Describe("AtPath", func() {
+	c := make(Comparisons)
+
+	It("should apply options to values at matching paths only", func() {
+		cmp := c.NewComparer(AtPath("Spec.Containers[*].Env[*].Value", Markers("")))
+		Expect(cmp.Compare(newPod("a", "b"), newPod("b", "a"))).To(Equal(0))
+
+		pod := newPod("a")
+		pod.Spec.Containers[0].Env[0].Name = "B"
+		Expect(cmp.Compare(newPod("a"), pod)).To(Equal(-1))
+	})
+
+	It("should match indices", func() {
+		cmp := c.NewComparer(AtPath("Spec.Containers[1]", Markers("")))
+		Expect(cmp.Compare(newPod("a", "b"), newPod("a", "c"))).To(Equal(0))
+		Expect(cmp.Compare(newPod("a", "b"), newPod("b", "b"))).To(Equal(-1))
+	})
+
+	It("should match any field and deep paths", func() {
+		Expect(c.NewComparer(AtPath("**.Value", Markers(""))).Compare(newPod("a"), newPod("b"))).To(Equal(0))
+		Expect(c.NewComparer(AtPath("Spec.**", Markers(""))).Compare(newPod("a"), newPod("b"))).To(Equal(0))
+		Expect(c.NewComparer(AtPath("**", Markers(""))).Compare(newPod("a"), newPod("b"))).To(Equal(0))
+		Expect(c.NewComparer(AtPath("*.Containers", Markers(""))).Compare(newPod("a"), newPod("b"))).To(Equal(0))
+		Expect(c.NewComparer(AtPath("*.Value", Markers(""))).Compare(newPod("a"), newPod("b"))).To(Equal(-1))
+	})
+
+	It("should match map entries", func() {
+		cmp := c.NewComparer(AtPath("Labels[*]", Markers("")))
+		Expect(cmp.Compare(Pod{Labels: map[string]string{"a": "x"}}, Pod{Labels: map[string]string{"a": "y"}})).To(Equal(0))
+		Expect(cmp.Compare(Pod{Labels: map[string]string{"a": "x"}}, Pod{Labels: map[string]string{"b": "x"}})).NotTo(Equal(0))
+	})
+
+	It("should apply to diffs", func() {
+		diffs, err := c.Diff(newPod("a", "b"), newPod("c", "d"), AtPath("Spec.Containers[0]", Markers("")))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diffs).To(HaveLen(1))
+		Expect(diffs[0].Path).To(Equal(".Spec.Containers[1].Env[0].Value"))
+	})
+
+	It("should apply options to fields with comparison functions", func() {
+		type Inner struct{ V int }
+		type WithHidden struct{ s Inner }
+		c := NewComparisonsOrDie(func(a, b Inner) int { return a.V - b.V })
+		_, err := c.NewComparer().TryCompare(WithHidden{Inner{1}}, WithHidden{Inner{2}})
+		Expect(err).To(MatchError(ErrUnexportedField))
+		Expect(c.NewComparer(AtPath("s", Lenient())).Compare(WithHidden{Inner{1}}, WithHidden{Inner{2}})).To(Equal(0))
+	})
+
+	It("should panic on malformed patterns", func() {
+		for _, pattern := range []string{"", "Spec.", "Spec..Containers", "Spec[", "Spec[-1]", "Spec[x]", "Spec[*]Env"} {
+			Expect(func() { AtPath(pattern) }).To(Panic(), pattern)
+		}
+	})
+})
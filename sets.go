@@ -0,0 +1,102 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"reflect"
+)
+
+// isSetType reports whether t is a map type used as set, i.e. map[K]struct{} or map[K]bool.
+func isSetType(t reflect.Type) bool {
+	if t.Kind() != reflect.Map {
+		return false
+	}
+	elem := t.Elem()
+	return elem.Kind() == reflect.Bool || (elem.Kind() == reflect.Struct && elem.NumField() == 0)
+}
+
+// setMembers returns the members of the set m in order. Keys of a map[K]bool
+// are only members if they map to true.
+func (t *traversal) setMembers(m reflect.Value) ([]reflect.Value, error) {
+	keys, err := t.sortedKeys(m)
+	if err != nil || m.Type().Elem().Kind() != reflect.Bool {
+		return keys, err
+	}
+	members := keys[:0]
+	for _, k := range keys {
+		if m.MapIndex(k).Bool() {
+			members = append(members, k)
+		}
+	}
+	return members, nil
+}
+
+// diffSets reports the members of the sets s1 and s2 present in only one of them, in order.
+func (d *differ) diffSets(s1, s2 reflect.Value, depth int) error {
+	t := d.traversal
+	m1, err := t.setMembers(s1)
+	if err != nil {
+		return prependStep(err, d.path())
+	}
+	m2, err := t.setMembers(s2)
+	if err != nil {
+		return prependStep(err, d.path())
+	}
+	for i, j := 0, 0; i < len(m1) || j < len(m2); {
+		res := 1
+		if i < len(m1) && j < len(m2) {
+			if res, err = t.deepValueCompare(m1[i], m2[j], depth+1); err != nil {
+				return prependStep(err, d.path())
+			}
+		} else if i < len(m1) {
+			res = -1
+		}
+		switch {
+		case res < 0:
+			d.reportAt(keyElem(m1[i]), s1.MapIndex(m1[i]), reflect.Value{}, "member removed")
+			i++
+		case res > 0:
+			d.reportAt(keyElem(m2[j]), reflect.Value{}, s2.MapIndex(m2[j]), "member added")
+			j++
+		default:
+			i++
+			j++
+		}
+	}
+	return nil
+}
+
+// compareSets compares the sets s1 and s2 by their sorted members, lexicographically.
+func (t *traversal) compareSets(s1, s2 reflect.Value, depth int) (int, error) {
+	m1, err := t.setMembers(s1)
+	if err != nil {
+		return 0, err
+	}
+	m2, err := t.setMembers(s2)
+	if err != nil {
+		return 0, err
+	}
+	for i := 0; i < len(m1) && i < len(m2); i++ {
+		res, err := t.deepValueCompare(m1[i], m2[i], depth+1)
+		if err != nil {
+			return 0, prependStep(err, indexStep(i))
+		}
+		if res != 0 {
+			t.stepIndex(i)
+			return res, nil
+		}
+	}
+	return compareInt64(int64(len(m1)), int64(len(m2))), nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sets", func() {
+	cmp := make(Comparisons).NewComparer(CompareAsSets())
+
+	DescribeTable("CompareAsSets",
+		func(s1, s2 interface{}, expect int) {
+			Expect(cmp.Compare(s1, s2)).To(Equal(expect))
+			Expect(cmp.Compare(s2, s1)).To(Equal(-expect))
+		},
+		Entry("equal", map[string]struct{}{"a": {}, "b": {}}, map[string]struct{}{"b": {}, "a": {}}, 0),
+		Entry("nil and empty", map[string]struct{}(nil), map[string]struct{}{}, 0),
+		Entry("prefix is less", map[string]struct{}{"a": {}}, map[string]struct{}{"a": {}, "b": {}}, -1),
+		Entry("by sorted members", map[string]struct{}{"a": {}, "c": {}}, map[string]struct{}{"b": {}}, -1),
+		Entry("false is no member", map[string]bool{"a": true, "b": false}, map[string]bool{"a": true}, 0),
+		Entry("bool members", map[string]bool{"a": true}, map[string]bool{"a": false, "b": true}, -1),
+		Entry("nested sets", []map[int]bool{{1: true, 2: true}}, []map[int]bool{{3: true}}, -1),
+	)
+
+	It("should not affect other maps", func() {
+		Expect(cmp.Compare(map[string]int{"a": 1}, map[string]int{"a": 2})).To(Equal(-1))
+	})
+
+	It("should diff sets by added and removed members", func() {
+		type Pod struct {
+			Labels map[string]struct{}
+			Ready  map[int]bool
+		}
+		p1 := Pod{Labels: map[string]struct{}{"a": {}, "b": {}, "d": {}}, Ready: map[int]bool{1: true, 2: true}}
+		p2 := Pod{Labels: map[string]struct{}{"b": {}, "c": {}, "d": {}}, Ready: map[int]bool{1: true, 2: false}}
+		Expect(make(Comparisons).Diff(p1, p2, CompareAsSets())).To(Equal([]Difference{
+			{Path: `.Labels["a"]`, A: struct{}{}, Result: 1, Comparator: "member removed"},
+			{Path: `.Labels["c"]`, B: struct{}{}, Result: -1, Comparator: "member added"},
+			{Path: ".Ready[2]", A: true, Result: 1, Comparator: "member removed"},
+		}))
+	})
+
+	It("should diff empty sets by their members", func() {
+		Expect(make(Comparisons).Diff(map[string]bool(nil), map[string]bool{"a": true, "b": false}, CompareAsSets())).To(Equal([]Difference{
+			{Path: `["a"]`, B: true, Result: -1, Comparator: "member added"},
+		}))
+	})
+
+	It("should count sorting members", func() {
+		cmp := make(Comparisons).NewComparer(CompareAsSets())
+		cmp.Compare(map[int]bool{1: true}, map[int]bool{2: true})
+		Expect(cmp.Stats().MapKeySorts).To(Equal(2))
+	})
+})
@@ -0,0 +1,67 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// sortAdapter implements sort.Interface for a slice using a traversal.
+type sortAdapter struct {
+	traversal *traversal
+	slice     reflect.Value
+	swap      func(i, j int)
+}
+
+// Interface returns a sort.Interface for the given slice ordering its elements via DeepCompare.
+// This allows using it with any API accepting a sort.Interface:
+//
+//	sort.Stable(sort.Reverse(c.Interface(items)))
+//
+// Interface panics if slice is not a slice. Less panics if elements cannot be compared.
+func (c Comparisons) Interface(slice interface{}) sort.Interface {
+	v := reflect.ValueOf(slice)
+	if v.Kind() != reflect.Slice {
+		panic(fmt.Sprintf("expected slice, got: %T", slice))
+	}
+	return &sortAdapter{
+		traversal: c.newTraversal(),
+		slice:     v,
+		swap:      reflect.Swapper(slice),
+	}
+}
+
+// Len implements sort.Interface.
+func (a *sortAdapter) Len() int {
+	return a.slice.Len()
+}
+
+// Less implements sort.Interface.
+func (a *sortAdapter) Less(i, j int) bool {
+	// Elements are moved in between comparisons, so don't reuse visited comparisons.
+	defer a.traversal.reset()
+	res, err := a.traversal.deepValueCompare(a.slice.Index(i), a.slice.Index(j), 0)
+	if err != nil {
+		panic(err)
+	}
+	return checkTotal(res, a.slice.Type().Elem()) < 0
+}
+
+// Swap implements sort.Interface.
+func (a *sortAdapter) Swap(i, j int) {
+	a.swap(i, j)
+}
@@ -0,0 +1,43 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"sort"
+	"strings"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Interface", func() {
+	It("should sort slices", func() {
+		items := [][]int{{2}, {1, 2}, {1, 1}}
+		sort.Sort(make(Comparisons).Interface(items))
+		Expect(items).To(Equal([][]int{{2}, {1, 1}, {1, 2}}))
+	})
+
+	It("should be usable with sort.Reverse and sort.Stable", func() {
+		c := NewComparisonsOrDie(func(s1, s2 Spec) int { return strings.Compare(s1.Image, s2.Image) })
+		specs := []Spec{{Image: "a", Replicas: intPtr(1)}, {Image: "b"}, {Image: "a", Replicas: intPtr(2)}}
+		sort.Stable(sort.Reverse(c.Interface(specs)))
+		Expect(specs).To(Equal([]Spec{{Image: "b"}, {Image: "a", Replicas: intPtr(1)}, {Image: "a", Replicas: intPtr(2)}}))
+	})
+
+	It("should panic on non-slices", func() {
+		Expect(func() { make(Comparisons).Interface(1) }).To(Panic())
+	})
+})
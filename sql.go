@@ -0,0 +1,110 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// SQLOrderBy translates the given columns to an equivalent SQL ORDER BY clause, e.g.
+// 'ORDER BY name ASC, replicas DESC NULLS LAST', so in-memory ordering via SortTable
+// and database ordering stay consistent. Empty columns result in an empty clause.
+//
+// sample is a value of the row type and mapping maps the path of each column to its
+// SQL expression. As nil values sort first in SortTable, nullable columns (reached via
+// pointers or being pointers themselves) get explicit NULLS FIRST / NULLS LAST modifiers.
+//
+// SQLOrderBy errors if a column has no mapping, or if its type is composite (e.g. a struct
+// or slice) without a comparison function in c, as databases cannot order these the same way.
+// Columns with comparison functions are assumed to be stored in a way ordering alike, e.g.
+// time.Time as timestamp. Note that strings are ordered by byte value in Go, so the
+// database column should use a binary collation (e.g. COLLATE "C").
+func (c Comparisons) SQLOrderBy(sample interface{}, columns []SortColumn, mapping map[string]string) (string, error) {
+	t := reflect.TypeOf(sample)
+	if t == nil {
+		return "", fmt.Errorf("expected sample, got: nil")
+	}
+	resolved, err := resolveColumns(t, columns)
+	if err != nil {
+		return "", err
+	}
+
+	terms := make([]string, len(columns))
+	for i, column := range columns {
+		expr, ok := mapping[column.Path]
+		if !ok {
+			return "", fmt.Errorf("no SQL expression for column %q", column.Path)
+		}
+		typ, nullable := resolved[i].path.typeOf(t)
+		if _, ok := c[typ]; !ok && !isSQLOrderable(typ) {
+			return "", fmt.Errorf("column %q of type %v cannot be ordered by SQL", column.Path, typ)
+		}
+
+		var sb strings.Builder
+		sb.WriteString(expr)
+		if column.Descending {
+			sb.WriteString(" DESC")
+		} else {
+			sb.WriteString(" ASC")
+		}
+		if nullable {
+			// nil is less than any other value.
+			if column.Descending {
+				sb.WriteString(" NULLS LAST")
+			} else {
+				sb.WriteString(" NULLS FIRST")
+			}
+		}
+		terms[i] = sb.String()
+	}
+	if len(terms) == 0 {
+		return "", nil
+	}
+	return "ORDER BY " + strings.Join(terms, ", "), nil
+}
+
+// typeOf returns the type of the field denoted by p of values of type t, with pointers
+// dereferenced, and whether the field may be nil or not reachable because of nil pointers.
+func (p fieldPath) typeOf(t reflect.Type) (reflect.Type, bool) {
+	var nullable bool
+	for _, index := range p {
+		for _, i := range index {
+			for t.Kind() == reflect.Ptr {
+				nullable = true
+				t = t.Elem()
+			}
+			t = t.Field(i).Type
+		}
+	}
+	for t.Kind() == reflect.Ptr {
+		nullable = true
+		t = t.Elem()
+	}
+	return t, nullable
+}
+
+// isSQLOrderable reports whether values of type t are ordered by databases like by DeepCompare.
+func isSQLOrderable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"time"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type Job struct {
+	Name     string
+	Priority *int
+	Created  time.Time
+	Spec     *Spec
+	Tags     []string
+}
+
+var _ = Describe("SQLOrderBy", func() {
+	mapping := map[string]string{
+		"Name":          "name",
+		"Priority":      "priority",
+		"Created":       "created_at",
+		"Spec.Image":    "spec_image",
+		"Spec.Replicas": "spec_replicas",
+		"Tags":          "tags",
+	}
+
+	It("should translate columns", func() {
+		c := NewComparisonsOrDie(CompareTime)
+		Expect(c.SQLOrderBy(Job{}, []SortColumn{
+			{Path: "Name"},
+			{Path: "Created", Descending: true},
+		}, mapping)).To(Equal("ORDER BY name ASC, created_at DESC"))
+	})
+
+	It("should place NULLs like SortTable", func() {
+		Expect(make(Comparisons).SQLOrderBy(&Job{}, []SortColumn{
+			{Path: "Priority"},
+			{Path: "Spec.Image", Descending: true},
+			{Path: "Spec.Replicas"},
+		}, mapping)).To(Equal("ORDER BY priority ASC NULLS FIRST, spec_image DESC NULLS LAST, spec_replicas ASC NULLS FIRST"))
+	})
+
+	It("should return an empty clause for no columns", func() {
+		Expect(make(Comparisons).SQLOrderBy(Job{}, nil, mapping)).To(BeEmpty())
+	})
+
+	It("should error on columns that cannot be ordered by SQL", func() {
+		_, err := make(Comparisons).SQLOrderBy(Job{}, []SortColumn{{Path: "Created"}}, mapping)
+		Expect(err).To(MatchError(ContainSubstring(`column "Created" of type time.Time cannot be ordered by SQL`)))
+		_, err = make(Comparisons).SQLOrderBy(Job{}, []SortColumn{{Path: "Tags"}}, mapping)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should error on unmapped or unknown columns", func() {
+		_, err := make(Comparisons).SQLOrderBy(Job{}, []SortColumn{{Path: "Name"}}, nil)
+		Expect(err).To(MatchError(`no SQL expression for column "Name"`))
+		_, err = make(Comparisons).SQLOrderBy(Job{}, []SortColumn{{Path: "Unknown"}}, mapping)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,27 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+// StandardFuncs returns the standard bundle of comparison functions for commonly
+// used types. Use it to create Comparisons that handle these types out of the box:
+//
+//	c := NewComparisonsOrDie(StandardFuncs()...)
+func StandardFuncs() []interface{} {
+	return []interface{}{
+		CompareHardwareAddr,
+		CompareUUID,
+		CompareTime,
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"time"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Standard", func() {
+	Describe("StandardFuncs", func() {
+		It("should only contain valid comparison functions", func() {
+			_, err := NewComparisons(StandardFuncs()...)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should compare times by instant", func() {
+			c := NewComparisonsOrDie(StandardFuncs()...)
+			t := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+			Expect(c.DeepCompare(t, t.In(time.FixedZone("other", 3600)))).To(Equal(0))
+			Expect(c.DeepCompare(t, t.Add(time.Second))).To(Equal(-1))
+		})
+
+		It("should compare UUIDs", func() {
+			c := NewComparisonsOrDie(StandardFuncs()...)
+			Expect(c.DeepCompare([16]byte{1}, [16]byte{2})).To(Equal(-1))
+		})
+	})
+})
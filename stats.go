@@ -0,0 +1,55 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+// Stats are statistics about the work done by comparisons. They help to quantify the
+// cost of traversals and to decide where registering comparison functions pays off.
+type Stats struct {
+	// Nodes is the number of value pairs visited.
+	Nodes int
+	// OverrideHits is the number of calls to comparison functions.
+	OverrideHits int
+	// MapKeySorts is the number of times the keys of a map were sorted.
+	// Comparing maps by their entries does not require sorting keys.
+	MapKeySorts int
+	// MaxDepth is the maximum nesting depth reached.
+	MaxDepth int
+}
+
+// enter records visiting a value pair at the given depth.
+func (s *Stats) enter(depth int) {
+	s.Nodes++
+	if depth > s.MaxDepth {
+		s.MaxDepth = depth
+	}
+}
+
+// CompareWithStats compares a1 and a2 like DeepCompare and returns statistics about the comparison.
+func (c Comparisons) CompareWithStats(a1, a2 interface{}) (int, Stats) {
+	t := c.newTraversal()
+	res := t.compare(a1, a2)
+	return res, t.stats
+}
+
+// Stats returns the statistics accumulated by all comparisons of c since its creation
+// or the last call to ResetStats.
+func (c *Comparer) Stats() Stats {
+	return c.traversal.stats
+}
+
+// ResetStats resets the statistics of c.
+func (c *Comparer) ResetStats() {
+	c.traversal.stats = Stats{}
+}
@@ -0,0 +1,50 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Stats", func() {
+	Describe("CompareWithStats", func() {
+		It("should count nodes and depth", func() {
+			res, stats := make(Comparisons).CompareWithStats([][]int{{1, 2}}, [][]int{{1, 3}})
+			Expect(res).To(Equal(-1))
+			Expect(stats).To(Equal(Stats{Nodes: 4, MaxDepth: 2}))
+		})
+
+		It("should count override hits", func() {
+			c := NewComparisonsOrDie(func(i1, i2 int) int { return i1 - i2 })
+			_, stats := c.CompareWithStats(Struct{A: 1}, Struct{A: 1})
+			Expect(stats.OverrideHits).To(Equal(1))
+		})
+
+	})
+
+	Describe("Comparer", func() {
+		It("should accumulate stats until reset", func() {
+			c := make(Comparisons).NewComparer()
+			c.Compare([]int{1}, []int{1})
+			c.Compare([]int{1}, []int{2})
+			Expect(c.Stats()).To(Equal(Stats{Nodes: 4, MaxDepth: 1}))
+
+			c.ResetStats()
+			Expect(c.Stats()).To(Equal(Stats{}))
+		})
+	})
+})
@@ -0,0 +1,282 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// DeepCompareAt compares the values of a1 and a2 at the given path like TryDeepCompare,
+// without comparing anything else, e.g. '.Spec' or '.Spec.Containers[0].Env["PATH"]'.
+// Paths have the syntax of the paths reported by Diff: '.Name' selects the struct field Name,
+// '[N]' the element with index N of an array or slice and '[K]' the entry with key K of a map,
+// where K is a quoted string or an integer. Pointers and interfaces are dereferenced along
+// the path. The empty path selects the values themselves. A struct field selected last is
+// compared like when comparing the struct declaring it, e.g. ignored fields are equal.
+//
+// A value is missing at the path if a nil pointer or interface, an index out of range or a
+// key missing from a map is encountered. A missing value is less than a present one.
+// DeepCompareAt returns an error if the path is malformed or does not match the types of the
+// values, or if the values at the path cannot be compared.
+func (c Comparisons) DeepCompareAt(path string, a1, a2 interface{}) (res int, err error) {
+	// Comparison functions may still panic.
+	defer func() {
+		if x := recover(); x != nil {
+			err = recoveredError(x)
+		}
+	}()
+	return c.newTraversal().compareAt(path, a1, a2)
+}
+
+// CompareAt compares the values of a1 and a2 at the given path like TryCompare, see
+// Comparisons.DeepCompareAt.
+func (c *Comparer) CompareAt(path string, a1, a2 interface{}) (res int, err error) {
+	defer c.traversal.reset()
+	// Comparison functions may still panic.
+	defer func() {
+		if x := recover(); x != nil {
+			err = recoveredError(x)
+		}
+	}()
+	return c.traversal.compareAt(path, a1, a2)
+}
+
+// compareAt compares the values of a1 and a2 at the given path.
+func (t *traversal) compareAt(path string, a1, a2 interface{}) (res int, err error) {
+	sels, err := parseSelectorPath(path)
+	if err != nil {
+		return 0, err
+	}
+	v1, v2 := reflect.ValueOf(a1), reflect.ValueOf(a2)
+	if v1.IsValid() && v2.IsValid() && v1.Type() != v2.Type() {
+		return 0, &typeMismatchError{t1: v1.Type(), t2: v2.Type()}
+	}
+	v1, f1, err := selectPath(v1, sels)
+	if err != nil {
+		return 0, fmt.Errorf("path %q: %w", path, err)
+	}
+	v2, f2, err := selectPath(v2, sels)
+	if err != nil {
+		return 0, fmt.Errorf("path %q: %w", path, err)
+	}
+	// Compare a field selected last like when comparing the struct holding it.
+	if f := f1; f.typ != nil || f2.typ != nil {
+		if f.typ == nil {
+			f = f2
+		}
+		sf, ok, err := t.structField(f.typ, f.name)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			return 0, nil
+		}
+		if sf.compare.IsValid() && v1.IsValid() && v2.IsValid() {
+			if ok, err := t.canCall(v1, v2); !ok {
+				return 0, err
+			}
+			return t.callCompare(sf.compare, v1, v2, 0)
+		}
+		t.field = sf
+	}
+	if res, err = t.deepValueCompare(v1, v2, 0); err != nil {
+		return 0, prependStep(err, path)
+	}
+	if v1.IsValid() {
+		return totalOrder(res, v1.Type())
+	}
+	return res, nil
+}
+
+// ErrNotFound indicates that Lookup found no value at a path.
+var ErrNotFound = errors.New("no value at path")
+
+// Lookup returns the value of v at the given path, e.g. '.Spec.Containers[0].Name'. Paths are
+// navigated like by DeepCompareAt. If a nil pointer or interface, an index out of range or a
+// key missing from a map is encountered along the path, Lookup returns an error matching
+// ErrNotFound. It returns other errors if the path is malformed or does not match the type of v.
+func (c Comparisons) Lookup(v interface{}, path string) (interface{}, error) {
+	sels, err := parseSelectorPath(path)
+	if err != nil {
+		return nil, err
+	}
+	res, _, err := selectPath(reflect.ValueOf(v), sels)
+	if err != nil {
+		return nil, fmt.Errorf("path %q: %w", path, err)
+	}
+	if !res.IsValid() {
+		return nil, fmt.Errorf("path %q: %w", path, ErrNotFound)
+	}
+	return res.Interface(), nil
+}
+
+// pathSelector is a step of a path selecting a nested value, see DeepCompareAt.
+type pathSelector struct {
+	// field is the name of a struct field, if set.
+	field string
+	// arg is the index of an element or the key of a map entry otherwise, e.g. '0' or '"app"'.
+	arg string
+}
+
+// parseSelectorPath parses the path of a nested value, see DeepCompareAt.
+func parseSelectorPath(path string) ([]pathSelector, error) {
+	var sels []pathSelector
+	for rest := path; rest != ""; {
+		switch rest[0] {
+		case '.':
+			end := strings.IndexAny(rest[1:], ".[") + 1
+			if end == 0 {
+				end = len(rest)
+			}
+			if end == 1 {
+				return nil, fmt.Errorf("path %q: empty field name", path)
+			}
+			sels = append(sels, pathSelector{field: rest[1:end]})
+			rest = rest[end:]
+		case '[':
+			end := 1
+			if len(rest) > 1 && rest[1] == '"' {
+				// Skip the quoted key, which may contain ']'.
+				quoted, err := strconv.QuotedPrefix(rest[1:])
+				if err != nil {
+					return nil, fmt.Errorf("path %q: invalid key at %q", path, rest)
+				}
+				end += len(quoted)
+			}
+			n := strings.IndexByte(rest[end:], ']')
+			if n < 0 {
+				return nil, fmt.Errorf("path %q: unterminated %q", path, rest)
+			}
+			end += n
+			sels = append(sels, pathSelector{arg: rest[1:end]})
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("path %q: expected '.' or '[' at %q", path, rest)
+		}
+	}
+	return sels, nil
+}
+
+// selectedField is the struct field selected last by a path.
+type selectedField struct {
+	// typ is the struct type declaring the field, if any field was selected last.
+	typ  reflect.Type
+	name string
+}
+
+// selectPath returns the value selected by sels from v, or the zero reflect.Value if missing,
+// along with the struct field selected last, if any.
+func selectPath(v reflect.Value, sels []pathSelector) (reflect.Value, selectedField, error) {
+	var field selectedField
+	for i, sel := range sels {
+		for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+			v = v.Elem()
+		}
+		if !v.IsValid() {
+			return v, selectedField{}, nil
+		}
+		field = selectedField{}
+		if sel.field != "" {
+			if v.Kind() != reflect.Struct {
+				return reflect.Value{}, field, fmt.Errorf("cannot select field %q of non-struct type %v", sel.field, v.Type())
+			}
+			f, ok := v.Type().FieldByName(sel.field)
+			if !ok {
+				return reflect.Value{}, field, fmt.Errorf("type %v has no field %q", v.Type(), sel.field)
+			}
+			if f.PkgPath != "" {
+				return reflect.Value{}, field, fmt.Errorf("field %q of type %v is unexported", sel.field, v.Type())
+			}
+			if i == len(sels)-1 {
+				field = selectedField{typ: v.Type(), name: f.Name}
+				for _, j := range f.Index[:len(f.Index)-1] {
+					// The field is promoted from an embedded struct (pointer).
+					if field.typ = field.typ.Field(j).Type; field.typ.Kind() == reflect.Ptr {
+						field.typ = field.typ.Elem()
+					}
+				}
+			}
+			v = fieldPath{f.Index}.get(v)
+			continue
+		}
+		switch v.Kind() {
+		case reflect.Array, reflect.Slice:
+			index, err := strconv.Atoi(sel.arg)
+			if err != nil || index < 0 {
+				return reflect.Value{}, field, fmt.Errorf("invalid index %q of %v", sel.arg, v.Type())
+			}
+			if index >= v.Len() {
+				return reflect.Value{}, field, nil
+			}
+			v = v.Index(index)
+		case reflect.Map:
+			k, err := parseMapKey(sel.arg, v.Type().Key())
+			if err != nil {
+				return reflect.Value{}, field, err
+			}
+			v = v.MapIndex(k)
+		default:
+			return reflect.Value{}, field, fmt.Errorf("cannot select [%s] of type %v", sel.arg, v.Type())
+		}
+	}
+	return v, field, nil
+}
+
+// parseMapKey parses the map key arg of type typ, a quoted string or an integer.
+func parseMapKey(arg string, typ reflect.Type) (reflect.Value, error) {
+	k := reflect.New(typ).Elem()
+	switch typ.Kind() {
+	case reflect.String:
+		s, err := strconv.Unquote(arg)
+		if err != nil {
+			return k, fmt.Errorf("invalid key %s of %v", arg, typ)
+		}
+		k.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(arg, 10, typ.Bits())
+		if err != nil {
+			return k, fmt.Errorf("invalid key %s of %v", arg, typ)
+		}
+		k.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := strconv.ParseUint(arg, 10, typ.Bits())
+		if err != nil {
+			return k, fmt.Errorf("invalid key %s of %v", arg, typ)
+		}
+		k.SetUint(u)
+	default:
+		return k, fmt.Errorf("cannot select keys of type %v", typ)
+	}
+	return k, nil
+}
+
+// structField returns the field to compare with the given name of the struct type typ.
+// It reports false if the field is not compared, e.g. because it is ignored.
+func (t *traversal) structField(typ reflect.Type, name string) (structField, bool, error) {
+	fields, err := t.structFields(typ)
+	if err != nil {
+		return structField{}, false, err
+	}
+	for _, f := range fields {
+		if f.name == name {
+			return f, true, nil
+		}
+	}
+	return structField{}, false, nil
+}
@@ -0,0 +1,140 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"strings"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type Versioned struct {
+	*Pod
+	Ports   map[int]string
+	Version string   `compare:"reverse"`
+	Ignored []func() `compare:"ignore"`
+}
+
+var _ = Describe("DeepCompareAt", func() {
+	c := make(Comparisons)
+	p1 := newPod("a", "b")
+	p1.Labels = map[string]string{"app": "web", "a]b": "x"}
+	p2 := newPod("a", "c", "d")
+	p2.Labels = map[string]string{"app": "api", "a]b": "x"}
+
+	compareAt := func(c Comparisons, path string, a1, a2 interface{}) int {
+		res, err := c.DeepCompareAt(path, a1, a2)
+		Expect(err).NotTo(HaveOccurred())
+		return res
+	}
+
+	It("should compare the values at the path only", func() {
+		Expect(compareAt(c, ".Spec.Containers[0]", p1, p2)).To(Equal(0))
+		Expect(compareAt(c, ".Spec.Containers[1].Env[0].Value", p1, p2)).To(Equal(-1))
+		Expect(compareAt(c, `.Labels["app"]`, p1, p2)).To(Equal(1))
+		Expect(compareAt(c, `.Labels["a]b"]`, &p1, &p2)).To(Equal(0))
+		Expect(compareAt(c, "", p1, p1)).To(Equal(0))
+	})
+
+	It("should order missing values first", func() {
+		Expect(compareAt(c, ".Spec.Containers[2]", p1, p2)).To(Equal(-1))
+		Expect(compareAt(c, `.Labels["env"]`, p1, p2)).To(Equal(0))
+		Expect(compareAt(c, ".Spec", Versioned{}, Versioned{Pod: &p1})).To(Equal(-1))
+		Expect(compareAt(c, ".Spec", (*Pod)(nil), &p1)).To(Equal(-1))
+	})
+
+	It("should select entries of maps with integer keys", func() {
+		Expect(compareAt(c, ".Ports[80]", Versioned{Ports: map[int]string{80: "a"}}, Versioned{Ports: map[int]string{80: "b"}})).To(Equal(-1))
+	})
+
+	It("should compare selected fields like their structs do", func() {
+		v1 := Versioned{Version: "a", Ignored: []func(){func() {}}}
+		v2 := Versioned{Version: "b", Ignored: []func(){func() {}}}
+		cmp := c.NewComparer(TagComparison("reverse", func(s1, s2 string) int { return strings.Compare(s2, s1) }))
+		Expect(cmp.Compare(v1, v2)).To(Equal(1))
+		res, err := cmp.CompareAt(".Version", v1, v2)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res).To(Equal(1))
+		res, err = cmp.CompareAt(".Ignored", v1, v2)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res).To(Equal(0))
+	})
+
+	It("should fail for malformed paths or paths not matching the types", func() {
+		for _, path := range []string{"Spec", ".", ".Spec[", `.Labels["a]`, ".Spec.Nope", ".Spec.Containers[x]", ".Labels[1]", ".Spec[0]"} {
+			_, err := c.DeepCompareAt(path, p1, p2)
+			Expect(err).To(HaveOccurred(), path)
+		}
+		_, err := c.DeepCompareAt("", 1, "a")
+		Expect(err).To(MatchError(ErrTypeMismatch))
+	})
+
+	It("should return errors with the path", func() {
+		_, err := c.DeepCompareAt(".Ignored", struct{ Ignored []func() }{[]func(){nil}}, struct{ Ignored []func() }{[]func(){func() {}}})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = c.DeepCompareAt(".F", struct{ F []func() }{[]func(){func() {}}}, struct{ F []func() }{[]func(){func() {}}})
+		Expect(err).To(MatchError(ContainSubstring(".F[0]")))
+	})
+})
+
+var _ = Describe("Lookup", func() {
+	c := make(Comparisons)
+	pod := newPod("a", "b")
+	pod.Labels = map[string]string{"app": "web"}
+
+	It("should return the value at the path", func() {
+		for path, expected := range map[string]interface{}{
+			".Spec.Containers[1].Env[0].Value": "b",
+			".Spec.Containers[0].Name":         "c0",
+			`.Labels["app"]`:                   "web",
+			".Spec.Containers[0]":              pod.Spec.Containers[0],
+			"":                                 pod,
+		} {
+			v, err := c.Lookup(pod, path)
+			Expect(err).NotTo(HaveOccurred(), path)
+			Expect(v).To(Equal(expected), path)
+		}
+	})
+
+	It("should dereference pointers and promote fields", func() {
+		v, err := c.Lookup(&Versioned{Pod: &pod}, `.Labels["app"]`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v).To(Equal("web"))
+	})
+
+	It("should return nil pointers at the path", func() {
+		v, err := c.Lookup(Versioned{}, ".Pod")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v).To(Equal((*Pod)(nil)))
+	})
+
+	It("should report missing values", func() {
+		for _, path := range []string{".Spec.Containers[2]", `.Labels["env"]`} {
+			_, err := c.Lookup(pod, path)
+			Expect(err).To(MatchError(ErrNotFound), path)
+		}
+		_, err := c.Lookup(Versioned{}, ".Spec")
+		Expect(err).To(MatchError(ErrNotFound))
+	})
+
+	It("should fail for malformed paths or paths not matching the type", func() {
+		_, err := c.Lookup(pod, ".Spec.Nope")
+		Expect(err).To(MatchError(ContainSubstring("has no field")))
+		_, err = c.Lookup(pod, "Spec")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,105 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// SortColumn specifies a column to sort a table by.
+type SortColumn struct {
+	// Path is the dot-separated path of field names of the column, e.g. 'Spec.Replicas'.
+	Path string
+	// Descending reverses the order of the column.
+	Descending bool
+}
+
+// resolvedColumn is a SortColumn whose path has been resolved against a row type.
+type resolvedColumn struct {
+	path       fieldPath
+	descending bool
+}
+
+func resolveColumns(t reflect.Type, columns []SortColumn) ([]resolvedColumn, error) {
+	res := make([]resolvedColumn, len(columns))
+	for i, column := range columns {
+		path, err := resolveFieldPath(t, column.Path)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = resolvedColumn{path, column.Descending}
+	}
+	return res, nil
+}
+
+func (t *traversal) compareColumns(v1, v2 reflect.Value, columns []resolvedColumn) int {
+	// Values may be moved in between comparisons (e.g. when sorting), so don't reuse visited comparisons.
+	defer t.reset()
+	for _, column := range columns {
+		res, err := t.deepValueCompare(column.path.get(v1), column.path.get(v2), 0)
+		if err != nil {
+			panic(err)
+		}
+		res = checkTotal(res, v1.Type())
+		if column.descending {
+			res = -res
+		}
+		if res != 0 {
+			return res
+		}
+	}
+	return 0
+}
+
+// CompareColumns compares the given columns of a1 and a2 in order, using DeepCompare
+// for each column. If a nil pointer is encountered on the path of a column, the
+// column value is less than any non-nil value.
+//
+// CompareColumns panics if a1 and a2 are of different types or if any column does
+// not denote an exported field.
+func (c Comparisons) CompareColumns(a1, a2 interface{}, columns []SortColumn) int {
+	v1 := reflect.ValueOf(a1)
+	v2 := reflect.ValueOf(a2)
+	if v1.Type() != v2.Type() {
+		panic(fmt.Sprintf("cannot compare different types: %T - %T", a1, a2))
+	}
+	resolved, err := resolveColumns(v1.Type(), columns)
+	if err != nil {
+		panic(err)
+	}
+	return c.newTraversal().compareColumns(v1, v2, resolved)
+}
+
+// SortTable stably sorts the given rows by the given columns, like an 'ORDER BY'
+// clause would. rows has to be a slice of structs or of pointers to structs.
+//
+// SortTable panics if rows is not a slice or if any column does not denote an exported
+// field of the row type.
+func (c Comparisons) SortTable(rows interface{}, columns []SortColumn) {
+	rv := reflect.ValueOf(rows)
+	if rv.Kind() != reflect.Slice {
+		panic(fmt.Sprintf("expected slice, got: %T", rows))
+	}
+	resolved, err := resolveColumns(rv.Type().Elem(), columns)
+	if err != nil {
+		panic(err)
+	}
+	t := c.newTraversal()
+	sort.SliceStable(rows, func(i, j int) bool {
+		return t.compareColumns(rv.Index(i), rv.Index(j), resolved) < 0
+	})
+}
@@ -0,0 +1,73 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Table", func() {
+	var c Comparisons
+	BeforeEach(func() {
+		c = make(Comparisons)
+	})
+
+	Describe("CompareColumns", func() {
+		It("should compare the columns in order", func() {
+			o1 := Object{Name: "a", Spec: &Spec{Replicas: intPtr(1)}}
+			o2 := Object{Name: "a", Spec: &Spec{Replicas: intPtr(2)}}
+			Expect(c.CompareColumns(o1, o2, []SortColumn{{Path: "Name"}})).To(Equal(0))
+			Expect(c.CompareColumns(o1, o2, []SortColumn{{Path: "Name"}, {Path: "Spec.Replicas"}})).To(Equal(-1))
+			Expect(c.CompareColumns(o1, o2, []SortColumn{{Path: "Spec.Replicas", Descending: true}})).To(Equal(1))
+		})
+
+		It("should panic on different types", func() {
+			Expect(func() { c.CompareColumns(Object{}, &Object{}, nil) }).To(Panic())
+		})
+
+		It("should panic on unknown columns", func() {
+			Expect(func() { c.CompareColumns(Object{}, Object{}, []SortColumn{{Path: "Unknown"}}) }).To(Panic())
+		})
+	})
+
+	Describe("SortTable", func() {
+		It("should stably sort the rows by the given columns", func() {
+			rows := []*Object{
+				{Name: "b", Spec: &Spec{Image: "x", Replicas: intPtr(1)}},
+				{Name: "a", Spec: &Spec{Image: "y", Replicas: intPtr(2)}},
+				{Name: "c", Spec: &Spec{Image: "x", Replicas: intPtr(2)}},
+				{Name: "d"},
+				{Name: "e", Spec: &Spec{Image: "y", Replicas: intPtr(2)}},
+			}
+			c.SortTable(rows, []SortColumn{{Path: "Spec.Replicas", Descending: true}, {Path: "Spec.Image"}})
+
+			var names []string
+			for _, row := range rows {
+				names = append(names, row.Name)
+			}
+			Expect(names).To(Equal([]string{"c", "a", "e", "b", "d"}))
+		})
+
+		It("should panic if rows is no slice", func() {
+			Expect(func() { c.SortTable(Object{}, nil) }).To(Panic())
+		})
+
+		It("should panic on unknown columns", func() {
+			Expect(func() { c.SortTable([]Object{}, []SortColumn{{Path: "Unknown"}}) }).To(Panic())
+		})
+	})
+})
@@ -0,0 +1,195 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// tagKey is the key of struct tags configuring how fields are compared,
+// e.g. `compare:"weight=10"`. Directives are separated by commas.
+//
+// The following directives are supported:
+//
+//	weight=N	compare fields with higher weights first (default 0), see FieldWeights.
+//	ignore		do not compare the field.
+//	redact		mask the values of the field in reports, see Redact.
+//	since=N		the field was added in version N of its struct type, see AsOfVersion.
+//	NAME		compare the field using the comparison function registered as NAME, see TagComparison.
+//
+// Unless registered otherwise, the following names are predefined for string fields:
+//
+//	timestamp	compare timestamps by instant, see CompareTimestamps.
+//	language	compare BCP 47 language tags, see CompareLanguageTags.
+//	country		compare ISO 3166 country codes, see CompareCountryCodes.
+//
+// Use Comparisons.ScanTypes to validate tags upfront.
+const tagKey = "compare"
+
+// predefinedTagComparisons are the comparison functions fields can be tagged with without
+// registering them via TagComparison, by name.
+var predefinedTagComparisons = map[string]reflect.Value{
+	"timestamp": reflect.ValueOf(CompareTimestamps),
+	"language":  reflect.ValueOf(CompareLanguageTags),
+	"country":   reflect.ValueOf(CompareCountryCodes),
+}
+
+// fieldTag are the parsed directives of the compare tag of a struct field.
+type fieldTag struct {
+	weight int
+	ignore bool
+	redact bool
+	// since is the version of the struct type the field was added in, if positive.
+	since int
+	// named is the name of the comparison function to compare the field with, if any.
+	named string
+}
+
+// parseFieldTag parses the compare tag of the struct field f.
+func parseFieldTag(f reflect.StructField) (fieldTag, error) {
+	var tag fieldTag
+	value, ok := f.Tag.Lookup(tagKey)
+	if !ok || value == "" {
+		return tag, nil
+	}
+	for _, directive := range strings.Split(value, ",") {
+		name, arg := directive, ""
+		if i := strings.IndexByte(directive, '='); i >= 0 {
+			name, arg = directive[:i], directive[i+1:]
+		}
+		switch name {
+		case "weight":
+			weight, err := strconv.Atoi(arg)
+			if err != nil {
+				return tag, fmt.Errorf("field %s: invalid weight %q", f.Name, arg)
+			}
+			tag.weight = weight
+		case "ignore":
+			tag.ignore = true
+		case "redact":
+			tag.redact = true
+		case "since":
+			since, err := strconv.Atoi(arg)
+			if err != nil || since < 1 {
+				return tag, fmt.Errorf("field %s: invalid version %q", f.Name, arg)
+			}
+			tag.since = since
+		default:
+			if directive != name || tag.named != "" {
+				return tag, fmt.Errorf("field %s: unknown directive %q", f.Name, name)
+			}
+			tag.named = name
+		}
+	}
+	return tag, nil
+}
+
+// FieldTags sets the compare tags of the fields of the struct type of sample by field name,
+// e.g. {"Version": "semver,weight=1", "Cache": "ignore"}, replacing the tags the fields are
+// declared with, if any. This allows configuring how to compare fields of types whose
+// declarations cannot be changed, e.g. from a Config.
+//
+// FieldTags panics if sample is not a struct, if any name does not denote a field declared by
+// it or if any tag is malformed.
+func FieldTags(sample interface{}, tags map[string]string) Option {
+	t := reflect.TypeOf(sample)
+	if t == nil || t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("expected struct, got: %T", sample))
+	}
+	for name, value := range tags {
+		f, ok := t.FieldByName(name)
+		if !ok || len(f.Index) > 1 {
+			panic(fmt.Sprintf("type %v has no field %s", t, name))
+		}
+		if _, err := parseFieldTag(withTag(f, value)); err != nil {
+			panic(fmt.Sprintf("invalid %s tag of %v: %v", tagKey, t, err))
+		}
+	}
+	return func(o *options) {
+		if o.fieldTags == nil {
+			o.fieldTags = make(map[reflect.Type]map[string]string)
+		}
+		if o.fieldTags[t] == nil {
+			o.fieldTags[t] = make(map[string]string)
+		}
+		for name, value := range tags {
+			o.fieldTags[t][name] = value
+		}
+	}
+}
+
+// withTag returns f with its compare tag replaced by value.
+func withTag(f reflect.StructField, value string) reflect.StructField {
+	f.Tag = reflect.StructTag(fmt.Sprintf("%s:%q", tagKey, value))
+	return f
+}
+
+// parseTagOf parses the compare tag of the field f of the struct type typ, respecting the tags
+// set via FieldTags.
+func parseTagOf(fieldTags map[reflect.Type]map[string]string, typ reflect.Type, f reflect.StructField) (fieldTag, error) {
+	if value, ok := fieldTags[typ][f.Name]; ok {
+		f = withTag(f, value)
+	}
+	return parseFieldTag(f)
+}
+
+// TagComparison registers compFunc as the comparison function named name, so fields tagged
+// with the name (e.g. `compare:"semver"`) are compared using it. This lets the authors of
+// struct types decide how to compare fields one by one. Registering a predefined name,
+// e.g. timestamp (see CompareTimestamps), overrides it. compFunc has to be a comparison
+// function (see Comparisons.AddFunc) for the types of all fields tagged with the name.
+// Comparing values with fields tagged with names of unregistered comparison functions fails.
+//
+// TagComparison panics if name is a directive or if compFunc is not a comparison function.
+func TagComparison(name string, compFunc interface{}) Option {
+	switch {
+	case name == "weight" || name == "ignore" || name == "redact" || name == "since" || name == "":
+		panic(fmt.Sprintf("invalid comparison name: %q", name))
+	case strings.ContainsAny(name, ",="):
+		panic(fmt.Sprintf("invalid comparison name: %q", name))
+	}
+	fv := reflect.ValueOf(compFunc)
+	if err := checkFunc(fv); err != nil {
+		panic(err)
+	}
+	return func(o *options) {
+		if o.tagComparisons == nil {
+			o.tagComparisons = make(map[string]reflect.Value)
+		}
+		o.tagComparisons[name] = fv
+	}
+}
+
+// tagComparison returns the comparison function of tagComparisons to compare the field f with
+// as named by tag, if any.
+func tagComparison(tagComparisons map[string]reflect.Value, f reflect.StructField, tag fieldTag) (reflect.Value, error) {
+	if tag.named == "" {
+		return reflect.Value{}, nil
+	}
+	fv, ok := tagComparisons[tag.named]
+	if !ok {
+		fv, ok = predefinedTagComparisons[tag.named]
+	}
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("field %s: unknown directive %q", f.Name, tag.named)
+	}
+	if in := fv.Type().In(0); in != f.Type {
+		return reflect.Value{}, fmt.Errorf("field %s: comparison %q compares %v, not %v", f.Name, tag.named, in, f.Type)
+	}
+	return fv, nil
+}
@@ -0,0 +1,195 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"fmt"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type Release struct {
+	Name    string
+	Major   int `compare:"weight=2"`
+	Minor   int `compare:"weight=1"`
+	Comment string
+}
+
+var _ = Describe("Tags", func() {
+	Describe("weight", func() {
+		It("should compare fields with higher weights first", func() {
+			c := make(Comparisons)
+			Expect(c.DeepCompare(
+				Release{Name: "a", Major: 2, Minor: 0},
+				Release{Name: "b", Major: 1, Minor: 5},
+			)).To(Equal(1))
+			Expect(c.DeepCompare(
+				Release{Name: "b", Major: 1, Minor: 5},
+				Release{Name: "a", Major: 1, Minor: 5},
+			)).To(Equal(1))
+		})
+
+		It("should report the deciding field", func() {
+			Expect(make(Comparisons).Explain(Release{Name: "a", Minor: 1}, Release{Name: "b"}).Path).To(Equal(".Minor"))
+		})
+
+		It("should error on invalid tags", func() {
+			type Invalid struct {
+				A int `compare:"weight=high"`
+			}
+			type Unknown struct {
+				A int `compare:"heavy"`
+			}
+			c := make(Comparisons)
+			_, err := c.TryDeepCompare(Invalid{}, Invalid{})
+			Expect(err).To(MatchError(ContainSubstring(`field A: invalid weight "high"`)))
+			_, err = c.TryDeepCompare(Unknown{}, Unknown{})
+			Expect(err).To(MatchError(ContainSubstring(`field A: unknown directive "heavy"`)))
+		})
+	})
+
+	Describe("ignore", func() {
+		It("should not compare ignored fields", func() {
+			type Cached struct {
+				Key   string
+				Cache map[string]int `compare:"ignore"`
+			}
+			Expect(make(Comparisons).DeepCompare(
+				Cached{Key: "a", Cache: map[string]int{"x": 1}},
+				Cached{Key: "a", Cache: map[string]int{"x": 2}},
+			)).To(Equal(0))
+		})
+	})
+
+	Describe("since", func() {
+		type Service struct {
+			Name     string
+			Port     int
+			Protocol string   `compare:"since=2"`
+			Labels   []string `compare:"since=3,weight=1"`
+		}
+		old := Service{Name: "a", Port: 80}
+		current := Service{Name: "a", Port: 80, Protocol: "TCP", Labels: []string{"x"}}
+
+		It("should ignore fields added after the compared version", func() {
+			cmp := make(Comparisons).NewComparer(AsOfVersion(1))
+			Expect(cmp.Compare(old, current)).To(Equal(0))
+			Expect(cmp.Compare(old, Service{Name: "a", Port: 81})).To(Equal(-1))
+		})
+
+		It("should compare fields added up to the compared version", func() {
+			cmp := make(Comparisons).NewComparer(AsOfVersion(2))
+			Expect(cmp.Compare(old, current)).To(Equal(-1))
+			Expect(cmp.Compare(Service{Protocol: "TCP"}, current)).To(Equal(-1))
+			Expect(cmp.Compare(Service{Name: "a", Port: 80, Protocol: "TCP"}, current)).To(Equal(0))
+		})
+
+		It("should compare all fields by default", func() {
+			Expect(make(Comparisons).DeepCompare(Service{Name: "a", Port: 80, Protocol: "TCP", Labels: []string{"a"}}, current)).To(Equal(-1))
+		})
+
+		It("should error on invalid versions", func() {
+			type Invalid struct {
+				A int `compare:"since=0"`
+			}
+			_, err := make(Comparisons).TryDeepCompare(Invalid{}, Invalid{})
+			Expect(err).To(MatchError(ContainSubstring(`field A: invalid version "0"`)))
+			Expect(func() { TagComparison("since", func(a, b int) int { return a - b }) }).To(Panic())
+		})
+	})
+
+	Describe("FieldTags", func() {
+		It("should replace the tags fields are declared with", func() {
+			cmp := make(Comparisons).NewComparer(FieldTags(Release{}, map[string]string{"Major": "", "Comment": "weight=3"}))
+			Expect(cmp.Compare(Release{Major: 2, Comment: "a"}, Release{Major: 1, Comment: "b"})).To(Equal(-1))
+			cmp = make(Comparisons).NewComparer(FieldTags(Release{}, map[string]string{"Major": "ignore", "Minor": "ignore"}))
+			Expect(cmp.Compare(Release{Name: "a", Major: 2}, Release{Name: "a", Minor: 1})).To(Equal(0))
+		})
+
+		It("should panic on invalid tags", func() {
+			Expect(func() { FieldTags(1, nil) }).To(Panic())
+			Expect(func() { FieldTags(Release{}, map[string]string{"Missing": ""}) }).To(Panic())
+			Expect(func() { FieldTags(Release{}, map[string]string{"Major": "weight=x"}) }).To(Panic())
+		})
+	})
+
+	Describe("comparison names", func() {
+		type Package struct {
+			Name    string
+			Version string `compare:"semver,weight=1"`
+		}
+		semver := func(a, b string) int {
+			parse := func(s string) (major, minor int) {
+				fmt.Sscanf(s, "%d.%d", &major, &minor)
+				return major, minor
+			}
+			major1, minor1 := parse(a)
+			major2, minor2 := parse(b)
+			if major1 != major2 {
+				return major1 - major2
+			}
+			return minor1 - minor2
+		}
+
+		It("should compare tagged fields using the named comparison function", func() {
+			cmp := make(Comparisons).NewComparer(TagComparison("semver", semver))
+			Expect(cmp.Compare(Package{Name: "a", Version: "1.10"}, Package{Name: "b", Version: "1.9"})).To(Equal(1))
+			Expect(cmp.Compare(Package{Name: "a", Version: "1.0"}, Package{Name: "b", Version: "01.0"})).To(Equal(-1))
+			Expect(cmp.ScanTypes(Package{})).To(Succeed())
+		})
+
+		It("should compare tagged fields using the named comparison function when diffing, matching and tracking", func() {
+			opt := TagComparison("semver", semver)
+			c := make(Comparisons)
+			base := Package{Version: "1.9"}
+			for p, n := range map[Package]int{{Version: "01.9"}: 0, {Version: "1.10"}: 1} {
+				p := p
+				res := c.NewComparer(opt).Compare(base, p)
+
+				diffs, err := c.Diff(base, p, opt)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(diffs).To(HaveLen(n))
+				Expect(c.Matches(base, p, opt)).To(Equal(res == 0))
+				Expect(c.NewTracker(&base, &p, opt).Compare()).To(Equal(res))
+				Expect(c.NewComparer(opt).CompareAt(".Version", base, p)).To(Equal(res))
+			}
+
+			diffs, err := c.Diff(base, Package{Version: "1.10"}, opt)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diffs[0].Comparator).NotTo(Equal("string"))
+		})
+
+		It("should error on unregistered names", func() {
+			_, err := make(Comparisons).TryDeepCompare(Package{}, Package{})
+			Expect(err).To(MatchError(ContainSubstring(`field Version: unknown directive "semver"`)))
+			Expect(make(Comparisons).ScanTypes(Package{})).To(HaveOccurred())
+			Expect(make(Comparisons).Coverage([]interface{}{Package{}}).Covered()).To(BeFalse())
+		})
+
+		It("should error on mismatching field types", func() {
+			cmp := make(Comparisons).NewComparer(TagComparison("semver", func(a, b int) int { return a - b }))
+			_, err := cmp.TryCompare(Package{}, Package{})
+			Expect(err).To(MatchError(ContainSubstring(`field Version: comparison "semver" compares int, not string`)))
+		})
+
+		It("should panic on invalid registrations", func() {
+			Expect(func() { TagComparison("ignore", semver) }).To(Panic())
+			Expect(func() { TagComparison("a=b", semver) }).To(Panic())
+			Expect(func() { TagComparison("semver", 1) }).To(Panic())
+		})
+	})
+})
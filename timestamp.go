@@ -0,0 +1,68 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"strings"
+	"time"
+)
+
+// timestampLayouts are the layouts of the timestamps CompareTimestamps parses, in order.
+// Timestamps without a zone are in UTC.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// parseTimestamp parses the RFC 3339 or ISO 8601 timestamp s.
+func parseTimestamp(s string) (time.Time, bool) {
+	// ISO 8601 allows separating date and time by a space, as well as lowercase designators.
+	if len(s) > 10 && (s[10] == ' ' || s[10] == 't') {
+		s = s[:10] + "T" + s[11:]
+	}
+	if strings.HasSuffix(s, "z") {
+		s = s[:len(s)-1] + "Z"
+	}
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// CompareTimestamps compares the RFC 3339 or ISO 8601 timestamps s1 and s2 by the instants
+// they denote, e.g. "2021-01-01T10:00:00+02:00" equals "2021-01-01T08:00:00Z", which is less
+// than "2021-01-01T09:00:00.5+01:00". Timestamps without a zone are in UTC.
+// Strings that are not timestamps are greater than timestamps and ordered lexically.
+//
+// CompareTimestamps is predefined as the comparison function named timestamp, so fields
+// tagged with `compare:"timestamp"` are compared using it, see TagComparison.
+func CompareTimestamps(s1, s2 string) int {
+	t1, ok1 := parseTimestamp(s1)
+	t2, ok2 := parseTimestamp(s2)
+	switch {
+	case ok1 && ok2:
+		return CompareTime(t1, t2)
+	case ok1 != ok2:
+		return compareBool(ok2, ok1)
+	default:
+		return strings.Compare(s1, s2)
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"strings"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+type LogEntry struct {
+	At      string `compare:"timestamp"`
+	Message string
+}
+
+var _ = Describe("CompareTimestamps", func() {
+	DescribeTable("should compare timestamps by instant",
+		func(s1, s2 string, expected int) {
+			Expect(CompareTimestamps(s1, s2)).To(Equal(expected))
+			Expect(CompareTimestamps(s2, s1)).To(Equal(-expected))
+		},
+		Entry("same zone", "2021-01-01T08:00:00Z", "2021-01-01T09:00:00Z", -1),
+		Entry("different zones", "2021-01-01T10:00:00+02:00", "2021-01-01T08:00:00Z", 0),
+		Entry("lexically reversed", "2021-01-01T09:30:00+01:00", "2021-01-01T08:45:00Z", -1),
+		Entry("fractional seconds", "2021-01-01T08:00:00.5Z", "2021-01-01T08:00:00Z", 1),
+		Entry("offsets without colon", "2021-01-01T10:00:00+0200", "2021-01-01T08:00:00Z", 0),
+		Entry("space separator", "2021-01-01 08:00:00Z", "2021-01-01T08:00:00Z", 0),
+		Entry("lowercase designators", "2021-01-01t08:00:00z", "2021-01-01T08:00:00Z", 0),
+		Entry("without zone", "2021-01-01T08:00:00", "2021-01-01T08:00:00Z", 0),
+		Entry("without seconds", "2021-01-01T08:00Z", "2021-01-01T08:00:00Z", 0),
+		Entry("dates", "2021-01-02", "2021-01-01T23:59:59Z", 1),
+		Entry("invalid after valid", "2021-01-01T08:00:00Z", "yesterday", -1),
+		Entry("invalid lexically", "today", "yesterday", -1),
+	)
+
+	It("should be predefined for tags", func() {
+		c := make(Comparisons)
+		Expect(c.DeepCompare(
+			LogEntry{At: "2021-01-01T10:00:00+02:00", Message: "a"},
+			LogEntry{At: "2021-01-01T08:00:00Z", Message: "a"},
+		)).To(Equal(0))
+		Expect(c.DeepCompare(
+			LogEntry{At: "2021-01-01T09:30:00+01:00"},
+			LogEntry{At: "2021-01-01T08:45:00Z"},
+		)).To(Equal(-1))
+		Expect(c.ScanTypes(LogEntry{})).To(Succeed())
+	})
+
+	It("should be overridden by registered comparison functions", func() {
+		cmp := make(Comparisons).NewComparer(TagComparison("timestamp", strings.Compare))
+		Expect(cmp.Compare(LogEntry{At: "2021-01-01T10:00:00+02:00"}, LogEntry{At: "2021-01-01T08:00:00Z"})).To(Equal(1))
+	})
+})
@@ -0,0 +1,193 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Tracker repeatedly compares a current value to a baseline while the current value is
+// mutated in place, recomparing only the fields reported as dirty. This makes reconciling
+// single fields of huge objects cheap, as unchanged fields are not traversed again.
+//
+// Results of struct fields are cached, recursively for fields of structs and of pointers
+// to structs. Fields of types with a comparison function, a CompareTo method, or scoped
+// options are compared as a whole.
+//
+// A Tracker is not safe for concurrent use.
+type Tracker struct {
+	traversal         *traversal
+	baseline, current reflect.Value
+	root              trackedNode
+}
+
+// trackedNode is the cached result of comparing a subtree of the tracked values.
+type trackedNode struct {
+	valid bool
+	res   int
+	// fields are the nodes of the fields of a struct, by field index.
+	fields map[int]*trackedNode
+}
+
+// NewTracker creates a Tracker comparing *baseline to *current, configured by the given options.
+// Nothing is compared until the first call to Compare.
+//
+// NewTracker panics if baseline and current are not non-nil pointers of the same type.
+func (c Comparisons) NewTracker(baseline, current interface{}, opts ...Option) *Tracker {
+	b := reflect.ValueOf(baseline)
+	cur := reflect.ValueOf(current)
+	if b.Kind() != reflect.Ptr || b.IsNil() || cur.Kind() != reflect.Ptr || cur.IsNil() {
+		panic(fmt.Sprintf("expected non-nil pointers, got: %T - %T", baseline, current))
+	}
+	if b.Type() != cur.Type() {
+		panic(fmt.Sprintf("cannot compare different types: %T - %T", baseline, current))
+	}
+	return &Tracker{
+		traversal: c.newTraversal(opts...),
+		baseline:  b.Elem(),
+		current:   cur.Elem(),
+	}
+}
+
+// Dirty reports that the fields denoted by the given dot-separated paths of field names
+// (e.g. 'Spec.Replicas') may have changed, so the next call to Compare recompares them.
+// The empty path reports that anything may have changed.
+//
+// Dirty panics if any path does not denote an exported field.
+func (tr *Tracker) Dirty(paths ...string) {
+	for _, path := range paths {
+		if path == "" {
+			tr.root = trackedNode{}
+			continue
+		}
+		p, err := resolveFieldPath(tr.baseline.Type(), path)
+		if err != nil {
+			panic(err)
+		}
+		n := &tr.root
+		for _, index := range p {
+			for _, i := range index {
+				n.valid = false
+				if n = n.fields[i]; n == nil {
+					break
+				}
+			}
+			if n == nil {
+				break
+			}
+		}
+		if n != nil {
+			*n = trackedNode{}
+		}
+	}
+}
+
+// Compare compares the baseline to the current value like Comparisons.DeepCompare,
+// reusing the results of fields that have not been reported as dirty since the last call.
+func (tr *Tracker) Compare() int {
+	t := tr.traversal
+	// Values may have been mutated in between comparisons, so don't reuse visited comparisons.
+	defer t.reset()
+	res, err := tr.compare(&tr.root, tr.baseline, tr.current, 0)
+	if err == nil && !t.partial {
+		res, err = totalOrder(res, tr.baseline.Type())
+	}
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+func (tr *Tracker) compare(n *trackedNode, v1, v2 reflect.Value, depth int) (int, error) {
+	field := tr.traversal.field
+	tr.traversal.field = structField{}
+	if n.valid {
+		return n.res, nil
+	}
+	v1, v2 = tr.traversal.applyDefaults(field, v1, v2)
+	if t := tr.traversal; field.redact && !t.redact {
+		prev := t.scope
+		t.setScope(prev.redactedChild())
+		defer t.setScope(prev)
+	}
+	var (
+		res int
+		err error
+	)
+	if typ, ok := tr.decomposable(v1, v2); ok && !field.compare.IsValid() {
+		res, err = tr.compareFields(n, typ, reflect.Indirect(v1), reflect.Indirect(v2), depth)
+	} else {
+		tr.traversal.field = field
+		res, err = tr.traversal.deepValueCompare(v1, v2, depth)
+	}
+	if err != nil {
+		return 0, err
+	}
+	n.valid, n.res = true, res
+	return res, nil
+}
+
+// decomposable reports whether v1 and v2 can be compared field by field, returning
+// the struct type to compare if so.
+func (tr *Tracker) decomposable(v1, v2 reflect.Value) (reflect.Type, bool) {
+	t := tr.traversal
+	typ := v1.Type()
+	if typ.Kind() == reflect.Ptr {
+		if v1.IsNil() || v2.IsNil() || !t.plain(typ) {
+			return nil, false
+		}
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct || !t.plain(typ) {
+		return nil, false
+	}
+	return typ, true
+}
+
+func (tr *Tracker) compareFields(n *trackedNode, typ reflect.Type, v1, v2 reflect.Value, depth int) (int, error) {
+	fields, err := tr.traversal.structFields(typ)
+	if err != nil {
+		return 0, err
+	}
+	if n.fields == nil {
+		n.fields = make(map[int]*trackedNode, len(fields))
+	}
+	for _, f := range fields {
+		child := n.fields[f.index]
+		if child == nil {
+			child = &trackedNode{}
+			n.fields[f.index] = child
+		}
+		tr.traversal.field = f
+		res, err := tr.compareAt(child, fieldElem(f.name), v1.Field(f.index), v2.Field(f.index), depth+1)
+		if err != nil {
+			return 0, prependStep(err, fieldStep(f.name))
+		}
+		if res != 0 {
+			return res, nil
+		}
+	}
+	return 0, nil
+}
+
+// compareAt compares v1 and v2 nested in the current values at the step s.
+func (tr *Tracker) compareAt(n *trackedNode, s pathStep, v1, v2 reflect.Value, depth int) (int, error) {
+	if t := tr.traversal; t.paths != nil {
+		t.enterStep(s)
+		defer t.leaveStep()
+	}
+	return tr.compare(n, v1, v2, depth)
+}
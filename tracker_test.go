@@ -0,0 +1,111 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tracker", func() {
+	type Status struct {
+		Ready    bool
+		Replicas int
+	}
+	type Resource struct {
+		Name   string
+		Spec   *Spec
+		Status Status
+	}
+
+	var (
+		calls int
+		c     Comparisons
+	)
+	BeforeEach(func() {
+		calls = 0
+		c = NewComparisonsOrDie(func(s1, s2 string) int {
+			calls++
+			switch {
+			case s1 < s2:
+				return -1
+			case s1 > s2:
+				return 1
+			}
+			return 0
+		})
+	})
+
+	newResources := func() (*Resource, *Resource) {
+		return &Resource{Name: "a", Spec: &Spec{Image: "nginx"}},
+			&Resource{Name: "a", Spec: &Spec{Image: "nginx"}}
+	}
+
+	It("should compare like DeepCompare", func() {
+		baseline, current := newResources()
+		tr := c.NewTracker(baseline, current)
+		Expect(tr.Compare()).To(Equal(0))
+		current.Status.Replicas = 2
+		tr.Dirty("Status.Replicas")
+		Expect(tr.Compare()).To(Equal(-1))
+		Expect(tr.Compare()).To(Equal(c.DeepCompare(baseline, current)))
+	})
+
+	It("should only recompare dirty fields", func() {
+		baseline, current := newResources()
+		tr := c.NewTracker(baseline, current)
+		Expect(tr.Compare()).To(Equal(0))
+		Expect(calls).To(Equal(2))
+
+		current.Status.Ready = true
+		tr.Dirty("Status.Ready")
+		Expect(tr.Compare()).To(Equal(-1))
+		Expect(calls).To(Equal(2))
+
+		current.Spec.Image = "alpine"
+		tr.Dirty("Spec.Image")
+		Expect(tr.Compare()).To(Equal(1))
+		Expect(calls).To(Equal(3))
+	})
+
+	It("should not notice changes of fields not reported as dirty", func() {
+		baseline, current := newResources()
+		tr := c.NewTracker(baseline, current)
+		Expect(tr.Compare()).To(Equal(0))
+		current.Name = "b"
+		Expect(tr.Compare()).To(Equal(0))
+		tr.Dirty("")
+		Expect(tr.Compare()).To(Equal(-1))
+	})
+
+	It("should handle replaced pointers", func() {
+		baseline, current := newResources()
+		tr := c.NewTracker(baseline, current)
+		Expect(tr.Compare()).To(Equal(0))
+		current.Spec = nil
+		tr.Dirty("Spec")
+		Expect(tr.Compare()).To(Equal(1))
+		current.Spec = &Spec{Image: "nginx"}
+		tr.Dirty("Spec")
+		Expect(tr.Compare()).To(Equal(0))
+	})
+
+	It("should panic on invalid arguments", func() {
+		Expect(func() { c.NewTracker(Resource{}, Resource{}) }).To(Panic())
+		Expect(func() { c.NewTracker(&Resource{}, &Spec{}) }).To(Panic())
+		Expect(func() { c.NewTracker(&Resource{}, &Resource{}).Dirty("Missing") }).To(Panic())
+	})
+})
@@ -0,0 +1,94 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"fmt"
+	"reflect"
+	"unicode/utf8"
+)
+
+// Truncated is a value truncated in a report, see Truncate.
+type Truncated struct {
+	// Value is the retained prefix of the value. Truncated arrays are retained as slices.
+	Value interface{}
+	// Len is the length of the value before truncating it.
+	Len int
+}
+
+// omitted returns the number of omitted bytes or elements and their unit.
+func (tr Truncated) omitted() (int, string) {
+	v := reflect.ValueOf(tr.Value)
+	if v.Kind() == reflect.String {
+		return tr.Len - v.Len(), "bytes"
+	}
+	return tr.Len - v.Len(), "elements"
+}
+
+// String implements fmt.Stringer.
+func (tr Truncated) String() string {
+	n, unit := tr.omitted()
+	return fmt.Sprintf("%v...(%d more %s)", tr.Value, n, unit)
+}
+
+// GoString implements fmt.GoStringer.
+func (tr Truncated) GoString() string {
+	n, unit := tr.omitted()
+	return fmt.Sprintf("%#v...(%d more %s)", tr.Value, n, unit)
+}
+
+// Truncate limits the size of the values reported by Diff and of the map keys in the paths
+// reported by Diff, Explain and errors: Strings longer than maxBytes and slices and arrays
+// with more than maxElements elements are reported as Truncated values holding their prefixes.
+// Strings are truncated at rune boundaries. A non-positive limit disables truncating the
+// respective values. Values are not truncated in JSON patches, nor are the values nested in
+// reported values, e.g. in structs reported as a whole.
+func Truncate(maxBytes, maxElements int) Option {
+	return func(o *options) {
+		o.maxBytes = maxBytes
+		o.maxElements = maxElements
+	}
+}
+
+// truncated returns v truncated to the limits set via Truncate, if it exceeds them.
+func (t *traversal) truncated(v reflect.Value) (Truncated, bool) {
+	if !v.IsValid() || !v.CanInterface() {
+		return Truncated{}, false
+	}
+	switch v.Kind() {
+	case reflect.String:
+		if t.maxBytes <= 0 || v.Len() <= t.maxBytes {
+			return Truncated{}, false
+		}
+		s, n := v.String(), t.maxBytes
+		for n > 0 && !utf8.RuneStart(s[n]) {
+			n--
+		}
+		return Truncated{Value: v.Slice(0, n).Interface(), Len: v.Len()}, true
+	case reflect.Slice:
+		if t.maxElements <= 0 || v.Len() <= t.maxElements {
+			return Truncated{}, false
+		}
+		return Truncated{Value: v.Slice(0, t.maxElements).Interface(), Len: v.Len()}, true
+	case reflect.Array:
+		if t.maxElements <= 0 || v.Len() <= t.maxElements {
+			return Truncated{}, false
+		}
+		prefix := reflect.MakeSlice(reflect.SliceOf(v.Type().Elem()), t.maxElements, t.maxElements)
+		reflect.Copy(prefix, v)
+		return Truncated{Value: prefix.Interface(), Len: v.Len()}, true
+	}
+	return Truncated{}, false
+}
@@ -0,0 +1,95 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type Blob struct {
+	Name string
+	Data []byte
+}
+
+var _ = Describe("Truncate", func() {
+	c := make(Comparisons)
+
+	It("should truncate long strings and slices", func() {
+		diffs, err := c.Diff(
+			Blob{Name: strings.Repeat("a", 10), Data: make([]byte, 100)},
+			Blob{Name: strings.Repeat("b", 10), Data: make([]byte, 200)},
+			Truncate(4, 2),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diffs).To(HaveLen(101))
+		Expect(diffs[0]).To(Equal(Difference{
+			Path: ".Name", A: Truncated{Value: "aaaa", Len: 10}, B: Truncated{Value: "bbbb", Len: 10},
+			Result: -1, Comparator: "string",
+		}))
+	})
+
+	It("should truncate values reported as a whole", func() {
+		diffs, err := c.Diff([][]int{{1}}, [][]int{{1}, {1, 2, 3}}, Truncate(0, 2))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diffs).To(Equal([]Difference{
+			{Path: "[1]", B: Truncated{Value: []int{1, 2}, Len: 3}, Result: -1, Comparator: "length"},
+		}))
+	})
+
+	It("should truncate arrays as slices", func() {
+		diffs, err := c.Diff([3]int{1, 2, 3}, [3]int{1, 2, 4}, Truncate(0, 1), MaxDifferences(1))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diffs).To(Equal([]Difference{{Path: "[2]", A: 3, B: 4, Result: -1, Comparator: "int"}}))
+
+		c := NewComparisonsOrDie(func(a, b [3]int) int { return a[2] - b[2] })
+		diffs, err = c.Diff([3]int{1, 2, 3}, [3]int{1, 2, 4}, Truncate(0, 1))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diffs[0].A).To(Equal(Truncated{Value: []int{1}, Len: 3}))
+	})
+
+	It("should truncate strings at rune boundaries", func() {
+		diffs, err := c.Diff("äöü", "äöx", Truncate(3, 0))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diffs[0].A).To(Equal(Truncated{Value: "ä", Len: 6}))
+	})
+
+	It("should truncate keys in paths", func() {
+		key := strings.Repeat("k", 10)
+		diffs, err := c.Diff(map[string]int{key: 1}, map[string]int{key: 2}, Truncate(2, 0))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diffs[0].Path).To(Equal(`["kk"...(8 more bytes)]`))
+
+		cmp := c.NewComparer(Truncate(2, 0))
+		Expect(cmp.Explain(map[string]int{key: 1}, map[string]int{key: 2}).Path).To(Equal(`["kk"...(8 more bytes)]`))
+	})
+
+	It("should not truncate by default", func() {
+		diffs, err := c.Diff(strings.Repeat("a", 1000), "b")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diffs[0].A).To(Equal(strings.Repeat("a", 1000)))
+	})
+
+	It("should render elision markers", func() {
+		tr := Truncated{Value: []int{1, 2}, Len: 5}
+		Expect(tr.String()).To(Equal("[1 2]...(3 more elements)"))
+		Expect(fmt.Sprintf("%#v", tr)).To(Equal("[]int{1, 2}...(3 more elements)"))
+		Expect(Truncated{Value: "ab", Len: 4}.String()).To(Equal("ab...(2 more bytes)"))
+	})
+})
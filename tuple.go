@@ -0,0 +1,41 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+// Tuple is a composite key of values, e.g. for sorting by several keys at once.
+// Tuples are ordered lexicographically by their elements, see Comparisons.CompareTuples.
+type Tuple []interface{}
+
+// T creates a Tuple of the given values.
+func T(values ...interface{}) Tuple {
+	return values
+}
+
+// CompareTuples compares t1 and t2 element by element via DeepCompare. The first unequal
+// elements decide; if one tuple is a prefix of the other, the shorter tuple is less.
+// Unlike comparing slices, which orders them by their lengths first, this allows
+// comparing tuples of different lengths lexicographically.
+//
+// CompareTuples panics if elements at the same position cannot be compared, e.g. because
+// their types differ.
+func (c Comparisons) CompareTuples(t1, t2 Tuple) int {
+	t := c.newTraversal()
+	for i := 0; i < len(t1) && i < len(t2); i++ {
+		if res := t.compare(t1[i], t2[i]); res != 0 {
+			return res
+		}
+	}
+	return compareInt64(int64(len(t1)), int64(len(t2)))
+}
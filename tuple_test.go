@@ -0,0 +1,51 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	"sort"
+
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tuple", func() {
+	c := make(Comparisons)
+
+	It("should compare tuples element by element", func() {
+		Expect(c.CompareTuples(T(1, "b", 2.0), T(1, "b", 2.0))).To(Equal(0))
+		Expect(c.CompareTuples(T(1, "b", 2.0), T(1, "c", 0.0))).To(Equal(-1))
+		Expect(c.CompareTuples(T(2, "a"), T(1, "z"))).To(Equal(1))
+	})
+
+	It("should order prefixes first", func() {
+		Expect(c.CompareTuples(T(1), T(1, "a"))).To(Equal(-1))
+		Expect(c.CompareTuples(T(2), T(1, "a"))).To(Equal(1))
+		Expect(c.CompareTuples(nil, T())).To(Equal(0))
+	})
+
+	It("should use comparison functions", func() {
+		c := make(Comparisons)
+		Expect(c.AddOrder(Low, High, Critical)).To(Succeed())
+		keys := []Tuple{T(Critical, 1), T(Low, 2), T(High, 0), T(Low, 1)}
+		sort.Slice(keys, func(i, j int) bool { return c.CompareTuples(keys[i], keys[j]) < 0 })
+		Expect(keys).To(Equal([]Tuple{T(Low, 1), T(Low, 2), T(High, 0), T(Critical, 1)}))
+	})
+
+	It("should panic on elements of different types", func() {
+		Expect(func() { c.CompareTuples(T(1, "a"), T(1, 2)) }).To(Panic())
+	})
+})
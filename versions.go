@@ -0,0 +1,61 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Versions registers the given version extractors. A version extractor is a func(T) V,
+// where V is comparable, e.g. func(o *Object) string { return o.ResourceVersion }.
+// Values of type T whose versions are equal are considered equal without traversing them.
+// Zero versions are considered unknown and never short-circuit comparisons.
+//
+// Versions panics if any argument is not a version extractor.
+func Versions(extractors ...interface{}) Option {
+	fvs := make([]reflect.Value, len(extractors))
+	for i, extractor := range extractors {
+		fv := reflect.ValueOf(extractor)
+		ft := fv.Type()
+		if ft.Kind() != reflect.Func || ft.NumIn() != 1 || ft.NumOut() != 1 || ft.IsVariadic() || !ft.Out(0).Comparable() {
+			panic(fmt.Sprintf("expected version extractor, got: %T", extractor))
+		}
+		fvs[i] = fv
+	}
+	return func(o *options) {
+		if o.versions == nil {
+			o.versions = make(map[reflect.Type]reflect.Value)
+		}
+		for _, fv := range fvs {
+			o.versions[fv.Type().In(0)] = fv
+		}
+	}
+}
+
+// sameVersion reports whether the version extractor fv extracts the same non-zero version of v1 and v2.
+func sameVersion(fv, v1, v2 reflect.Value) bool {
+	if !v1.CanInterface() || !v2.CanInterface() {
+		return false
+	}
+	if v1.Kind() == reflect.Ptr && (v1.IsNil() || v2.IsNil()) {
+		return false
+	}
+	ver1 := fv.Call([]reflect.Value{v1})[0]
+	if ver1.IsZero() {
+		return false
+	}
+	return ver1.Interface() == fv.Call([]reflect.Value{v2})[0].Interface()
+}
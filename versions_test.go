@@ -0,0 +1,58 @@
+// Copyright 2021 Axel Christ
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reflcompare_test
+
+import (
+	. "github.com/adracus/reflcompare"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Versions", func() {
+	type Resource struct {
+		Data            []int
+		ResourceVersion string
+	}
+	var traversed int
+	c := NewComparisonsOrDie(func(i1, i2 int) int {
+		traversed++
+		return i1 - i2
+	})
+	cmp := c.NewComparer(Versions(func(r *Resource) string { return r.ResourceVersion }))
+
+	BeforeEach(func() {
+		traversed = 0
+	})
+
+	It("should consider values with equal versions equal without traversing them", func() {
+		Expect(cmp.Compare(&Resource{[]int{1}, "1"}, &Resource{[]int{2}, "1"})).To(Equal(0))
+		Expect(traversed).To(Equal(0))
+	})
+
+	It("should traverse values with different versions", func() {
+		Expect(cmp.Compare(&Resource{[]int{1}, "1"}, &Resource{[]int{2}, "2"})).To(Equal(-1))
+		Expect(traversed).To(Equal(1))
+	})
+
+	It("should traverse values with zero versions", func() {
+		Expect(cmp.Compare(&Resource{[]int{1}, ""}, &Resource{[]int{2}, ""})).To(Equal(-1))
+		Expect(cmp.Compare((*Resource)(nil), &Resource{})).To(Equal(-1))
+	})
+
+	It("should panic on invalid extractors", func() {
+		Expect(func() { Versions(func(r *Resource) []int { return nil }) }).To(Panic())
+		Expect(func() { Versions(1) }).To(Panic())
+	})
+})